@@ -58,24 +58,115 @@ func run() error {
 	r.Use(middleware.Recoverer)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{"GET", "PUT", "POST", "DELETE"},
+		AllowedMethods: []string{"GET", "PUT", "PATCH", "POST", "DELETE"},
+		ExposedHeaders: []string{"Link", "X-Total-Count", "Accept-Patch"},
 		MaxAge:         300,
 	}))
 
 	courseSvs := services.NewCourseService(db)
 	personSvs := services.NewPersonService(db)
+	enrollmentSvs := services.NewEnrollmentService(db)
+	termSvs := services.NewTermService(db)
+	gradeSvs := services.NewGradeService(db)
+	departmentSvs := services.NewDepartmentService(db)
+	roomSvs := services.NewRoomService(db)
+	attendanceSvs := services.NewAttendanceService(db)
+	assignmentSvs := services.NewAssignmentService(db)
+	programSvs := services.NewProgramService(db)
 	r.Route("/api", func(r chi.Router) {
 		r.Route("/course", func(r chi.Router) {
 			r.Get("/", handlers.HandleGetCourses(logger, courseSvs))
 			r.Get("/{id}", handlers.HandleGetCourse(logger, courseSvs))
+			r.Get("/by-code/{code}", handlers.HandleGetCourseByCode(logger, courseSvs))
 			r.Put("/{id}", handlers.HandleUpdateCourse(logger, courseSvs))
+			r.Patch("/{id}", handlers.HandlePatchCourse(logger, courseSvs))
 			r.Post("/", handlers.HandleCreateCourse(logger, courseSvs))
 			r.Delete("/{id}", handlers.HandleDeleteCourse(logger, courseSvs))
+			r.Get("/{id}/roster", handlers.HandleGetCourseRoster(logger, courseSvs))
+			r.Get("/{id}/prerequisites", handlers.HandleGetPrerequisites(logger, courseSvs))
+			r.Put("/{id}/prerequisites/{prerequisiteID}", handlers.HandleAddPrerequisite(logger, courseSvs))
+			r.Delete("/{id}/prerequisites/{prerequisiteID}", handlers.HandleRemovePrerequisite(logger, courseSvs))
+			r.Get("/{id}/enrollments", handlers.HandleGetCourseEnrollments(logger, enrollmentSvs))
+			r.Post("/{id}/enrollments", handlers.HandleCreateCourseEnrollment(logger, enrollmentSvs))
+			r.Delete("/{id}/enrollments/{personID}", handlers.HandleDeleteCourseEnrollment(logger, enrollmentSvs))
+			r.Get("/{id}/waitlist", handlers.HandleGetCourseWaitlist(logger, enrollmentSvs))
+			r.Get("/{id}/grades", handlers.HandleGetCourseGrades(logger, gradeSvs))
+			r.Put("/{id}/grades", handlers.HandlePostCourseGrades(logger, gradeSvs))
+			r.Get("/{id}/sessions", handlers.HandleGetSessions(logger, attendanceSvs))
+			r.Post("/{id}/sessions", handlers.HandleCreateSession(logger, attendanceSvs))
+			r.Put("/{id}/sessions/{sessionID}", handlers.HandleUpdateSession(logger, attendanceSvs))
+			r.Delete("/{id}/sessions/{sessionID}", handlers.HandleDeleteSession(logger, attendanceSvs))
+			r.Get("/{id}/sessions/{sessionID}/attendance", handlers.HandleGetAttendance(logger, attendanceSvs))
+			r.Put("/{id}/sessions/{sessionID}/attendance", handlers.HandleRecordAttendance(logger, attendanceSvs))
+			r.Get("/{id}/attendance", handlers.HandleGetCourseAttendance(logger, attendanceSvs))
+			r.Get("/{id}/assignments", handlers.HandleGetAssignments(logger, assignmentSvs))
+			r.Post("/{id}/assignments", handlers.HandleCreateAssignment(logger, assignmentSvs))
+			r.Get("/{id}/assignments/{assignmentID}", handlers.HandleGetAssignment(logger, assignmentSvs))
+			r.Put("/{id}/assignments/{assignmentID}", handlers.HandleUpdateAssignment(logger, assignmentSvs))
+			r.Delete("/{id}/assignments/{assignmentID}", handlers.HandleDeleteAssignment(logger, assignmentSvs))
+			r.Get("/{id}/assignments/{assignmentID}/submissions", handlers.HandleGetSubmissions(logger, assignmentSvs))
+			r.Post("/{id}/assignments/{assignmentID}/submissions", handlers.HandleSubmit(logger, assignmentSvs))
+			r.Put("/{id}/assignments/{assignmentID}/submissions/{personID}/score", handlers.HandleScoreSubmission(logger, assignmentSvs))
+		})
+		r.Route("/term", func(r chi.Router) {
+			r.Get("/", handlers.HandleGetTerms(logger, termSvs))
+			r.Get("/{id}", handlers.HandleGetTerm(logger, termSvs))
+			r.Put("/{id}", handlers.HandleUpdateTerm(logger, termSvs))
+			r.Post("/", handlers.HandleCreateTerm(logger, termSvs))
+			r.Delete("/{id}", handlers.HandleDeleteTerm(logger, termSvs))
+		})
+		r.Route("/department", func(r chi.Router) {
+			r.Get("/", handlers.HandleGetDepartments(logger, departmentSvs))
+			r.Get("/{id}", handlers.HandleGetDepartment(logger, departmentSvs))
+			r.Put("/{id}", handlers.HandleUpdateDepartment(logger, departmentSvs))
+			r.Post("/", handlers.HandleCreateDepartment(logger, departmentSvs))
+			r.Delete("/{id}", handlers.HandleDeleteDepartment(logger, departmentSvs))
+		})
+		r.Route("/room", func(r chi.Router) {
+			r.Get("/", handlers.HandleGetRooms(logger, roomSvs))
+			r.Get("/{id}", handlers.HandleGetRoom(logger, roomSvs))
+			r.Put("/{id}", handlers.HandleUpdateRoom(logger, roomSvs))
+			r.Post("/", handlers.HandleCreateRoom(logger, roomSvs))
+			r.Delete("/{id}", handlers.HandleDeleteRoom(logger, roomSvs))
+			r.Get("/{id}/bookings", handlers.HandleGetRoomBookings(logger, roomSvs))
+		})
+		r.Route("/program", func(r chi.Router) {
+			r.Get("/", handlers.HandleGetPrograms(logger, programSvs))
+			r.Get("/{id}", handlers.HandleGetProgram(logger, programSvs))
+			r.Put("/{id}", handlers.HandleUpdateProgram(logger, programSvs))
+			r.Post("/", handlers.HandleCreateProgram(logger, programSvs))
+			r.Delete("/{id}", handlers.HandleDeleteProgram(logger, programSvs))
+		})
+		r.Route("/grade-scale", func(r chi.Router) {
+			r.Get("/", handlers.HandleGetGradeScale(logger, gradeSvs))
+			r.Put("/{letter}", handlers.HandleSetGradeScaleEntry(logger, gradeSvs))
+			r.Delete("/{letter}", handlers.HandleDeleteGradeScaleEntry(logger, gradeSvs))
+		})
+		r.Route("/person", func(r chi.Router) {
+			r.Get("/", handlers.HandleGetPeople(logger, personSvs))
+			r.Post("/", handlers.HandleCreatePerson(logger, personSvs))
+			r.Get("/{id}", handlers.HandleGetPerson(logger, personSvs))
+			r.Put("/{id}", handlers.HandleUpdatePerson(logger, personSvs))
+			r.Patch("/{id}", handlers.HandlePatchPerson(logger, personSvs))
+			r.Delete("/{id}", handlers.HandleDeletePerson(logger, personSvs))
+			r.Get("/{id}/courses", handlers.HandleGetPersonEnrollments(logger, enrollmentSvs))
+			r.Post("/{id}/courses", handlers.HandleCreatePersonEnrollment(logger, enrollmentSvs))
+			r.Delete("/{id}/courses/{courseID}", handlers.HandleDeletePersonEnrollment(logger, enrollmentSvs))
+			r.Get("/{id}/transcript", handlers.HandleGetTranscript(logger, gradeSvs))
+			r.Get("/{id}/attendance", handlers.HandleGetPersonAttendance(logger, attendanceSvs))
+			r.Get("/{id}/advisor", handlers.HandleGetAdvisor(logger, personSvs))
+			r.Put("/{id}/advisor", handlers.HandleAssignAdvisor(logger, personSvs))
+			r.Delete("/{id}/advisor", handlers.HandleRemoveAdvisor(logger, personSvs))
+			r.Get("/{id}/program", handlers.HandleGetDeclaration(logger, programSvs))
+			r.Put("/{id}/program", handlers.HandleDeclareProgram(logger, programSvs))
+			r.Delete("/{id}/program", handlers.HandleRemoveDeclaration(logger, programSvs))
+			r.Get("/{id}/degree-audit", handlers.HandleGetDegreeAudit(logger, programSvs))
 		})
 		r.Route("/student", func(r chi.Router) {
 			r.Get("/", handlers.HandleGetStudents(logger, personSvs))
 			r.Get("/{firstName}", handlers.HandleGetStudent(logger, personSvs))
 			r.Put("/{firstName}", handlers.HandleUpdateStudent(logger, personSvs))
+			r.Patch("/{firstName}", handlers.HandlePatchStudent(logger, personSvs))
 			r.Post("/", handlers.HandleCreateStudent(logger, personSvs))
 			r.Delete("/{firstName}", handlers.HandleDeleteStudent(logger, personSvs))
 		})
@@ -83,8 +174,10 @@ func run() error {
 			r.Get("/", handlers.HandleGetProfessors(logger, personSvs))
 			r.Get("/{firstName}", handlers.HandleGetProfessor(logger, personSvs))
 			r.Put("/{firstName}", handlers.HandleUpdateProfessor(logger, personSvs))
+			r.Patch("/{firstName}", handlers.HandlePatchProfessor(logger, personSvs))
 			r.Post("/", handlers.HandleCreateProfessor(logger, personSvs))
 			r.Delete("/{firstName}", handlers.HandleDeleteProfessor(logger, personSvs))
+			r.Get("/{id}/advisees", handlers.HandleGetAdvisees(logger, personSvs))
 		})
 	})
 
@@ -1,59 +1,388 @@
+DROP TABLE IF EXISTS student_program;
+DROP TABLE IF EXISTS elective_pool_course;
+DROP TABLE IF EXISTS elective_pool;
+DROP TABLE IF EXISTS program_course;
+DROP TABLE IF EXISTS program;
+DROP TABLE IF EXISTS submission;
+DROP TABLE IF EXISTS assignment;
+DROP TABLE IF EXISTS attendance;
+DROP TABLE IF EXISTS class_session;
+DROP TABLE IF EXISTS student_advisor;
+DROP TABLE IF EXISTS course_waitlist;
+DROP TABLE IF EXISTS course_prerequisite;
+DROP TABLE IF EXISTS course_meeting;
+DROP TABLE IF EXISTS room;
 DROP TABLE IF EXISTS person_course;
+DROP TABLE IF EXISTS grade_scale;
+DROP TABLE IF EXISTS term;
 DROP TABLE IF EXISTS course;
+DROP TABLE IF EXISTS department;
 DROP TABLE IF EXISTS person;
 
 -- person
 CREATE TABLE person
 (
-    id         SERIAL PRIMARY KEY,
-    first_name TEXT                                          NOT NULL,
-    last_name  TEXT                                          NOT NULL,
-    type       TEXT CHECK (type IN ('professor', 'student')) NOT NULL,
-    age        INTEGER                                       NOT NULL
+    id          SERIAL PRIMARY KEY,
+    first_name  TEXT                                          NOT NULL,
+    last_name   TEXT                                          NOT NULL,
+    type        TEXT CHECK (type IN ('professor', 'student')) NOT NULL,
+    age         INTEGER                                       NOT NULL,
+    -- NULL uses the API's default credit load limit.
+    max_credits INTEGER CHECK (max_credits > 0)
 );
 
-INSERT INTO person (first_name, last_name, type, age)
-VALUES ('Steve', 'Jobs', 'professor', 56),
-       ('Jeff', 'Bezos', 'professor', 60),
-       ('Larry', 'Page', 'student', 51),
-       ('Bill', 'Gates', 'student', 67),
-       ('Elon', 'Musk', 'student', 52);
+INSERT INTO person (first_name, last_name, type, age, max_credits)
+VALUES ('Steve', 'Jobs', 'professor', 56, NULL),
+       ('Jeff', 'Bezos', 'professor', 60, NULL),
+       ('Larry', 'Page', 'student', 51, NULL),
+       ('Bill', 'Gates', 'student', 67, 12),
+       ('Elon', 'Musk', 'student', 52, 21);
 
--- course
-CREATE TABLE course
+-- department
+CREATE TABLE department
 (
     id   SERIAL PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE,
     name TEXT NOT NULL
 );
 
-INSERT INTO course (name)
-VALUES ('Programming'),
-       ('Databases'),
-       ('UI Design');
+INSERT INTO department (code, name)
+VALUES ('CS', 'Computer Science'),
+       ('DES', 'Design');
+
+-- course
+CREATE TABLE course
+(
+    id            SERIAL PRIMARY KEY,
+    code          TEXT NOT NULL UNIQUE,
+    name          TEXT NOT NULL,
+    department_id INTEGER,
+    capacity      INTEGER CHECK (capacity > 0),
+    credits       INTEGER NOT NULL DEFAULT 0 CHECK (credits >= 0),
+    FOREIGN KEY (department_id) REFERENCES department (id)
+);
+
+INSERT INTO course (code, name, department_id, credits)
+VALUES ('CS-101', 'Programming', 1, 4),
+       ('CS-201', 'Databases', 1, 3),
+       ('DES-110', 'UI Design', 2, 3);
+
+-- room
+CREATE TABLE room
+(
+    id       SERIAL PRIMARY KEY,
+    building TEXT    NOT NULL,
+    number   TEXT    NOT NULL,
+    seats    INTEGER NOT NULL CHECK (seats > 0),
+    features TEXT[]  NOT NULL DEFAULT '{}',
+    UNIQUE (building, number)
+);
+
+INSERT INTO room (building, number, seats, features)
+VALUES ('Main', 'Hall A', 120, ARRAY ['projector', 'microphone']),
+       ('Science', 'Lab 1', 24, ARRAY ['computers']),
+       ('Main', '204', 40, ARRAY ['projector']),
+       ('Arts', 'Studio 3', 2, ARRAY ['drawing tables']);
+
+-- course_meeting
+-- A weekly meeting pattern of a course. end_time is exclusive. A room is
+-- never booked for two overlapping meetings; the API checks this.
+CREATE TABLE course_meeting
+(
+    id         SERIAL PRIMARY KEY,
+    course_id  INTEGER NOT NULL,
+    days       TEXT[]  NOT NULL CHECK (
+        cardinality(days) > 0 AND days <@ ARRAY ['mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun']
+    ),
+    start_time TIME    NOT NULL,
+    end_time   TIME    NOT NULL,
+    location   TEXT    NOT NULL DEFAULT '',
+    room_id    INTEGER,
+    CHECK (end_time > start_time),
+    FOREIGN KEY (course_id) REFERENCES course (id) ON DELETE CASCADE,
+    FOREIGN KEY (room_id) REFERENCES room (id)
+);
+
+INSERT INTO course_meeting (course_id, days, start_time, end_time, location, room_id)
+VALUES (1, ARRAY ['mon', 'wed'], '09:00', '10:30', 'Hall A', 1),
+       (1, ARRAY ['fri'], '09:00', '10:00', 'Lab 1', 2),
+       (2, ARRAY ['tue', 'thu'], '13:00', '14:30', 'Room 204', 3),
+       (3, ARRAY ['mon', 'wed'], '11:00', '12:30', 'Studio 3', 4);
+
+-- course_prerequisite
+-- prerequisite_id must be completed before course_id can be taken. The
+-- graph is kept acyclic by the API.
+CREATE TABLE course_prerequisite
+(
+    course_id       INTEGER NOT NULL,
+    prerequisite_id INTEGER NOT NULL,
+    PRIMARY KEY (course_id, prerequisite_id),
+    CHECK (course_id <> prerequisite_id),
+    FOREIGN KEY (course_id) REFERENCES course (id) ON DELETE CASCADE,
+    FOREIGN KEY (prerequisite_id) REFERENCES course (id) ON DELETE CASCADE
+);
+
+INSERT INTO course_prerequisite (course_id, prerequisite_id)
+VALUES (2, 1);
+
+-- term
+CREATE TABLE term
+(
+    id         SERIAL PRIMARY KEY,
+    name       TEXT NOT NULL UNIQUE,
+    start_date DATE NOT NULL,
+    end_date   DATE NOT NULL,
+    CHECK (end_date >= start_date)
+);
+
+INSERT INTO term (name, start_date, end_date)
+VALUES ('Spring 2026', '2026-01-12', '2026-05-08'),
+       ('Fall 2026', '2026-08-31', '2026-12-18'),
+       ('Spring 2027', '2027-01-11', '2027-05-07');
+
+-- grade_scale
+-- Letters without points, such as W, do not count towards a GPA.
+CREATE TABLE grade_scale
+(
+    letter TEXT PRIMARY KEY,
+    points NUMERIC(3, 2) CHECK (points >= 0)
+);
+
+INSERT INTO grade_scale (letter, points)
+VALUES ('A', 4.0),
+       ('A-', 3.7),
+       ('B+', 3.3),
+       ('B', 3.0),
+       ('B-', 2.7),
+       ('C+', 2.3),
+       ('C', 2.0),
+       ('C-', 1.7),
+       ('D', 1.0),
+       ('F', 0.0),
+       ('W', NULL);
 
 -- person_course
+-- term_id is NULL for enrollments not tied to a term. A person can take the
+-- same course again in a later term. Instructors and co-instructors are
+-- professors; the API checks this when people are enrolled.
 CREATE TABLE person_course
 (
-    person_id INTEGER NOT NULL,
-    course_id INTEGER NOT NULL,
-    PRIMARY KEY (person_id, course_id),
+    person_id    INTEGER     NOT NULL,
+    course_id    INTEGER     NOT NULL,
+    term_id      INTEGER,
+    role         TEXT        NOT NULL DEFAULT 'student'
+        CHECK (role IN ('instructor', 'co_instructor', 'teaching_assistant', 'student')),
+    enrolled_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    grade        TEXT,
+    grade_points NUMERIC(3, 2),
+    UNIQUE NULLS NOT DISTINCT (person_id, course_id, term_id),
+    FOREIGN KEY (person_id) REFERENCES person (id),
+    FOREIGN KEY (course_id) REFERENCES course (id),
+    FOREIGN KEY (term_id) REFERENCES term (id),
+    FOREIGN KEY (grade) REFERENCES grade_scale (letter) ON UPDATE CASCADE
+);
+
+INSERT INTO person_course (person_id, course_id, term_id, role)
+VALUES (1, 1, 2, 'instructor'),
+       (1, 2, 2, 'instructor'),
+       (1, 3, 2, 'co_instructor'),
+       (2, 1, 2, 'co_instructor'),
+       (2, 2, 2, 'co_instructor'),
+       (2, 3, 2, 'instructor'),
+       (3, 1, 2, 'student'),
+       (3, 2, 2, 'student'),
+       (3, 3, 2, 'student'),
+       (4, 1, 2, 'student'),
+       (4, 2, 2, 'student'),
+       (4, 3, 2, 'student'),
+       (5, 1, 2, 'student'),
+       (5, 2, 2, 'student'),
+       (5, 3, 2, 'student');
+
+-- course_waitlist
+CREATE TABLE course_waitlist
+(
+    id            SERIAL PRIMARY KEY,
+    course_id     INTEGER     NOT NULL,
+    person_id     INTEGER     NOT NULL,
+    term_id       INTEGER,
+    waitlisted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE NULLS NOT DISTINCT (course_id, person_id, term_id),
     FOREIGN KEY (person_id) REFERENCES person (id),
+    FOREIGN KEY (course_id) REFERENCES course (id),
+    FOREIGN KEY (term_id) REFERENCES term (id)
+);
+
+-- student_advisor
+CREATE TABLE student_advisor
+(
+    student_id  INTEGER PRIMARY KEY,
+    advisor_id  INTEGER     NOT NULL,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (student_id) REFERENCES person (id),
+    FOREIGN KEY (advisor_id) REFERENCES person (id)
+);
+
+INSERT INTO student_advisor (student_id, advisor_id)
+VALUES (3, 1),
+       (4, 1),
+       (5, 2);
+
+-- class_session
+-- A single class of a course, taken from the students of the course in its
+-- term. Deleting a course deletes its sessions and their attendance.
+CREATE TABLE class_session
+(
+    id        SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    term_id   INTEGER,
+    date      DATE    NOT NULL,
+    topic     TEXT    NOT NULL DEFAULT '',
+    FOREIGN KEY (course_id) REFERENCES course (id) ON DELETE CASCADE,
+    FOREIGN KEY (term_id) REFERENCES term (id)
+);
+
+INSERT INTO class_session (course_id, term_id, date, topic)
+VALUES (1, 2, '2026-09-01', 'Introduction'),
+       (1, 2, '2026-09-03', 'Variables and types'),
+       (2, 2, '2026-09-02', 'Relational model');
+
+-- attendance
+CREATE TABLE attendance
+(
+    session_id INTEGER NOT NULL,
+    person_id  INTEGER NOT NULL,
+    status     TEXT    NOT NULL CHECK (status IN ('present', 'absent', 'late', 'excused')),
+    PRIMARY KEY (session_id, person_id),
+    FOREIGN KEY (session_id) REFERENCES class_session (id) ON DELETE CASCADE,
+    FOREIGN KEY (person_id) REFERENCES person (id) ON DELETE CASCADE
+);
+
+INSERT INTO attendance (session_id, person_id, status)
+VALUES (1, 3, 'present'),
+       (1, 4, 'present'),
+       (1, 5, 'late'),
+       (2, 3, 'present'),
+       (2, 4, 'absent'),
+       (2, 5, 'excused'),
+       (3, 3, 'present'),
+       (3, 4, 'present'),
+       (3, 5, 'absent');
+
+-- assignment
+-- Deleting a course deletes its assignments and their submissions.
+CREATE TABLE assignment
+(
+    id         SERIAL PRIMARY KEY,
+    course_id  INTEGER       NOT NULL,
+    term_id    INTEGER,
+    title      TEXT          NOT NULL,
+    due_at     TIMESTAMPTZ   NOT NULL,
+    max_points NUMERIC(6, 2) NOT NULL CHECK (max_points > 0),
+    FOREIGN KEY (course_id) REFERENCES course (id) ON DELETE CASCADE,
+    FOREIGN KEY (term_id) REFERENCES term (id)
+);
+
+INSERT INTO assignment (course_id, term_id, title, due_at, max_points)
+VALUES (1, 2, 'Homework 1', '2026-09-14 23:59:00+00', 100),
+       (1, 2, 'Project proposal', '2026-10-02 17:00:00+00', 20),
+       (2, 2, 'Schema design', '2026-09-21 23:59:00+00', 50);
+
+-- submission
+-- One submission per student and assignment. Whether it is late is derived
+-- from submitted_at and the due date of the assignment.
+CREATE TABLE submission
+(
+    assignment_id INTEGER     NOT NULL,
+    person_id     INTEGER     NOT NULL,
+    content       TEXT        NOT NULL DEFAULT '',
+    file_ref      TEXT        NOT NULL DEFAULT '',
+    submitted_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    score         NUMERIC(6, 2) CHECK (score >= 0),
+    scored_by     INTEGER,
+    scored_at     TIMESTAMPTZ,
+    PRIMARY KEY (assignment_id, person_id),
+    FOREIGN KEY (assignment_id) REFERENCES assignment (id) ON DELETE CASCADE,
+    FOREIGN KEY (person_id) REFERENCES person (id) ON DELETE CASCADE,
+    FOREIGN KEY (scored_by) REFERENCES person (id) ON DELETE SET NULL
+);
+
+INSERT INTO submission (assignment_id, person_id, content, file_ref, submitted_at, score, scored_by, scored_at)
+VALUES (1, 3, 'See attached solutions.', 'uploads/hw1-3.pdf', '2026-09-13 20:15:00+00', 95, 1, '2026-09-16 10:00:00+00'),
+       (1, 4, '', 'uploads/hw1-4.pdf', '2026-09-15 08:30:00+00', NULL, NULL, NULL),
+       (3, 5, 'Normalized to 3NF.', '', '2026-09-20 12:00:00+00', NULL, NULL, NULL);
+
+-- program
+CREATE TABLE program
+(
+    id   SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE
+);
+
+INSERT INTO program (name)
+VALUES ('Computer Science'),
+       ('Interaction Design');
+
+-- program_course
+-- The courses every student of a program must complete. Courses a program
+-- requires cannot be deleted.
+CREATE TABLE program_course
+(
+    program_id INTEGER NOT NULL,
+    course_id  INTEGER NOT NULL,
+    PRIMARY KEY (program_id, course_id),
+    FOREIGN KEY (program_id) REFERENCES program (id) ON DELETE CASCADE,
     FOREIGN KEY (course_id) REFERENCES course (id)
 );
 
-INSERT INTO person_course (person_id, course_id)
+INSERT INTO program_course (program_id, course_id)
 VALUES (1, 1),
        (1, 2),
-       (1, 3),
+       (2, 3);
+
+-- elective_pool
+CREATE TABLE elective_pool
+(
+    id          SERIAL PRIMARY KEY,
+    program_id  INTEGER NOT NULL,
+    name        TEXT    NOT NULL,
+    min_credits INTEGER NOT NULL CHECK (min_credits > 0),
+    UNIQUE (program_id, name),
+    FOREIGN KEY (program_id) REFERENCES program (id) ON DELETE CASCADE
+);
+
+INSERT INTO elective_pool (program_id, name, min_credits)
+VALUES (1, 'Design electives', 3),
+       (2, 'Technical electives', 4);
+
+-- elective_pool_course
+CREATE TABLE elective_pool_course
+(
+    pool_id   INTEGER NOT NULL,
+    course_id INTEGER NOT NULL,
+    PRIMARY KEY (pool_id, course_id),
+    FOREIGN KEY (pool_id) REFERENCES elective_pool (id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES course (id)
+);
+
+INSERT INTO elective_pool_course (pool_id, course_id)
+VALUES (1, 3),
        (2, 1),
-       (2, 2),
-       (2, 3),
-       (3, 1),
-       (3, 2),
-       (3, 3),
+       (2, 2);
+
+-- student_program
+-- The program each student declared. Programs that students declared cannot
+-- be deleted.
+CREATE TABLE student_program
+(
+    student_id  INTEGER PRIMARY KEY,
+    program_id  INTEGER     NOT NULL,
+    declared_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (student_id) REFERENCES person (id) ON DELETE CASCADE,
+    FOREIGN KEY (program_id) REFERENCES program (id)
+);
+
+INSERT INTO student_program (student_id, program_id)
+VALUES (3, 1),
        (4, 1),
-       (4, 2),
-       (4, 3),
-       (5, 1),
-       (5, 2),
-       (5, 3);
\ No newline at end of file
+       (5, 2);
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/go-chi/httplog/v2"
+)
+
+type advisorManager interface {
+	GetAdvisor(ctx context.Context, studentID int) (models.Person, error)
+	GetAdvisees(ctx context.Context, professorID int) ([]models.Person, error)
+	AssignAdvisor(ctx context.Context, studentID, advisorID int) (models.Advising, error)
+	RemoveAdvisor(ctx context.Context, studentID int) error
+}
+
+func HandleGetAdvisor(logger *httplog.Logger, service advisorManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		advisor, err := service.GetAdvisor(r.Context(), studentID)
+		if err != nil {
+			logger.Error("error getting advisor", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, advisor)
+	}
+}
+
+// HandleAssignAdvisor makes the professor named by advisor_id in a body such
+// as {"advisor_id": 1} the advisor of the student in the URL, replacing any
+// advisor the student had.
+func HandleAssignAdvisor(logger *httplog.Logger, service advisorManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		var advising models.Advising
+		if err := json.NewDecoder(r.Body).Decode(&advising); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if advising.AdvisorID <= 0 {
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "advisor id must be a positive number", FieldError{Field: "advisor_id", Code: utils.CodeRequired, Detail: "advisor id must be a positive number"})
+			return
+		}
+
+		advising, err := service.AssignAdvisor(r.Context(), studentID, advising.AdvisorID)
+		if err != nil {
+			logger.Error("error assigning advisor", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, advising)
+	}
+}
+
+func HandleRemoveAdvisor(logger *httplog.Logger, service advisorManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		if err := service.RemoveAdvisor(r.Context(), studentID); err != nil {
+			logger.Error("error removing advisor", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Advisor has successfully been removed")
+	}
+}
+
+// HandleGetAdvisees lists the students advised by the professor with the ID
+// in the URL.
+func HandleGetAdvisees(logger *httplog.Logger, service advisorManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		professorID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid professor ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid professor ID", errs...)
+			return
+		}
+
+		advisees, err := service.GetAdvisees(r.Context(), professorID)
+		if err != nil {
+			logger.Error("error getting advisees", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, advisees)
+	}
+}
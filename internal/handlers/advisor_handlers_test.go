@@ -0,0 +1,219 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAdvisorManager struct {
+	mock.Mock
+}
+
+func (m *mockAdvisorManager) GetAdvisor(ctx context.Context, studentID int) (models.Person, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(models.Person), args.Error(1)
+}
+
+func (m *mockAdvisorManager) GetAdvisees(ctx context.Context, professorID int) ([]models.Person, error) {
+	args := m.Called(ctx, professorID)
+	return args.Get(0).([]models.Person), args.Error(1)
+}
+
+func (m *mockAdvisorManager) AssignAdvisor(ctx context.Context, studentID, advisorID int) (models.Advising, error) {
+	args := m.Called(ctx, studentID, advisorID)
+	return args.Get(0).(models.Advising), args.Error(1)
+}
+
+func (m *mockAdvisorManager) RemoveAdvisor(ctx context.Context, studentID int) error {
+	args := m.Called(ctx, studentID)
+	return args.Error(0)
+}
+
+func newAdvisorRouter(service *mockAdvisorManager) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/person/{id}/advisor", handlers.HandleGetAdvisor(logger, service))
+	r.Put("/api/person/{id}/advisor", handlers.HandleAssignAdvisor(logger, service))
+	r.Delete("/api/person/{id}/advisor", handlers.HandleRemoveAdvisor(logger, service))
+	r.Get("/api/professor/{id}/advisees", handlers.HandleGetAdvisees(logger, service))
+	return r
+}
+
+func TestHandleGetAdvisor(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		mockAdvisor    models.Person
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			url:            "/api/person/3/advisor",
+			mockAdvisor:    models.Person{ID: 1, FirstName: "Steve", LastName: "Jobs", Type: "professor"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "No Advisor",
+			url:            "/api/person/3/advisor",
+			mockError:      fmt.Errorf("no advisor: %w", services.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid ID",
+			url:            "/api/person/abc/advisor",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockAdvisorManager)
+			if tt.expectedStatus != http.StatusBadRequest {
+				mockService.On("GetAdvisor", mock.Anything, 3).Return(tt.mockAdvisor, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newAdvisorRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var advisor models.Person
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &advisor))
+				assert.Equal(t, tt.mockAdvisor, advisor)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleAssignAdvisor(t *testing.T) {
+	assignedAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectedStatus int
+		expectedType   string
+	}{
+		{
+			name:           "Success",
+			body:           `{"advisor_id": 1}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing Advisor",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   handlers.ProblemTypeValidation,
+		},
+		{
+			name:           "Invalid Payload",
+			body:           `{"advisor_id": "Steve"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   handlers.ProblemTypeInvalidPayload,
+		},
+		{
+			name:           "Advisor Not A Professor",
+			body:           `{"advisor_id": 4}`,
+			mockError:      fmt.Errorf("not a professor: %w", services.ErrConstraintViolation),
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   handlers.ProblemTypeConstraintViolation,
+		},
+		{
+			name:           "Unknown Advisor",
+			body:           `{"advisor_id": 99}`,
+			mockError:      fmt.Errorf("unknown advisor: %w", services.ErrInvalidReference),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedType:   handlers.ProblemTypeInvalidReference,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockAdvisorManager)
+			var advising models.Advising
+			_ = json.Unmarshal([]byte(tt.body), &advising)
+			if tt.mockError != nil || tt.expectedStatus == http.StatusOK {
+				mockService.On("AssignAdvisor", mock.Anything, 3, advising.AdvisorID).
+					Return(models.Advising{StudentID: 3, AdvisorID: advising.AdvisorID, AssignedAt: assignedAt}, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("PUT", "/api/person/3/advisor", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newAdvisorRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, `{"student_id": 3, "advisor_id": 1, "assigned_at": "2024-09-01T12:00:00Z"}`, rr.Body.String())
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleRemoveAdvisor(t *testing.T) {
+	mockService := new(mockAdvisorManager)
+	mockService.On("RemoveAdvisor", mock.Anything, 3).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/api/person/3/advisor", nil)
+	rr := httptest.NewRecorder()
+	newAdvisorRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"Advisor has successfully been removed"`, strings.TrimSpace(rr.Body.String()))
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetAdvisees(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		advisees := []models.Person{
+			{ID: 3, FirstName: "Larry", LastName: "Page", Type: "student", Age: 51},
+			{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 67},
+		}
+		mockService := new(mockAdvisorManager)
+		mockService.On("GetAdvisees", mock.Anything, 1).Return(advisees, nil)
+
+		req, _ := http.NewRequest("GET", "/api/professor/1/advisees", nil)
+		rr := httptest.NewRecorder()
+		newAdvisorRouter(mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var got []models.Person
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, advisees, got)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Not A Professor", func(t *testing.T) {
+		mockService := new(mockAdvisorManager)
+		mockService.On("GetAdvisees", mock.Anything, 3).Return([]models.Person{}, fmt.Errorf("not found: %w", services.ErrNotFound))
+
+		req, _ := http.NewRequest("GET", "/api/professor/3/advisees", nil)
+		rr := httptest.NewRecorder()
+		newAdvisorRouter(mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
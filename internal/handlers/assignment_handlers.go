@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/httplog/v2"
+)
+
+type assignmentManager interface {
+	GetAssignments(ctx context.Context, courseID int, term services.TermScope) ([]models.Assignment, error)
+	GetAssignment(ctx context.Context, courseID, assignmentID int) (models.Assignment, error)
+	CreateAssignment(ctx context.Context, assignment models.Assignment) (models.Assignment, error)
+	UpdateAssignment(ctx context.Context, assignment models.Assignment) (models.Assignment, error)
+	DeleteAssignment(ctx context.Context, courseID, assignmentID int) error
+	GetSubmissions(ctx context.Context, courseID, assignmentID int) ([]models.Submission, error)
+	Submit(ctx context.Context, courseID, assignmentID int, submission models.Submission) (models.Submission, error)
+	ScoreSubmission(ctx context.Context, courseID, assignmentID, personID int, score models.SubmissionScore) (models.Submission, error)
+}
+
+// HandleGetAssignments lists the assignments of a course by due date. The
+// term query parameter keeps only the assignments of the given terms.
+func HandleGetAssignments(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		term, termErrs := parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid assignment query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		assignments, err := service.GetAssignments(ctx, courseID, term)
+		if err != nil {
+			logger.Error("error getting assignments", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, assignments)
+	}
+}
+
+func HandleGetAssignment(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, errs := pathID(r, "id")
+		assignmentID, assignmentErrs := pathID(r, "assignmentID")
+		errs = append(errs, assignmentErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid assignment ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		assignment, err := service.GetAssignment(r.Context(), courseID, assignmentID)
+		if err != nil {
+			logger.Error("error getting assignment", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, assignment)
+	}
+}
+
+// HandleCreateAssignment adds an assignment to a course from a body such as
+// {"term_id": 2, "title": "Homework 1", "due_at": "2026-09-14T23:59:00Z",
+// "max_points": 100}.
+func HandleCreateAssignment(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid course ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID", errs...)
+			return
+		}
+
+		var assignment models.Assignment
+		if err := json.NewDecoder(r.Body).Decode(&assignment); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		assignment.CourseID = courseID
+		if err := utils.ValidateAssignment(assignment); err != nil {
+			logger.Error("invalid assignment data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		assignment, err := service.CreateAssignment(ctx, assignment)
+		if err != nil {
+			logger.Error("error creating assignment", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, assignment)
+	}
+}
+
+// HandleUpdateAssignment changes the title, due date and maximum points of an
+// assignment. The term of an assignment is fixed when it is created.
+func HandleUpdateAssignment(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		assignmentID, assignmentErrs := pathID(r, "assignmentID")
+		errs = append(errs, assignmentErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid assignment ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		var assignment models.Assignment
+		if err := json.NewDecoder(r.Body).Decode(&assignment); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		assignment.ID = assignmentID
+		assignment.CourseID = courseID
+		assignment.TermID = nil
+		if err := utils.ValidateAssignment(assignment); err != nil {
+			logger.Error("invalid assignment data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		assignment, err := service.UpdateAssignment(ctx, assignment)
+		if err != nil {
+			logger.Error("error updating assignment", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, assignment)
+	}
+}
+
+func HandleDeleteAssignment(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, errs := pathID(r, "id")
+		assignmentID, assignmentErrs := pathID(r, "assignmentID")
+		errs = append(errs, assignmentErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid assignment ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		if err := service.DeleteAssignment(r.Context(), courseID, assignmentID); err != nil {
+			logger.Error("error deleting assignment", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Assignment has successfully been deleted")
+	}
+}
+
+func HandleGetSubmissions(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, errs := pathID(r, "id")
+		assignmentID, assignmentErrs := pathID(r, "assignmentID")
+		errs = append(errs, assignmentErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid assignment ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		submissions, err := service.GetSubmissions(r.Context(), courseID, assignmentID)
+		if err != nil {
+			logger.Error("error getting submissions", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, submissions)
+	}
+}
+
+// HandleSubmit hands in a student's work for an assignment from a body such
+// as {"person_id": 3, "content": "...", "file_ref": "..."}. Only students of
+// the course may submit. The submission is stamped with the time it was
+// received and flagged late if that is after the due date.
+func HandleSubmit(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		assignmentID, assignmentErrs := pathID(r, "assignmentID")
+		errs = append(errs, assignmentErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid assignment ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		var submission models.Submission
+		if err := json.NewDecoder(r.Body).Decode(&submission); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		// Everything but the work itself is set by the service.
+		submission = models.Submission{PersonID: submission.PersonID, Content: submission.Content, FileRef: submission.FileRef}
+		if err := utils.ValidateSubmission(submission); err != nil {
+			logger.Error("invalid submission", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		submission, err := service.Submit(ctx, courseID, assignmentID, submission)
+		if err != nil {
+			logger.Error("error submitting assignment", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, submission)
+	}
+}
+
+// HandleScoreSubmission scores a student's submission from a body such as
+// {"professor_id": 1, "score": 92.5}. The professor must teach the course in
+// the assignment's term.
+func HandleScoreSubmission(logger *httplog.Logger, service assignmentManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		assignmentID, assignmentErrs := pathID(r, "assignmentID")
+		personID, personErrs := pathID(r, "personID")
+		errs = append(errs, assignmentErrs...)
+		errs = append(errs, personErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid submission ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		var score models.SubmissionScore
+		if err := json.NewDecoder(r.Body).Decode(&score); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateSubmissionScore(score); err != nil {
+			logger.Error("invalid submission score", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		submission, err := service.ScoreSubmission(ctx, courseID, assignmentID, personID, score)
+		if err != nil {
+			logger.Error("error scoring submission", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, submission)
+	}
+}
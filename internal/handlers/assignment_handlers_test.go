@@ -0,0 +1,239 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAssignmentManager struct {
+	mock.Mock
+}
+
+func (m *mockAssignmentManager) GetAssignments(ctx context.Context, courseID int, term services.TermScope) ([]models.Assignment, error) {
+	args := m.Called(ctx, courseID, term)
+	return args.Get(0).([]models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentManager) GetAssignment(ctx context.Context, courseID, assignmentID int) (models.Assignment, error) {
+	args := m.Called(ctx, courseID, assignmentID)
+	return args.Get(0).(models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentManager) CreateAssignment(ctx context.Context, assignment models.Assignment) (models.Assignment, error) {
+	args := m.Called(ctx, assignment)
+	return args.Get(0).(models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentManager) UpdateAssignment(ctx context.Context, assignment models.Assignment) (models.Assignment, error) {
+	args := m.Called(ctx, assignment)
+	return args.Get(0).(models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentManager) DeleteAssignment(ctx context.Context, courseID, assignmentID int) error {
+	args := m.Called(ctx, courseID, assignmentID)
+	return args.Error(0)
+}
+
+func (m *mockAssignmentManager) GetSubmissions(ctx context.Context, courseID, assignmentID int) ([]models.Submission, error) {
+	args := m.Called(ctx, courseID, assignmentID)
+	return args.Get(0).([]models.Submission), args.Error(1)
+}
+
+func (m *mockAssignmentManager) Submit(ctx context.Context, courseID, assignmentID int, submission models.Submission) (models.Submission, error) {
+	args := m.Called(ctx, courseID, assignmentID, submission)
+	return args.Get(0).(models.Submission), args.Error(1)
+}
+
+func (m *mockAssignmentManager) ScoreSubmission(ctx context.Context, courseID, assignmentID, personID int, score models.SubmissionScore) (models.Submission, error) {
+	args := m.Called(ctx, courseID, assignmentID, personID, score)
+	return args.Get(0).(models.Submission), args.Error(1)
+}
+
+func newAssignmentRouter(service *mockAssignmentManager) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/course/{id}/assignments", handlers.HandleGetAssignments(logger, service))
+	r.Post("/api/course/{id}/assignments", handlers.HandleCreateAssignment(logger, service))
+	r.Get("/api/course/{id}/assignments/{assignmentID}", handlers.HandleGetAssignment(logger, service))
+	r.Put("/api/course/{id}/assignments/{assignmentID}", handlers.HandleUpdateAssignment(logger, service))
+	r.Delete("/api/course/{id}/assignments/{assignmentID}", handlers.HandleDeleteAssignment(logger, service))
+	r.Get("/api/course/{id}/assignments/{assignmentID}/submissions", handlers.HandleGetSubmissions(logger, service))
+	r.Post("/api/course/{id}/assignments/{assignmentID}/submissions", handlers.HandleSubmit(logger, service))
+	r.Put("/api/course/{id}/assignments/{assignmentID}/submissions/{personID}/score", handlers.HandleScoreSubmission(logger, service))
+	return r
+}
+
+func TestHandleGetAssignments(t *testing.T) {
+	termID := 2
+	assignments := []models.Assignment{{ID: 1, CourseID: 1, TermID: &termID, Title: "Homework 1", DueAt: time.Date(2026, 9, 14, 23, 59, 0, 0, time.UTC), MaxPoints: 100}}
+	mockService := new(mockAssignmentManager)
+	mockService.On("GetAssignments", mock.Anything, 1, services.TermScope{ID: 2}).Return(assignments, nil)
+
+	req, _ := http.NewRequest("GET", "/api/course/1/assignments?term=2", nil)
+	rr := httptest.NewRecorder()
+	newAssignmentRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id": 1, "course_id": 1, "term_id": 2, "title": "Homework 1", "due_at": "2026-09-14T23:59:00Z", "max_points": 100}]`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleCreateAssignment(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"term_id": 2, "title": "Homework 2", "due_at": "2026-09-28T23:59:00Z", "max_points": 100}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Unknown Term", body: `{"term_id": 2, "title": "Homework 2", "due_at": "2026-09-28T23:59:00Z", "max_points": 100}`, mockError: fmt.Errorf("bad term: %w", services.ErrInvalidReference), expectCall: true, expectedStatus: http.StatusUnprocessableEntity, expectedType: handlers.ProblemTypeInvalidReference},
+		{name: "No Points", body: `{"title": "Homework 2", "due_at": "2026-09-28T23:59:00Z"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Due Date", body: `{"title": "Homework 2", "due_at": "next week"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			termID := 2
+			requested := models.Assignment{CourseID: 1, TermID: &termID, Title: "Homework 2", DueAt: time.Date(2026, 9, 28, 23, 59, 0, 0, time.UTC), MaxPoints: 100}
+			created := requested
+			created.ID = 4
+			mockService := new(mockAssignmentManager)
+			if tt.expectCall {
+				mockService.On("CreateAssignment", mock.Anything, requested).Return(created, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("POST", "/api/course/1/assignments", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newAssignmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Assignment
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, created, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleSubmit(t *testing.T) {
+	submitted := time.Date(2026, 10, 3, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"person_id": 3, "file_ref": "uploads/proposal-3.pdf"}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Ignores Score", body: `{"person_id": 3, "file_ref": "uploads/proposal-3.pdf", "score": 20, "late": false}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not Enrolled", body: `{"person_id": 3, "file_ref": "uploads/proposal-3.pdf"}`, mockError: fmt.Errorf("not a student: %w", services.ErrForbidden), expectCall: true, expectedStatus: http.StatusForbidden, expectedType: handlers.ProblemTypeForbidden},
+		{name: "Already Scored", body: `{"person_id": 3, "file_ref": "uploads/proposal-3.pdf"}`, mockError: fmt.Errorf("scored: %w", services.ErrConflict), expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeConflict},
+		{name: "Empty Submission", body: `{"person_id": 3, "content": " "}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := models.Submission{AssignmentID: 2, PersonID: 3, FileRef: "uploads/proposal-3.pdf", SubmittedAt: submitted, Late: true}
+			mockService := new(mockAssignmentManager)
+			if tt.expectCall {
+				mockService.On("Submit", mock.Anything, 1, 2, models.Submission{PersonID: 3, FileRef: "uploads/proposal-3.pdf"}).Return(saved, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("POST", "/api/course/1/assignments/2/submissions", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newAssignmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, `{"assignment_id": 2, "person_id": 3, "file_ref": "uploads/proposal-3.pdf", "submitted_at": "2026-10-03T09:00:00Z", "late": true, "score": null}`, rr.Body.String())
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleScoreSubmission(t *testing.T) {
+	points := 88.5
+	score := models.SubmissionScore{ProfessorID: 1, Score: &points}
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", url: "/api/course/1/assignments/1/submissions/4/score", body: `{"professor_id": 1, "score": 88.5}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not The Course Professor", url: "/api/course/1/assignments/1/submissions/4/score", body: `{"professor_id": 1, "score": 88.5}`, mockError: fmt.Errorf("not teaching: %w", services.ErrForbidden), expectCall: true, expectedStatus: http.StatusForbidden, expectedType: handlers.ProblemTypeForbidden},
+		{name: "Above Max Points", url: "/api/course/1/assignments/1/submissions/4/score", body: `{"professor_id": 1, "score": 88.5}`, mockError: fmt.Errorf("too many points: %w", services.ErrConstraintViolation), expectCall: true, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeConstraintViolation},
+		{name: "Missing Score", url: "/api/course/1/assignments/1/submissions/4/score", body: `{"professor_id": 1}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Invalid Person ID", url: "/api/course/1/assignments/1/submissions/abc/score", body: `{"professor_id": 1, "score": 88.5}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			professor := 1
+			scored := models.Submission{AssignmentID: 1, PersonID: 4, FileRef: "uploads/hw1-4.pdf", Score: &points, ScoredBy: &professor}
+			mockService := new(mockAssignmentManager)
+			if tt.expectCall {
+				mockService.On("ScoreSubmission", mock.Anything, 1, 1, 4, score).Return(scored, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("PUT", tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newAssignmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Submission
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, scored, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteAssignment(t *testing.T) {
+	mockService := new(mockAssignmentManager)
+	mockService.On("DeleteAssignment", mock.Anything, 1, 9).Return(fmt.Errorf("no assignment: %w", services.ErrNotFound))
+
+	req, _ := http.NewRequest("DELETE", "/api/course/1/assignments/9", nil)
+	rr := httptest.NewRecorder()
+	newAssignmentRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
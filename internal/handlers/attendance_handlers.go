@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/httplog/v2"
+)
+
+type attendanceManager interface {
+	GetSessions(ctx context.Context, courseID int, term services.TermScope) ([]models.ClassSession, error)
+	CreateSession(ctx context.Context, session models.ClassSession) (models.ClassSession, error)
+	UpdateSession(ctx context.Context, session models.ClassSession) (models.ClassSession, error)
+	DeleteSession(ctx context.Context, courseID, sessionID int) error
+	GetAttendance(ctx context.Context, courseID, sessionID int) ([]models.AttendanceRecord, error)
+	RecordAttendance(ctx context.Context, courseID, sessionID int, submission models.AttendanceSubmission) ([]models.AttendanceRecord, error)
+	GetCourseAttendance(ctx context.Context, courseID int, term services.TermScope) (models.CourseAttendance, error)
+	GetPersonAttendance(ctx context.Context, personID int, term services.TermScope) (models.PersonAttendance, error)
+}
+
+// HandleGetSessions lists the class sessions of a course by date. The term
+// query parameter keeps only the sessions of the given terms.
+func HandleGetSessions(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		term, termErrs := parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid class session query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		sessions, err := service.GetSessions(ctx, courseID, term)
+		if err != nil {
+			logger.Error("error getting class sessions", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, sessions)
+	}
+}
+
+// HandleCreateSession adds a class session to a course from a body such as
+// {"term_id": 2, "date": "2024-09-02", "topic": "Introduction"}.
+func HandleCreateSession(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid course ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID", errs...)
+			return
+		}
+
+		var session models.ClassSession
+		if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		session.CourseID = courseID
+		if err := utils.ValidateClassSession(session); err != nil {
+			logger.Error("invalid class session data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		session, err := service.CreateSession(ctx, session)
+		if err != nil {
+			logger.Error("error creating class session", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, session)
+	}
+}
+
+// HandleUpdateSession changes the date and topic of a class session. The
+// term of a session is fixed when it is created.
+func HandleUpdateSession(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		sessionID, sessionErrs := pathID(r, "sessionID")
+		errs = append(errs, sessionErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid class session ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		var session models.ClassSession
+		if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		session.ID = sessionID
+		session.CourseID = courseID
+		session.TermID = nil
+		if err := utils.ValidateClassSession(session); err != nil {
+			logger.Error("invalid class session data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		session, err := service.UpdateSession(ctx, session)
+		if err != nil {
+			logger.Error("error updating class session", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, session)
+	}
+}
+
+func HandleDeleteSession(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, errs := pathID(r, "id")
+		sessionID, sessionErrs := pathID(r, "sessionID")
+		errs = append(errs, sessionErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid class session ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		if err := service.DeleteSession(r.Context(), courseID, sessionID); err != nil {
+			logger.Error("error deleting class session", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Class session has successfully been deleted")
+	}
+}
+
+func HandleGetAttendance(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, errs := pathID(r, "id")
+		sessionID, sessionErrs := pathID(r, "sessionID")
+		errs = append(errs, sessionErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid class session ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		records, err := service.GetAttendance(r.Context(), courseID, sessionID)
+		if err != nil {
+			logger.Error("error getting attendance", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, records)
+	}
+}
+
+// HandleRecordAttendance takes attendance at a class session. The body names
+// the professor taking it, who must teach the course in the session's term,
+// and the status of each listed student. With a default status every other
+// student of the course gets that status, so a whole roster can be submitted
+// at once. Either every record is stored or none is.
+func HandleRecordAttendance(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		sessionID, sessionErrs := pathID(r, "sessionID")
+		errs = append(errs, sessionErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid class session ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		var submission models.AttendanceSubmission
+		if err := json.NewDecoder(r.Body).Decode(&submission); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateAttendanceSubmission(submission); err != nil {
+			logger.Error("invalid attendance submission", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		records, err := service.RecordAttendance(ctx, courseID, sessionID, submission)
+		if err != nil {
+			logger.Error("error recording attendance", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, records)
+	}
+}
+
+// HandleGetCourseAttendance reports the attendance rate of each student of a
+// course and of the course as a whole. The term query parameter keeps only
+// the given terms.
+func HandleGetCourseAttendance(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		term, termErrs := parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid course attendance query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		report, err := service.GetCourseAttendance(ctx, courseID, term)
+		if err != nil {
+			logger.Error("error getting course attendance", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, report)
+	}
+}
+
+// HandleGetPersonAttendance reports the attendance rate of a student in each
+// of their courses and over all of them. The term query parameter keeps only
+// the given terms.
+func HandleGetPersonAttendance(logger *httplog.Logger, service attendanceManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		personID, errs := pathID(r, "id")
+		term, termErrs := parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid person attendance query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		report, err := service.GetPersonAttendance(ctx, personID, term)
+		if err != nil {
+			logger.Error("error getting person attendance", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, report)
+	}
+}
@@ -0,0 +1,240 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAttendanceManager struct {
+	mock.Mock
+}
+
+func (m *mockAttendanceManager) GetSessions(ctx context.Context, courseID int, term services.TermScope) ([]models.ClassSession, error) {
+	args := m.Called(ctx, courseID, term)
+	return args.Get(0).([]models.ClassSession), args.Error(1)
+}
+
+func (m *mockAttendanceManager) CreateSession(ctx context.Context, session models.ClassSession) (models.ClassSession, error) {
+	args := m.Called(ctx, session)
+	return args.Get(0).(models.ClassSession), args.Error(1)
+}
+
+func (m *mockAttendanceManager) UpdateSession(ctx context.Context, session models.ClassSession) (models.ClassSession, error) {
+	args := m.Called(ctx, session)
+	return args.Get(0).(models.ClassSession), args.Error(1)
+}
+
+func (m *mockAttendanceManager) DeleteSession(ctx context.Context, courseID, sessionID int) error {
+	args := m.Called(ctx, courseID, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockAttendanceManager) GetAttendance(ctx context.Context, courseID, sessionID int) ([]models.AttendanceRecord, error) {
+	args := m.Called(ctx, courseID, sessionID)
+	return args.Get(0).([]models.AttendanceRecord), args.Error(1)
+}
+
+func (m *mockAttendanceManager) RecordAttendance(ctx context.Context, courseID, sessionID int, submission models.AttendanceSubmission) ([]models.AttendanceRecord, error) {
+	args := m.Called(ctx, courseID, sessionID, submission)
+	return args.Get(0).([]models.AttendanceRecord), args.Error(1)
+}
+
+func (m *mockAttendanceManager) GetCourseAttendance(ctx context.Context, courseID int, term services.TermScope) (models.CourseAttendance, error) {
+	args := m.Called(ctx, courseID, term)
+	return args.Get(0).(models.CourseAttendance), args.Error(1)
+}
+
+func (m *mockAttendanceManager) GetPersonAttendance(ctx context.Context, personID int, term services.TermScope) (models.PersonAttendance, error) {
+	args := m.Called(ctx, personID, term)
+	return args.Get(0).(models.PersonAttendance), args.Error(1)
+}
+
+func newAttendanceRouter(service *mockAttendanceManager) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/course/{id}/sessions", handlers.HandleGetSessions(logger, service))
+	r.Post("/api/course/{id}/sessions", handlers.HandleCreateSession(logger, service))
+	r.Put("/api/course/{id}/sessions/{sessionID}", handlers.HandleUpdateSession(logger, service))
+	r.Delete("/api/course/{id}/sessions/{sessionID}", handlers.HandleDeleteSession(logger, service))
+	r.Get("/api/course/{id}/sessions/{sessionID}/attendance", handlers.HandleGetAttendance(logger, service))
+	r.Put("/api/course/{id}/sessions/{sessionID}/attendance", handlers.HandleRecordAttendance(logger, service))
+	r.Get("/api/course/{id}/attendance", handlers.HandleGetCourseAttendance(logger, service))
+	r.Get("/api/person/{id}/attendance", handlers.HandleGetPersonAttendance(logger, service))
+	return r
+}
+
+func TestHandleGetSessions(t *testing.T) {
+	termID := 2
+	sessions := []models.ClassSession{{ID: 1, CourseID: 1, TermID: &termID, Date: models.Date{Time: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)}, Topic: "Introduction"}}
+	mockService := new(mockAttendanceManager)
+	mockService.On("GetSessions", mock.Anything, 1, services.TermScope{ID: 2}).Return(sessions, nil)
+
+	req, _ := http.NewRequest("GET", "/api/course/1/sessions?term=2", nil)
+	rr := httptest.NewRecorder()
+	newAttendanceRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id": 1, "course_id": 1, "term_id": 2, "date": "2026-09-01", "topic": "Introduction"}]`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleCreateSession(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"term_id": 2, "date": "2026-09-08", "topic": "Control flow"}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Course Not Found", body: `{"term_id": 2, "date": "2026-09-08", "topic": "Control flow"}`, mockError: services.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound, expectedType: handlers.ProblemTypeNotFound},
+		{name: "Missing Date", body: `{"term_id": 2, "topic": "Control flow"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Date", body: `{"date": "September 8"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			termID := 2
+			requested := models.ClassSession{CourseID: 1, TermID: &termID, Date: models.Date{Time: time.Date(2026, 9, 8, 0, 0, 0, 0, time.UTC)}, Topic: "Control flow"}
+			created := requested
+			created.ID = 4
+			mockService := new(mockAttendanceManager)
+			if tt.expectCall {
+				mockService.On("CreateSession", mock.Anything, requested).Return(created, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("POST", "/api/course/1/sessions", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newAttendanceRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.ClassSession
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, created, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteSession(t *testing.T) {
+	mockService := new(mockAttendanceManager)
+	mockService.On("DeleteSession", mock.Anything, 1, 4).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/api/course/1/sessions/4", nil)
+	rr := httptest.NewRecorder()
+	newAttendanceRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+
+	req, _ = http.NewRequest("DELETE", "/api/course/x/sessions/0", nil)
+	rr = httptest.NewRecorder()
+	newAttendanceRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var problem handlers.ResponseErr
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Len(t, problem.Errors, 2)
+}
+
+func TestHandleRecordAttendance(t *testing.T) {
+	submission := models.AttendanceSubmission{ProfessorID: 1, DefaultStatus: "present", Records: []models.AttendanceEntry{{PersonID: 4, Status: "late"}}}
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"professor_id": 1, "default_status": "present", "records": [{"person_id": 4, "status": "late"}]}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not The Course Professor", body: `{"professor_id": 1, "default_status": "present", "records": [{"person_id": 4, "status": "late"}]}`, mockError: fmt.Errorf("not teaching: %w", services.ErrForbidden), expectCall: true, expectedStatus: http.StatusForbidden, expectedType: handlers.ProblemTypeForbidden},
+		{name: "Not On Roster", body: `{"professor_id": 1, "default_status": "present", "records": [{"person_id": 4, "status": "late"}]}`, mockError: fmt.Errorf("not a student: %w", services.ErrInvalidReference), expectCall: true, expectedStatus: http.StatusUnprocessableEntity, expectedType: handlers.ProblemTypeInvalidReference},
+		{name: "Unknown Status", body: `{"professor_id": 1, "records": [{"person_id": 4, "status": "asleep"}]}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := []models.AttendanceRecord{{SessionID: 2, PersonID: 3, Status: "present"}, {SessionID: 2, PersonID: 4, Status: "late"}}
+			mockService := new(mockAttendanceManager)
+			if tt.expectCall {
+				mockService.On("RecordAttendance", mock.Anything, 1, 2, submission).Return(records, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("PUT", "/api/course/1/sessions/2/attendance", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newAttendanceRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body []models.AttendanceRecord
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, records, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetCourseAttendance(t *testing.T) {
+	termID := 2
+	rate := 50.0
+	report := models.CourseAttendance{CourseID: 1, Rate: &rate, Students: []models.AttendanceRate{
+		{PersonID: 4, CourseID: 1, TermID: &termID, Present: 1, Absent: 1, Rate: &rate},
+		{PersonID: 5, CourseID: 1, TermID: &termID, Excused: 1},
+	}}
+	mockService := new(mockAttendanceManager)
+	mockService.On("GetCourseAttendance", mock.Anything, 1, services.TermScope{ID: 2}).Return(report, nil)
+
+	req, _ := http.NewRequest("GET", "/api/course/1/attendance?term=2", nil)
+	rr := httptest.NewRecorder()
+	newAttendanceRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"course_id": 1,
+		"rate": 50,
+		"students": [
+			{"person_id": 4, "course_id": 1, "term_id": 2, "present": 1, "late": 0, "absent": 1, "excused": 0, "rate": 50},
+			{"person_id": 5, "course_id": 1, "term_id": 2, "present": 0, "late": 0, "absent": 0, "excused": 1, "rate": null}
+		]
+	}`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetPersonAttendance(t *testing.T) {
+	mockService := new(mockAttendanceManager)
+	mockService.On("GetPersonAttendance", mock.Anything, 9, services.TermScope{}).Return(models.PersonAttendance{}, fmt.Errorf("no person: %w", services.ErrNotFound))
+
+	req, _ := http.NewRequest("GET", "/api/person/9/attendance", nil)
+	rr := httptest.NewRecorder()
+	newAttendanceRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
@@ -3,34 +3,70 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog/v2"
 )
 
 type courseGetter interface {
-	GetCourses(ctx context.Context) ([]models.Course, error)
+	GetCourses(ctx context.Context, filter services.CourseFilter, page services.Page) ([]models.Course, services.PageInfo, error)
 	GetCourse(ctx context.Context, id int) (models.Course, error)
+	GetCourseByCode(ctx context.Context, code string) (models.Course, error)
 	CreateCourse(ctx context.Context, course models.Course) (models.Course, error)
 	UpdateCourse(ctx context.Context, id int, course models.Course) (models.Course, error)
 	DeleteCourse(ctx context.Context, id int) error
+	GetPeopleByCourse(ctx context.Context, courseIDs []int) (map[int][]models.Person, error)
+	GetCourseRoster(ctx context.Context, courseID int, filter services.RosterFilter) (models.Roster, error)
+}
+
+// parseCourseFilter reads the department and term query parameters of the
+// course listing.
+func parseCourseFilter(r *http.Request) (services.CourseFilter, []FieldError) {
+	var filter services.CourseFilter
+	var errs []FieldError
+	if value := r.URL.Query().Get("department"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			errs = append(errs, FieldError{Field: "department", Code: utils.CodeInvalid, Detail: "must be a department ID"})
+		} else {
+			filter.DepartmentID = id
+		}
+	}
+	term, termErrs := parseTermScope(r)
+	errs = append(errs, termErrs...)
+	filter.Term = term
+	return filter, errs
 }
 
 func HandleGetCourses(logger *httplog.Logger, service courseGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		courses, err := service.GetCourses(ctx)
+		page, errs := parsePage(r)
+		filter, filterErrs := parseCourseFilter(r)
+		errs = append(errs, filterErrs...)
+		expand, expandErrs := parseExpand(r, "people")
+		errs = append(errs, expandErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid course page", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
+		courses, info, err := service.GetCourses(ctx, filter, page)
 		if err != nil {
 			logger.Error("error getting all courses", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error retrieving data"})
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
 			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, courses)
+		setPageHeaders(w, r, info)
+		encodeCourses(w, r, logger, service, expand, courses)
 	}
 }
 
@@ -42,17 +78,91 @@ func HandleGetCourse(logger *httplog.Logger, service courseGetter) http.HandlerF
 		id, err := strconv.Atoi(idParam)
 		if err != nil {
 			logger.Error("invalid course ID", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid course ID"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
+			return
+		}
+		expand, errs := parseExpand(r, "people")
+		if len(errs) > 0 {
+			logger.Error("invalid course query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
 			return
 		}
 
 		course, err := service.GetCourse(ctx, id)
 		if err != nil {
 			logger.Error("error getting course", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error retrieving data"})
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
 			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, course)
+		encodeCourse(w, r, logger, service, expand, course)
+	}
+}
+
+// HandleGetCourseByCode looks a course up by its code. Codes are matched
+// case-insensitively, so /api/course/by-code/cs-101 finds CS-101.
+func HandleGetCourseByCode(logger *httplog.Logger, service courseGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		code := strings.ToUpper(chi.URLParam(r, "code"))
+
+		expand, errs := parseExpand(r, "people")
+		if len(errs) > 0 {
+			logger.Error("invalid course query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
+		course, err := service.GetCourseByCode(ctx, code)
+		if err != nil {
+			logger.Error("error getting course by code", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		encodeCourse(w, r, logger, service, expand, course)
+	}
+}
+
+// HandleGetCourseRoster lists the teaching staff and students of a course
+// with their roles in it. The type query parameter keeps only one of the
+// two, term keeps the people enrolled in the given terms, and sort orders
+// both lists like the people listings do, for example sort=last_name.
+func HandleGetCourseRoster(logger *httplog.Logger, service courseGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid course ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
+			return
+		}
+
+		queryParams := r.URL.Query()
+		filter := services.RosterFilter{Type: queryParams.Get("type")}
+		var errs []FieldError
+		if filter.Type != "" && filter.Type != "student" && filter.Type != "professor" {
+			errs = append(errs, FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be either 'student' or 'professor'"})
+		}
+		if filter.Sort, err = services.ParsePersonSort(queryParams.Get("sort")); err != nil {
+			errs = append(errs, FieldError{Field: "sort", Code: utils.CodeInvalid, Detail: "must be a comma separated list of id, first_name, last_name, type or age, each optionally prefixed with -"})
+		}
+		var termErrs []FieldError
+		filter.Term, termErrs = parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid roster query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
+		roster, err := service.GetCourseRoster(ctx, id, filter)
+		if err != nil {
+			logger.Error("error getting course roster", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, roster)
 	}
 }
 
@@ -62,18 +172,18 @@ func HandleCreateCourse(logger *httplog.Logger, service courseGetter) http.Handl
 		var course models.Course
 		if err := json.NewDecoder(r.Body).Decode(&course); err != nil {
 			logger.Error("failed to decode request body", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid request payload"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
 			return
 		}
-		if course.Name == "" {
-			logger.Error("course name is missing", "error", fmt.Errorf("missing course name"))
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Course name is required"})
+		if err := utils.ValidateCourse(course); err != nil {
+			logger.Error("invalid course data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
 			return
 		}
 		course, err := service.CreateCourse(ctx, course)
 		if err != nil {
 			logger.Error("error creating course", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error creating data"})
+			EncodeServiceError(w, r, logger, err, "Error creating data")
 			return
 		}
 		EncodeResponse(w, logger, http.StatusOK, course.ID)
@@ -89,20 +199,67 @@ func HandleUpdateCourse(logger *httplog.Logger, service courseGetter) http.Handl
 		id, err := strconv.Atoi(idParam)
 		if err != nil {
 			logger.Error("invalid course ID", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid course ID"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
 			return
 		}
 
 		err = json.NewDecoder(r.Body).Decode(&course)
 		if err != nil {
 			logger.Error("failed to decode request body", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid request payload"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
 			return
 		}
+		if err := utils.ValidateCourse(course); err != nil {
+			logger.Error("invalid course data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		course, err = service.UpdateCourse(ctx, id, course)
+		if err != nil {
+			logger.Error("error updating course", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, course)
+	}
+}
+
+// HandlePatchCourse applies a merge patch or JSON Patch to the stored course.
+func HandlePatchCourse(logger *httplog.Logger, service courseGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid course ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
+			return
+		}
+
+		course, err := service.GetCourse(ctx, id)
+		if err != nil {
+			logger.Error("error fetching existing course", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+
+		course, err = applyPatch(r, course)
+		if err != nil {
+			logger.Error("failed to apply patch", "error", err)
+			encodePatchError(w, r, logger, err)
+			return
+		}
+		if err := utils.ValidateCourse(course); err != nil {
+			logger.Error("invalid course data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
 		course, err = service.UpdateCourse(ctx, id, course)
 		if err != nil {
 			logger.Error("error updating course", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error updating data"})
+			EncodeServiceError(w, r, logger, err, "Error updating data")
 			return
 		}
 		EncodeResponse(w, logger, http.StatusOK, course)
@@ -118,13 +275,13 @@ func HandleDeleteCourse(logger *httplog.Logger, service courseGetter) http.Handl
 		id, err := strconv.Atoi(idParam)
 		if err != nil {
 			logger.Error("invalid course ID", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid course ID"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
 			return
 		}
 
 		if err := service.DeleteCourse(ctx, id); err != nil {
 			logger.Error("error deleting course", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error deleting data"})
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
 			return
 		}
 		EncodeResponse(w, logger, http.StatusOK, "Course has successfully been deleted")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog/v2"
 	"github.com/stretchr/testify/assert"
@@ -22,9 +24,9 @@ type mockCourseGetter struct {
 	mock.Mock
 }
 
-func (m *mockCourseGetter) GetCourses(ctx context.Context) ([]models.Course, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]models.Course), args.Error(1)
+func (m *mockCourseGetter) GetCourses(ctx context.Context, filter services.CourseFilter, page services.Page) ([]models.Course, services.PageInfo, error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]models.Course), args.Get(1).(services.PageInfo), args.Error(2)
 }
 
 func (m *mockCourseGetter) GetCourse(ctx context.Context, id int) (models.Course, error) {
@@ -32,6 +34,11 @@ func (m *mockCourseGetter) GetCourse(ctx context.Context, id int) (models.Course
 	return args.Get(0).(models.Course), args.Error(1)
 }
 
+func (m *mockCourseGetter) GetCourseByCode(ctx context.Context, code string) (models.Course, error) {
+	args := m.Called(ctx, code)
+	return args.Get(0).(models.Course), args.Error(1)
+}
+
 func (m *mockCourseGetter) CreateCourse(ctx context.Context, course models.Course) (models.Course, error) {
 	args := m.Called(ctx, course)
 	return args.Get(0).(models.Course), args.Error(1)
@@ -46,6 +53,16 @@ func (m *mockCourseGetter) DeleteCourse(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *mockCourseGetter) GetCourseRoster(ctx context.Context, courseID int, filter services.RosterFilter) (models.Roster, error) {
+	args := m.Called(ctx, courseID, filter)
+	return args.Get(0).(models.Roster), args.Error(1)
+}
+
+func (m *mockCourseGetter) GetPeopleByCourse(ctx context.Context, courseIDs []int) (map[int][]models.Person, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).(map[int][]models.Person), args.Error(1)
+}
+
 func TestHandleGetCourses(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -73,7 +90,7 @@ func TestHandleGetCourses(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := new(mockCourseGetter)
-			mockService.On("GetCourses", mock.Anything).Return(tt.mockCourses, tt.mockError)
+			mockService.On("GetCourses", mock.Anything, services.CourseFilter{}, services.Page{}).Return(tt.mockCourses, services.PageInfo{}, tt.mockError)
 
 			logger := httplog.NewLogger("test")
 
@@ -95,7 +112,7 @@ func TestHandleGetCourses(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, "Error retrieving data", errorResponse.Error)
+				assert.Equal(t, "Error retrieving data", errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -128,6 +145,14 @@ func TestHandleGetCourse(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   models.Course{},
 		},
+		{
+			name:           "Not Found",
+			courseID:       "2",
+			mockCourses:    models.Course{},
+			mockError:      fmt.Errorf("[in services.GetCourse] course not found: %w", services.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   models.Course{},
+		},
 		{
 			name:           "Invalid ID",
 			courseID:       "abc",
@@ -171,12 +196,17 @@ func TestHandleGetCourse(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, "Error retrieving data", errorResponse.Error)
+				assert.Equal(t, "Error retrieving data", errorResponse.Detail)
+			} else if tt.expectedStatus == http.StatusNotFound {
+				var errorResponse handlers.ResponseErr
+				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+				assert.NoError(t, err)
+				assert.Equal(t, "Resource not found", errorResponse.Detail)
 			} else if tt.expectedStatus == http.StatusBadRequest {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, "Invalid course ID", errorResponse.Error)
+				assert.Equal(t, "Invalid course ID", errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -196,9 +226,9 @@ func TestHandleCreateCourse(t *testing.T) {
 	}{
 		{
 			name:           "Success",
-			requestBody:    `{"Name": "Test Course"}`,
-			inputCourse:    models.Course{Name: "Test Course"},
-			returnedCourse: models.Course{ID: 1, Name: "Test Course"},
+			requestBody:    `{"code": "CS-101", "name": "Test Course"}`,
+			inputCourse:    models.Course{Code: "CS-101", Name: "Test Course"},
+			returnedCourse: models.Course{ID: 1, Code: "CS-101", Name: "Test Course"},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
 			expectedBody:   1,
@@ -210,16 +240,16 @@ func TestHandleCreateCourse(t *testing.T) {
 			returnedCourse: models.Course{},
 			mockError:      nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   handlers.ResponseErr{Error: "Course name is required"},
+			expectedBody:   handlers.ResponseErr{Detail: "course code is required; course name is required"},
 		},
 		{
 			name:           "Error Creating Course",
-			requestBody:    `{"Name": "Test Course"}`,
-			inputCourse:    models.Course{Name: "Test Course"},
+			requestBody:    `{"code": "CS-101", "name": "Test Course"}`,
+			inputCourse:    models.Course{Code: "CS-101", Name: "Test Course"},
 			returnedCourse: models.Course{},
 			mockError:      errors.New("database error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error creating data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error creating data"},
 		},
 	}
 
@@ -249,7 +279,7 @@ func TestHandleCreateCourse(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -271,42 +301,42 @@ func TestHandleUpdateCourse(t *testing.T) {
 		{
 			name:           "Success",
 			courseID:       "1",
-			requestBody:    `{"Name": "Updated Course"}`,
-			inputCourse:    models.Course{Name: "Updated Course"},        // Input to the service
-			returnedCourse: models.Course{ID: 1, Name: "Updated Course"}, // Returned by the service
+			requestBody:    `{"code": "CS-101", "name": "Updated Course"}`,
+			inputCourse:    models.Course{Code: "CS-101", Name: "Updated Course"},        // Input to the service
+			returnedCourse: models.Course{ID: 1, Code: "CS-101", Name: "Updated Course"}, // Returned by the service
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   models.Course{ID: 1, Name: "Updated Course"}, // Expect full course object
+			expectedBody:   models.Course{ID: 1, Code: "CS-101", Name: "Updated Course"}, // Expect full course object
 		},
 		{
 			name:           "Invalid Course ID",
 			courseID:       "abc", // Invalid ID
-			requestBody:    `{"Name": "Test Course"}`,
+			requestBody:    `{"code": "CS-101", "name": "Test Course"}`,
+			inputCourse:    models.Course{},
+			returnedCourse: models.Course{},
+			mockError:      nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   handlers.ResponseErr{Detail: "Invalid course ID"},
+		},
+		{
+			name:           "Invalid Request Body",
+			courseID:       "1",
+			requestBody:    `{}`,
 			inputCourse:    models.Course{},
 			returnedCourse: models.Course{},
 			mockError:      nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   handlers.ResponseErr{Error: "Invalid course ID"},
+			expectedBody:   handlers.ResponseErr{Detail: "course code is required; course name is required"},
 		},
-		// {
-		// 	name:           "Invalid Request Body",
-		// 	courseID:       "1",
-		// 	requestBody:    `{}`,
-		// 	inputCourse:    models.Course{},
-		// 	returnedCourse: models.Course{},
-		// 	mockError:      nil,
-		// 	expectedStatus: http.StatusBadRequest,
-		// 	expectedBody:   handlers.ResponseErr{Error: "Invalid request payload"},
-		// },
 		{
 			name:           "Error Updating Course",
 			courseID:       "1",
-			requestBody:    `{"Name": "Test Course"}`,
-			inputCourse:    models.Course{Name: "Test Course"},
+			requestBody:    `{"code": "CS-101", "name": "Test Course"}`,
+			inputCourse:    models.Course{Code: "CS-101", Name: "Test Course"},
 			returnedCourse: models.Course{},
 			mockError:      errors.New("database error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error updating data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error updating data"},
 		},
 	}
 
@@ -341,7 +371,7 @@ func TestHandleUpdateCourse(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -369,14 +399,21 @@ func TestHandleDeleteCourse(t *testing.T) {
 			courseID:       "abc", // Invalid ID
 			mockError:      nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   handlers.ResponseErr{Error: "Invalid course ID"},
+			expectedBody:   handlers.ResponseErr{Detail: "Invalid course ID"},
+		},
+		{
+			name:           "Course Still Referenced",
+			courseID:       "1",
+			mockError:      &services.Error{Kind: services.ErrConflict, Err: errors.New("foreign key violation")},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   handlers.ResponseErr{Detail: "Request conflicts with existing data"},
 		},
 		{
 			name:           "Error Deleting Course",
 			courseID:       "1",
 			mockError:      errors.New("database error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error deleting data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error deleting data"},
 		},
 	}
 
@@ -408,10 +445,246 @@ func TestHandleDeleteCourse(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
 		})
 	}
 }
+
+func TestHandleGetCoursesExpandPeople(t *testing.T) {
+	courses := []models.Course{{ID: 1, Name: "Math"}, {ID: 2, Name: "Physics"}}
+	people := map[int][]models.Person{
+		1: {{ID: 3, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1}}},
+	}
+
+	mockService := new(mockCourseGetter)
+	mockService.On("GetCourses", mock.Anything, services.CourseFilter{}, services.Page{}).Return(courses, services.PageInfo{}, nil)
+	mockService.On("GetPeopleByCourse", mock.Anything, []int{1, 2}).Return(people, nil).Once()
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	req, _ := http.NewRequest("GET", "/api/course?expand=people", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetCourses(logger, mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var body []models.CourseWithPeople
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, []models.CourseWithPeople{
+		{Course: courses[0], People: people[1]},
+		{Course: courses[1], People: []models.Person{}},
+	}, body)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetCoursesByDepartment(t *testing.T) {
+	departmentID := 2
+	courses := []models.Course{{ID: 3, Code: "DES-110", Name: "UI Design", DepartmentID: &departmentID}}
+
+	t.Run("Filtered", func(t *testing.T) {
+		mockService := new(mockCourseGetter)
+		mockService.On("GetCourses", mock.Anything, services.CourseFilter{DepartmentID: 2}, services.Page{}).Return(courses, services.PageInfo{}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/course?department=2", nil)
+		rr := httptest.NewRecorder()
+		handlers.HandleGetCourses(httplog.NewLogger("test"), mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body []models.Course
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, courses, body)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Department", func(t *testing.T) {
+		mockService := new(mockCourseGetter)
+
+		req, _ := http.NewRequest("GET", "/api/course?department=cs", nil)
+		rr := httptest.NewRecorder()
+		handlers.HandleGetCourses(httplog.NewLogger("test"), mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var errorResponse handlers.ResponseErr
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+		assert.Equal(t, "department", errorResponse.Errors[0].Field)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHandleGetCoursesByTerm(t *testing.T) {
+	courses := []models.Course{{ID: 1, Code: "CS-101", Name: "Programming"}}
+
+	t.Run("Current Term", func(t *testing.T) {
+		mockService := new(mockCourseGetter)
+		mockService.On("GetCourses", mock.Anything, services.CourseFilter{DepartmentID: 1, Term: services.TermScope{When: services.TermCurrent}}, services.Page{}).Return(courses, services.PageInfo{}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/course?department=1&term=current", nil)
+		rr := httptest.NewRecorder()
+		handlers.HandleGetCourses(httplog.NewLogger("test"), mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Term", func(t *testing.T) {
+		mockService := new(mockCourseGetter)
+
+		req, _ := http.NewRequest("GET", "/api/course?term=someday", nil)
+		rr := httptest.NewRecorder()
+		handlers.HandleGetCourses(httplog.NewLogger("test"), mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var errorResponse handlers.ResponseErr
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+		assert.Equal(t, "term", errorResponse.Errors[0].Field)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHandleGetCourseByCode(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "Success", code: "CS-101", expectedStatus: http.StatusOK},
+		{name: "Lowercase Code", code: "cs-101", expectedStatus: http.StatusOK},
+		{name: "Not Found", code: "CS-999", mockError: services.ErrNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			course := models.Course{ID: 1, Code: strings.ToUpper(tt.code), Name: "Programming"}
+			mockService := new(mockCourseGetter)
+			if tt.mockError != nil {
+				mockService.On("GetCourseByCode", mock.Anything, course.Code).Return(models.Course{}, tt.mockError)
+			} else {
+				mockService.On("GetCourseByCode", mock.Anything, course.Code).Return(course, nil)
+			}
+
+			r := chi.NewRouter()
+			r.Get("/api/course/by-code/{code}", handlers.HandleGetCourseByCode(httplog.NewLogger("test"), mockService))
+
+			req, _ := http.NewRequest("GET", "/api/course/by-code/"+tt.code, nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Course
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, course, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandlePatchCourse(t *testing.T) {
+	mockService := new(mockCourseGetter)
+	mockService.On("GetCourse", mock.Anything, 1).Return(models.Course{ID: 1, Code: "MATH-101", Name: "Math", Credits: 3}, nil)
+	mockService.On("UpdateCourse", mock.Anything, 1, models.Course{ID: 1, Code: "MATH-101", Name: "Algebra", Credits: 3}).Return(models.Course{ID: 1, Code: "MATH-101", Name: "Algebra", Credits: 3}, nil)
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Patch("/api/course/{id}", handlers.HandlePatchCourse(logger, mockService))
+
+	req, _ := http.NewRequest("PATCH", "/api/course/1", strings.NewReader(`{"name": "Algebra"}`))
+	req.Header.Set("Content-Type", handlers.MediaTypeMergePatch)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"id":1,"code":"MATH-101","name":"Algebra","credits":3}`, rr.Body.String())
+
+	req, _ = http.NewRequest("PATCH", "/api/course/1", strings.NewReader(`{"name": null}`))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var errorResponse handlers.ResponseErr
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+	assert.Equal(t, handlers.ProblemTypeValidation, errorResponse.Type)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetCourseRoster(t *testing.T) {
+	roster := models.Roster{
+		CourseID:       1,
+		Professors:     []models.RosterEntry{{Person: models.Person{ID: 1, FirstName: "Steve", LastName: "Jobs", Type: "professor", Age: 56, Courses: []int64{1}}, Role: models.RoleInstructor}},
+		Students:       []models.RosterEntry{{Person: models.Person{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 67, Courses: []int64{1}}, Role: models.RoleStudent}},
+		ProfessorCount: 1,
+		StudentCount:   1,
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedFilter *services.RosterFilter
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			url:            "/api/course/1/roster",
+			expectedFilter: &services.RosterFilter{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Type and Sort",
+			url:            "/api/course/1/roster?type=student&sort=-last_name",
+			expectedFilter: &services.RosterFilter{Type: "student", Sort: []services.SortKey{{Column: "last_name", Desc: true}}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Course",
+			url:            "/api/course/1/roster",
+			expectedFilter: &services.RosterFilter{},
+			mockError:      services.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid Type",
+			url:            "/api/course/1/roster?type=dean",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Sort",
+			url:            "/api/course/1/roster?sort=salary",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockCourseGetter)
+			if tt.expectedFilter != nil {
+				if tt.mockError != nil {
+					mockService.On("GetCourseRoster", mock.Anything, 1, *tt.expectedFilter).Return(models.Roster{}, tt.mockError)
+				} else {
+					mockService.On("GetCourseRoster", mock.Anything, 1, *tt.expectedFilter).Return(roster, nil)
+				}
+			}
+
+			logger := httplog.NewLogger("test", httplog.Options{})
+			r := chi.NewRouter()
+			r.Get("/api/course/{id}/roster", handlers.HandleGetCourseRoster(logger, mockService))
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Roster
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, roster, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+)
+
+type departmentGetter interface {
+	GetDepartments(ctx context.Context) ([]models.Department, error)
+	GetDepartment(ctx context.Context, id int) (models.Department, error)
+	CreateDepartment(ctx context.Context, department models.Department) (models.Department, error)
+	UpdateDepartment(ctx context.Context, id int, department models.Department) (models.Department, error)
+	DeleteDepartment(ctx context.Context, id int) error
+}
+
+func HandleGetDepartments(logger *httplog.Logger, service departmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		departments, err := service.GetDepartments(r.Context())
+		if err != nil {
+			logger.Error("error getting all departments", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, departments)
+	}
+}
+
+func HandleGetDepartment(logger *httplog.Logger, service departmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid department ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid department ID")
+			return
+		}
+
+		department, err := service.GetDepartment(ctx, id)
+		if err != nil {
+			logger.Error("error getting department", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, department)
+	}
+}
+
+func HandleCreateDepartment(logger *httplog.Logger, service departmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var department models.Department
+		if err := json.NewDecoder(r.Body).Decode(&department); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateDepartment(department); err != nil {
+			logger.Error("invalid department data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		department, err := service.CreateDepartment(ctx, department)
+		if err != nil {
+			logger.Error("error creating department", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, department)
+	}
+}
+
+func HandleUpdateDepartment(logger *httplog.Logger, service departmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var department models.Department
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid department ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid department ID")
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&department); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateDepartment(department); err != nil {
+			logger.Error("invalid department data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		department, err = service.UpdateDepartment(ctx, id, department)
+		if err != nil {
+			logger.Error("error updating department", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, department)
+	}
+}
+
+func HandleDeleteDepartment(logger *httplog.Logger, service departmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid department ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid department ID")
+			return
+		}
+
+		if err := service.DeleteDepartment(ctx, id); err != nil {
+			logger.Error("error deleting department", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Department has successfully been deleted")
+	}
+}
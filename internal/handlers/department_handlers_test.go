@@ -0,0 +1,192 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDepartmentGetter struct {
+	mock.Mock
+}
+
+func (m *mockDepartmentGetter) GetDepartments(ctx context.Context) ([]models.Department, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Department), args.Error(1)
+}
+
+func (m *mockDepartmentGetter) GetDepartment(ctx context.Context, id int) (models.Department, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Department), args.Error(1)
+}
+
+func (m *mockDepartmentGetter) CreateDepartment(ctx context.Context, department models.Department) (models.Department, error) {
+	args := m.Called(ctx, department)
+	return args.Get(0).(models.Department), args.Error(1)
+}
+
+func (m *mockDepartmentGetter) UpdateDepartment(ctx context.Context, id int, department models.Department) (models.Department, error) {
+	args := m.Called(ctx, id, department)
+	return args.Get(0).(models.Department), args.Error(1)
+}
+
+func (m *mockDepartmentGetter) DeleteDepartment(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func newDepartmentRouter(service *mockDepartmentGetter) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/department", handlers.HandleGetDepartments(logger, service))
+	r.Get("/api/department/{id}", handlers.HandleGetDepartment(logger, service))
+	r.Post("/api/department", handlers.HandleCreateDepartment(logger, service))
+	r.Put("/api/department/{id}", handlers.HandleUpdateDepartment(logger, service))
+	r.Delete("/api/department/{id}", handlers.HandleDeleteDepartment(logger, service))
+	return r
+}
+
+var computerScience = models.Department{ID: 1, Code: "CS", Name: "Computer Science"}
+
+func TestHandleGetDepartments(t *testing.T) {
+	mockService := new(mockDepartmentGetter)
+	mockService.On("GetDepartments", mock.Anything).Return([]models.Department{computerScience}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/department", nil)
+	rr := httptest.NewRecorder()
+	newDepartmentRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id": 1, "code": "CS", "name": "Computer Science"}]`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetDepartment(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "Success", url: "/api/department/1", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not Found", url: "/api/department/1", mockError: services.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "Invalid ID", url: "/api/department/cs", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockDepartmentGetter)
+			if tt.expectCall {
+				mockService.On("GetDepartment", mock.Anything, 1).Return(computerScience, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newDepartmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Department
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, computerScience, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCreateDepartment(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"code": "CS", "name": "Computer Science"}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Duplicate Code", body: `{"code": "CS", "name": "Computer Science"}`, mockError: services.ErrConflict, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeConflict},
+		{name: "Invalid Code", body: `{"code": "C.S.", "name": "Computer Science"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Body", body: `{"code": 1}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockDepartmentGetter)
+			if tt.expectCall {
+				requested := computerScience
+				requested.ID = 0
+				mockService.On("CreateDepartment", mock.Anything, requested).Return(computerScience, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("POST", "/api/department", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newDepartmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Department
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, computerScience, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateDepartment(t *testing.T) {
+	mockService := new(mockDepartmentGetter)
+	requested := computerScience
+	requested.ID = 0
+	mockService.On("UpdateDepartment", mock.Anything, 1, requested).Return(computerScience, nil)
+
+	req, _ := http.NewRequest("PUT", "/api/department/1", strings.NewReader(`{"code": "CS", "name": "Computer Science"}`))
+	rr := httptest.NewRecorder()
+	newDepartmentRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleDeleteDepartment(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "Has Courses", mockError: services.ErrConflict, expectedStatus: http.StatusConflict},
+		{name: "Error", mockError: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockDepartmentGetter)
+			mockService.On("DeleteDepartment", mock.Anything, 1).Return(tt.mockError)
+
+			req, _ := http.NewRequest("DELETE", "/api/department/1", nil)
+			rr := httptest.NewRecorder()
+			newDepartmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/httplog/v2"
+)
+
+type enrollmentGetter interface {
+	GetCourseEnrollments(ctx context.Context, courseID int, term services.TermScope) ([]models.Enrollment, error)
+	GetPersonEnrollments(ctx context.Context, personID int, term services.TermScope) ([]models.Enrollment, error)
+	GetCourseWaitlist(ctx context.Context, courseID int, term services.TermScope) ([]models.Enrollment, error)
+	Enroll(ctx context.Context, enrollment models.Enrollment, opts services.EnrollOptions) (models.Enrollment, error)
+	Unenroll(ctx context.Context, personID, courseID int, termID *int) error
+}
+
+// HandleGetCourseEnrollments lists the enrollments of a course. The term
+// query parameter, either a term ID or one of current, past and future,
+// keeps only the enrollments in those terms.
+func HandleGetCourseEnrollments(logger *httplog.Logger, service enrollmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		term, termErrs := parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid course enrollment query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		enrollments, err := service.GetCourseEnrollments(ctx, courseID, term)
+		if err != nil {
+			logger.Error("error getting course enrollments", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, enrollments)
+	}
+}
+
+func HandleGetCourseWaitlist(logger *httplog.Logger, service enrollmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		term, termErrs := parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid course enrollment query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		waitlist, err := service.GetCourseWaitlist(ctx, courseID, term)
+		if err != nil {
+			logger.Error("error getting course waitlist", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, waitlist)
+	}
+}
+
+func HandleGetPersonEnrollments(logger *httplog.Logger, service enrollmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		personID, errs := pathID(r, "id")
+		term, termErrs := parseTermScope(r)
+		errs = append(errs, termErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid person enrollment query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid request parameters", errs...)
+			return
+		}
+
+		enrollments, err := service.GetPersonEnrollments(ctx, personID, term)
+		if err != nil {
+			logger.Error("error getting person enrollments", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, enrollments)
+	}
+}
+
+// HandleCreateCourseEnrollment enrolls the person named by person_id in the
+// body in the course addressed by the URL. See createEnrollment for what
+// happens when the course is full.
+func HandleCreateCourseEnrollment(logger *httplog.Logger, service enrollmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid course ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID", errs...)
+			return
+		}
+
+		var enrollment models.Enrollment
+		if err := json.NewDecoder(r.Body).Decode(&enrollment); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		enrollment.CourseID = courseID
+
+		createEnrollment(w, r, logger, service, enrollment)
+	}
+}
+
+// HandleCreatePersonEnrollment enrolls the person addressed by the URL in
+// the course named by course_id in the body. See createEnrollment for what
+// happens when the course is full.
+func HandleCreatePersonEnrollment(logger *httplog.Logger, service enrollmentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		personID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		var enrollment models.Enrollment
+		if err := json.NewDecoder(r.Body).Decode(&enrollment); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		enrollment.PersonID = personID
+
+		createEnrollment(w, r, logger, service, enrollment)
+	}
+}
+
+// createEnrollment stores enrollment in the role named by the body's role,
+// which defaults to instructor for professors and student for students.
+// When the course is full the request fails with a course-full problem,
+// unless the waitlist query parameter is true, in which case the person
+// joins the course's waitlist instead. A course that clashes with the
+// person's timetable fails with a schedule-conflict problem unless
+// override_conflicts is true; the override is meant for administrators.
+func createEnrollment(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, service enrollmentGetter, enrollment models.Enrollment) {
+	var opts services.EnrollOptions
+	var errs []FieldError
+	for _, param := range []struct {
+		name string
+		dest *bool
+	}{
+		{"waitlist", &opts.Waitlist},
+		{"override_conflicts", &opts.OverrideConflicts},
+	} {
+		value := r.URL.Query().Get(param.name)
+		if value == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, FieldError{Field: param.name, Code: utils.CodeInvalid, Detail: "must be a boolean"})
+			continue
+		}
+		*param.dest = b
+	}
+	if len(errs) > 0 {
+		logger.Error("invalid enrollment query", "errors", errs)
+		EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+		return
+	}
+	if err := utils.ValidateEnrollment(enrollment); err != nil {
+		logger.Error("invalid enrollment data", "error", err)
+		EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+		return
+	}
+
+	enrollment, err := service.Enroll(r.Context(), enrollment, opts)
+	if err != nil {
+		logger.Error("error creating enrollment", "error", err)
+		EncodeServiceError(w, r, logger, err, "Error creating data")
+		return
+	}
+	EncodeResponse(w, logger, http.StatusOK, enrollment)
+}
+
+// HandleDeleteCourseEnrollment removes a person from the course addressed by
+// the URL. The term query parameter names the term of the enrollment; without
+// it the enrollment not tied to a term is removed.
+func HandleDeleteCourseEnrollment(logger *httplog.Logger, service enrollmentGetter) http.HandlerFunc {
+	return handleDeleteEnrollment(logger, service, "personID", "id")
+}
+
+// HandleDeletePersonEnrollment is HandleDeleteCourseEnrollment for routes
+// under the person.
+func HandleDeletePersonEnrollment(logger *httplog.Logger, service enrollmentGetter) http.HandlerFunc {
+	return handleDeleteEnrollment(logger, service, "id", "courseID")
+}
+
+func handleDeleteEnrollment(logger *httplog.Logger, service enrollmentGetter, personParam, courseParam string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		personID, errs := pathID(r, personParam)
+		courseID, courseErrs := pathID(r, courseParam)
+		errs = append(errs, courseErrs...)
+		var termID *int
+		term, termErrs := parseTermScope(r)
+		switch {
+		case len(termErrs) > 0:
+			errs = append(errs, termErrs...)
+		case term.When != "":
+			errs = append(errs, FieldError{Field: "term", Code: utils.CodeInvalid, Detail: "must be a term ID"})
+		case term.ID != 0:
+			termID = &term.ID
+		}
+		if len(errs) > 0 {
+			logger.Error("invalid enrollment IDs", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid enrollment IDs", errs...)
+			return
+		}
+
+		if err := service.Unenroll(ctx, personID, courseID, termID); err != nil {
+			logger.Error("error deleting enrollment", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Enrollment has successfully been deleted")
+	}
+}
@@ -0,0 +1,255 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockEnrollmentGetter struct {
+	mock.Mock
+}
+
+func (m *mockEnrollmentGetter) GetCourseEnrollments(ctx context.Context, courseID int, term services.TermScope) ([]models.Enrollment, error) {
+	args := m.Called(ctx, courseID, term)
+	return args.Get(0).([]models.Enrollment), args.Error(1)
+}
+
+func (m *mockEnrollmentGetter) GetPersonEnrollments(ctx context.Context, personID int, term services.TermScope) ([]models.Enrollment, error) {
+	args := m.Called(ctx, personID, term)
+	return args.Get(0).([]models.Enrollment), args.Error(1)
+}
+
+func (m *mockEnrollmentGetter) GetCourseWaitlist(ctx context.Context, courseID int, term services.TermScope) ([]models.Enrollment, error) {
+	args := m.Called(ctx, courseID, term)
+	return args.Get(0).([]models.Enrollment), args.Error(1)
+}
+
+func (m *mockEnrollmentGetter) Enroll(ctx context.Context, enrollment models.Enrollment, opts services.EnrollOptions) (models.Enrollment, error) {
+	args := m.Called(ctx, enrollment, opts)
+	return args.Get(0).(models.Enrollment), args.Error(1)
+}
+
+func (m *mockEnrollmentGetter) Unenroll(ctx context.Context, personID, courseID int, termID *int) error {
+	args := m.Called(ctx, personID, courseID, termID)
+	return args.Error(0)
+}
+
+func newEnrollmentRouter(service *mockEnrollmentGetter) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/course/{id}/enrollments", handlers.HandleGetCourseEnrollments(logger, service))
+	r.Post("/api/course/{id}/enrollments", handlers.HandleCreateCourseEnrollment(logger, service))
+	r.Delete("/api/course/{id}/enrollments/{personID}", handlers.HandleDeleteCourseEnrollment(logger, service))
+	r.Get("/api/course/{id}/waitlist", handlers.HandleGetCourseWaitlist(logger, service))
+	r.Get("/api/person/{id}/courses", handlers.HandleGetPersonEnrollments(logger, service))
+	r.Post("/api/person/{id}/courses", handlers.HandleCreatePersonEnrollment(logger, service))
+	r.Delete("/api/person/{id}/courses/{courseID}", handlers.HandleDeletePersonEnrollment(logger, service))
+	return r
+}
+
+func TestHandleGetEnrollments(t *testing.T) {
+	enrollments := []models.Enrollment{{PersonID: 3, CourseID: 1, Status: models.EnrollmentStatusEnrolled, EnrolledAt: time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)}}
+
+	tests := []struct {
+		name           string
+		url            string
+		setup          func(m *mockEnrollmentGetter)
+		expectedStatus int
+	}{
+		{
+			name: "Course",
+			url:  "/api/course/1/enrollments",
+			setup: func(m *mockEnrollmentGetter) {
+				m.On("GetCourseEnrollments", mock.Anything, 1, services.TermScope{}).Return(enrollments, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Person",
+			url:  "/api/person/3/courses",
+			setup: func(m *mockEnrollmentGetter) {
+				m.On("GetPersonEnrollments", mock.Anything, 3, services.TermScope{}).Return(enrollments, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Waitlist",
+			url:  "/api/course/1/waitlist",
+			setup: func(m *mockEnrollmentGetter) {
+				m.On("GetCourseWaitlist", mock.Anything, 1, services.TermScope{}).Return(enrollments, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Unknown Course",
+			url:  "/api/course/9/enrollments",
+			setup: func(m *mockEnrollmentGetter) {
+				m.On("GetCourseEnrollments", mock.Anything, 9, services.TermScope{}).Return([]models.Enrollment{}, services.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Current Term",
+			url:  "/api/person/3/courses?term=current",
+			setup: func(m *mockEnrollmentGetter) {
+				m.On("GetPersonEnrollments", mock.Anything, 3, services.TermScope{When: services.TermCurrent}).Return(enrollments, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Term ID",
+			url:  "/api/course/1/enrollments?term=2",
+			setup: func(m *mockEnrollmentGetter) {
+				m.On("GetCourseEnrollments", mock.Anything, 1, services.TermScope{ID: 2}).Return(enrollments, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Term",
+			url:            "/api/course/1/waitlist?term=soon",
+			setup:          func(m *mockEnrollmentGetter) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Person ID",
+			url:            "/api/person/abc/courses",
+			setup:          func(m *mockEnrollmentGetter) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockEnrollmentGetter)
+			tt.setup(mockService)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newEnrollmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body []models.Enrollment
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, enrollments, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCreateEnrollment(t *testing.T) {
+	enrollment := models.Enrollment{PersonID: 3, CourseID: 1, Role: models.RoleStudent, Status: models.EnrollmentStatusEnrolled, EnrolledAt: time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)}
+	termID := 2
+
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		termID         *int
+		role           string
+		opts           services.EnrollOptions
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Via Course", url: "/api/course/1/enrollments", body: `{"person_id": 3}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Via Person", url: "/api/person/3/courses", body: `{"course_id": 1}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "In Term", url: "/api/person/3/courses", body: `{"course_id": 1, "term_id": 2}`, termID: &termID, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "As Teaching Assistant", url: "/api/course/1/enrollments", body: `{"person_id": 3, "role": "teaching_assistant"}`, role: models.RoleTeachingAssistant, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Role Not Allowed", url: "/api/course/1/enrollments", body: `{"person_id": 3, "role": "instructor"}`, role: models.RoleInstructor, mockError: services.ErrConstraintViolation, expectCall: true, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeConstraintViolation},
+		{name: "Unknown Role", url: "/api/course/1/enrollments", body: `{"person_id": 3, "role": "dean"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Waitlist", url: "/api/course/1/enrollments?waitlist=true", body: `{"person_id": 3}`, opts: services.EnrollOptions{Waitlist: true}, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Override Conflicts", url: "/api/person/3/courses?override_conflicts=true", body: `{"course_id": 1}`, opts: services.EnrollOptions{OverrideConflicts: true}, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Duplicate", url: "/api/course/1/enrollments", body: `{"person_id": 3}`, mockError: services.ErrConflict, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeConflict},
+		{name: "Course Full", url: "/api/course/1/enrollments", body: `{"person_id": 3}`, mockError: services.ErrCourseFull, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeCourseFull},
+		{name: "Missing Prerequisites", url: "/api/course/1/enrollments", body: `{"person_id": 3}`, mockError: &services.MissingPrerequisitesError{CourseID: 1, Missing: []models.Course{{ID: 2, Name: "Programming"}}}, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeMissingPrerequisites},
+		{name: "Schedule Conflict", url: "/api/person/3/courses", body: `{"course_id": 1}`, mockError: &services.ScheduleConflictError{CourseID: 1, Conflicts: []models.ScheduleConflict{{CourseID: 2, CourseName: "Databases"}}}, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeScheduleConflict},
+		{name: "Credit Limit", url: "/api/person/3/courses", body: `{"course_id": 1}`, mockError: &services.CreditLimitError{PersonID: 3, Limit: 18, Credits: 21}, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeCreditLimitExceeded},
+		{name: "Unknown Person", url: "/api/course/1/enrollments", body: `{"person_id": 3}`, mockError: services.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound, expectedType: handlers.ProblemTypeNotFound},
+		{name: "Missing Person", url: "/api/course/1/enrollments", body: `{}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Invalid Term", url: "/api/person/3/courses", body: `{"course_id": 1, "term_id": 0}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Body", url: "/api/person/3/courses", body: `{"course_id": "one"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+		{name: "Invalid Waitlist", url: "/api/course/1/enrollments?waitlist=maybe", body: `{"person_id": 3}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidParameter},
+		{name: "Invalid Override", url: "/api/person/3/courses?override_conflicts=yes please", body: `{"course_id": 1}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockEnrollmentGetter)
+			if tt.expectCall {
+				requested := models.Enrollment{PersonID: 3, CourseID: 1, TermID: tt.termID, Role: tt.role}
+				if tt.mockError != nil {
+					mockService.On("Enroll", mock.Anything, requested, tt.opts).Return(models.Enrollment{}, tt.mockError)
+				} else {
+					mockService.On("Enroll", mock.Anything, requested, tt.opts).Return(enrollment, nil)
+				}
+			}
+
+			req, _ := http.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newEnrollmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Enrollment
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, enrollment, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteEnrollment(t *testing.T) {
+	termID := 2
+
+	tests := []struct {
+		name           string
+		url            string
+		termID         *int
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "Via Course", url: "/api/course/1/enrollments/3", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Via Person", url: "/api/person/3/courses/1", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "In Term", url: "/api/person/3/courses/1?term=2", termID: &termID, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not Enrolled", url: "/api/person/3/courses/1", mockError: services.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "Relative Term", url: "/api/course/1/enrollments/3?term=current", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockEnrollmentGetter)
+			if tt.expectCall {
+				mockService.On("Unenroll", mock.Anything, 3, 1, tt.termID).Return(tt.mockError)
+			}
+
+			req, _ := http.NewRequest("DELETE", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newEnrollmentRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
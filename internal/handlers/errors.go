@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/httplog/v2"
+)
+
+// StatusFromError maps a service error to the HTTP status code that should be
+// returned to the client. Errors that do not carry a service error kind are
+// treated as internal server errors.
+func StatusFromError(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidReference):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrConstraintViolation):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, services.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// EncodeServiceError writes the problem response for an error returned by a
+// service. The detail message is only used for internal server errors; every
+// other status is described by its problem type.
+func EncodeServiceError(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, err error, detail string) {
+	status := StatusFromError(err)
+	problemType := ProblemTypeInternal
+	switch status {
+	case http.StatusNotFound:
+		problemType = ProblemTypeNotFound
+	case http.StatusConflict:
+		problemType = ProblemTypeConflict
+		if errors.Is(err, services.ErrCourseFull) {
+			problemType = ProblemTypeCourseFull
+		}
+	case http.StatusUnprocessableEntity:
+		problemType = ProblemTypeInvalidReference
+	case http.StatusBadRequest:
+		problemType = ProblemTypeConstraintViolation
+	case http.StatusServiceUnavailable:
+		problemType = ProblemTypeUnavailable
+	case http.StatusForbidden:
+		problemType = ProblemTypeForbidden
+	}
+	if status != http.StatusInternalServerError {
+		detail = problemTitles[problemType]
+	}
+
+	var ambiguous *services.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		body := newProblem(r, status, ProblemTypeAmbiguousName, "Address the person by ID using one of the candidates")
+		body.Candidates = ambiguous.Candidates
+		writeProblem(w, logger, body)
+		return
+	}
+	var cycle *services.PrerequisiteCycleError
+	if errors.As(err, &cycle) {
+		body := newProblem(r, status, ProblemTypePrerequisiteCycle, cycle.Error())
+		body.Cycle = cycle.Cycle
+		writeProblem(w, logger, body)
+		return
+	}
+	var missing *services.MissingPrerequisitesError
+	if errors.As(err, &missing) {
+		body := newProblem(r, status, ProblemTypeMissingPrerequisites, "Complete the missing prerequisites before enrolling")
+		body.MissingPrerequisites = missing.Missing
+		writeProblem(w, logger, body)
+		return
+	}
+	var clash *services.ScheduleConflictError
+	if errors.As(err, &clash) {
+		body := newProblem(r, status, ProblemTypeScheduleConflict, "Drop the clashing courses or ask an administrator to override the conflict")
+		body.Conflicts = clash.Conflicts
+		writeProblem(w, logger, body)
+		return
+	}
+	var booked *services.RoomConflictError
+	if errors.As(err, &booked) {
+		body := newProblem(r, status, ProblemTypeRoomConflict, "Book another room or time for the clashing meetings")
+		body.Conflicts = booked.Conflicts
+		writeProblem(w, logger, body)
+		return
+	}
+	var overload *services.CreditLimitError
+	if errors.As(err, &overload) {
+		body := newProblem(r, status, ProblemTypeCreditLimitExceeded, overload.Error())
+		body.CreditLimit = &overload.Limit
+		body.Credits = &overload.Credits
+		writeProblem(w, logger, body)
+		return
+	}
+	var advising *services.AdviseesError
+	if errors.As(err, &advising) {
+		body := newProblem(r, status, ProblemTypeHasAdvisees, "Reassign the advisees with reassign_advisees_to before deleting the professor")
+		body.Advisees = advising.Advisees
+		writeProblem(w, logger, body)
+		return
+	}
+	EncodeProblem(w, r, logger, status, problemType, detail)
+}
@@ -0,0 +1,62 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusFromError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "Not Found",
+			err:            fmt.Errorf("[in services.GetCourse] course not found: %w", services.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Conflict",
+			err:            &services.Error{Kind: services.ErrConflict, Err: errors.New("duplicate key")},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Invalid Reference",
+			err:            &services.Error{Kind: services.ErrInvalidReference, Err: errors.New("foreign key")},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Constraint Violation",
+			err:            services.ErrConstraintViolation,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unavailable",
+			err:            services.ErrUnavailable,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "Forbidden",
+			err:            fmt.Errorf("[in services.PostGrades] not a professor of the course: %w", services.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Unknown",
+			err:            errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expectedStatus, handlers.StatusFromError(tt.err))
+		})
+	}
+}
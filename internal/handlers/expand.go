@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/go-chi/httplog/v2"
+)
+
+type personCoursesGetter interface {
+	GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error)
+}
+
+type coursePeopleGetter interface {
+	GetPeopleByCourse(ctx context.Context, courseIDs []int) (map[int][]models.Person, error)
+}
+
+// parseExpand reads the expand query parameter, which may be repeated or
+// given as a comma separated list. Only the names in allowed are accepted.
+func parseExpand(r *http.Request, allowed ...string) (map[string]bool, []FieldError) {
+	expand := map[string]bool{}
+	for _, value := range r.URL.Query()["expand"] {
+		for _, name := range strings.Split(value, ",") {
+			if !slices.Contains(allowed, name) {
+				return nil, []FieldError{{
+					Field:  "expand",
+					Code:   utils.CodeInvalid,
+					Detail: fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")),
+				}}
+			}
+			expand[name] = true
+		}
+	}
+	return expand, nil
+}
+
+// expandPeople embeds the full courses of each person, loading the courses of
+// all of them at once.
+func expandPeople(ctx context.Context, service personCoursesGetter, people []models.Person) ([]models.PersonWithCourses, error) {
+	ids := make([]int, len(people))
+	for i, person := range people {
+		ids[i] = person.ID
+	}
+	courses, err := service.GetCoursesByPerson(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := make([]models.PersonWithCourses, len(people))
+	for i, person := range people {
+		expanded[i] = models.PersonWithCourses{Person: person, Courses: courses[person.ID]}
+		if expanded[i].Courses == nil {
+			expanded[i].Courses = []models.Course{}
+		}
+	}
+	return expanded, nil
+}
+
+// expandCourses embeds the people enrolled in each course, loading the
+// rosters of all of them at once.
+func expandCourses(ctx context.Context, service coursePeopleGetter, courses []models.Course) ([]models.CourseWithPeople, error) {
+	ids := make([]int, len(courses))
+	for i, course := range courses {
+		ids[i] = course.ID
+	}
+	people, err := service.GetPeopleByCourse(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := make([]models.CourseWithPeople, len(courses))
+	for i, course := range courses {
+		expanded[i] = models.CourseWithPeople{Course: course, People: people[course.ID]}
+		if expanded[i].People == nil {
+			expanded[i].People = []models.Person{}
+		}
+	}
+	return expanded, nil
+}
+
+// encodePeople writes people, embedding their courses when expand asks for
+// them.
+func encodePeople(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, service personCoursesGetter, expand map[string]bool, people []models.Person) {
+	if !expand["courses"] {
+		EncodeResponse(w, logger, http.StatusOK, people)
+		return
+	}
+	expanded, err := expandPeople(r.Context(), service, people)
+	if err != nil {
+		logger.Error("error expanding courses", "error", err)
+		EncodeServiceError(w, r, logger, err, "Error retrieving courses")
+		return
+	}
+	EncodeResponse(w, logger, http.StatusOK, expanded)
+}
+
+// encodePerson is encodePeople for a single person.
+func encodePerson(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, service personCoursesGetter, expand map[string]bool, person models.Person) {
+	if !expand["courses"] {
+		EncodeResponse(w, logger, http.StatusOK, person)
+		return
+	}
+	expanded, err := expandPeople(r.Context(), service, []models.Person{person})
+	if err != nil {
+		logger.Error("error expanding courses", "error", err)
+		EncodeServiceError(w, r, logger, err, "Error retrieving courses")
+		return
+	}
+	EncodeResponse(w, logger, http.StatusOK, expanded[0])
+}
+
+// encodeCourses writes courses, embedding the people enrolled in them when
+// expand asks for them.
+func encodeCourses(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, service coursePeopleGetter, expand map[string]bool, courses []models.Course) {
+	if !expand["people"] {
+		EncodeResponse(w, logger, http.StatusOK, courses)
+		return
+	}
+	expanded, err := expandCourses(r.Context(), service, courses)
+	if err != nil {
+		logger.Error("error expanding people", "error", err)
+		EncodeServiceError(w, r, logger, err, "Error retrieving people")
+		return
+	}
+	EncodeResponse(w, logger, http.StatusOK, expanded)
+}
+
+// encodeCourse is encodeCourses for a single course.
+func encodeCourse(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, service coursePeopleGetter, expand map[string]bool, course models.Course) {
+	if !expand["people"] {
+		EncodeResponse(w, logger, http.StatusOK, course)
+		return
+	}
+	expanded, err := expandCourses(r.Context(), service, []models.Course{course})
+	if err != nil {
+		logger.Error("error expanding people", "error", err)
+		EncodeServiceError(w, r, logger, err, "Error retrieving people")
+		return
+	}
+	EncodeResponse(w, logger, http.StatusOK, expanded[0])
+}
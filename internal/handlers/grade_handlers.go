@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+)
+
+type gradeManager interface {
+	GetGradeScale(ctx context.Context) ([]models.GradeScaleEntry, error)
+	SetGradeScaleEntry(ctx context.Context, entry models.GradeScaleEntry) (models.GradeScaleEntry, error)
+	DeleteGradeScaleEntry(ctx context.Context, letter string) error
+	GetCourseGrades(ctx context.Context, courseID int, term services.TermScope) ([]models.Grade, error)
+	PostGrades(ctx context.Context, courseID int, submission models.GradeSubmission) ([]models.Grade, error)
+	GetTranscript(ctx context.Context, personID int) (models.Transcript, error)
+}
+
+func HandleGetGradeScale(logger *httplog.Logger, service gradeManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scale, err := service.GetGradeScale(r.Context())
+		if err != nil {
+			logger.Error("error getting grade scale", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, scale)
+	}
+}
+
+// HandleSetGradeScaleEntry adds the letter in the URL to the grading scale,
+// or changes its grade points, from a body such as {"points": 3.7}. A null
+// points value keeps the letter out of GPAs.
+func HandleSetGradeScaleEntry(logger *httplog.Logger, service gradeManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var entry models.GradeScaleEntry
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		entry.Letter = chi.URLParam(r, "letter")
+		if err := utils.ValidateGradeScaleEntry(entry); err != nil {
+			logger.Error("invalid grade scale data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		entry, err := service.SetGradeScaleEntry(ctx, entry)
+		if err != nil {
+			logger.Error("error setting grade scale entry", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, entry)
+	}
+}
+
+func HandleDeleteGradeScaleEntry(logger *httplog.Logger, service gradeManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := service.DeleteGradeScaleEntry(r.Context(), chi.URLParam(r, "letter")); err != nil {
+			logger.Error("error deleting grade scale entry", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Grade has successfully been deleted")
+	}
+}
+
+// HandleGetCourseGrades lists the grades of the students of a course. The
+// term query parameter keeps only the grades of the given terms.
+func HandleGetCourseGrades(logger *httplog.Logger, service gradeManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid course ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
+			return
+		}
+		term, errs := parseTermScope(r)
+		if len(errs) > 0 {
+			logger.Error("invalid course grades query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
+		grades, err := service.GetCourseGrades(ctx, id, term)
+		if err != nil {
+			logger.Error("error getting course grades", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, grades)
+	}
+}
+
+// HandlePostCourseGrades posts grades for students of a course. The body
+// names the professor posting them, who must teach the course in the term,
+// and the grades to post. Either every grade is stored or none is.
+func HandlePostCourseGrades(logger *httplog.Logger, service gradeManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid course ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
+			return
+		}
+
+		var submission models.GradeSubmission
+		if err := json.NewDecoder(r.Body).Decode(&submission); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateGradeSubmission(submission); err != nil {
+			logger.Error("invalid grade submission", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		grades, err := service.PostGrades(ctx, id, submission)
+		if err != nil {
+			logger.Error("error posting grades", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, grades)
+	}
+}
+
+// HandleGetTranscript returns a person's courses and grades by term with
+// the GPA of each term and the cumulative GPA.
+func HandleGetTranscript(logger *httplog.Logger, service gradeManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid person ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID")
+			return
+		}
+
+		transcript, err := service.GetTranscript(ctx, id)
+		if err != nil {
+			logger.Error("error getting transcript", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, transcript)
+	}
+}
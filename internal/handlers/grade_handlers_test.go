@@ -0,0 +1,242 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockGradeManager struct {
+	mock.Mock
+}
+
+func (m *mockGradeManager) GetGradeScale(ctx context.Context) ([]models.GradeScaleEntry, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.GradeScaleEntry), args.Error(1)
+}
+
+func (m *mockGradeManager) SetGradeScaleEntry(ctx context.Context, entry models.GradeScaleEntry) (models.GradeScaleEntry, error) {
+	args := m.Called(ctx, entry)
+	return args.Get(0).(models.GradeScaleEntry), args.Error(1)
+}
+
+func (m *mockGradeManager) DeleteGradeScaleEntry(ctx context.Context, letter string) error {
+	args := m.Called(ctx, letter)
+	return args.Error(0)
+}
+
+func (m *mockGradeManager) GetCourseGrades(ctx context.Context, courseID int, term services.TermScope) ([]models.Grade, error) {
+	args := m.Called(ctx, courseID, term)
+	return args.Get(0).([]models.Grade), args.Error(1)
+}
+
+func (m *mockGradeManager) PostGrades(ctx context.Context, courseID int, submission models.GradeSubmission) ([]models.Grade, error) {
+	args := m.Called(ctx, courseID, submission)
+	return args.Get(0).([]models.Grade), args.Error(1)
+}
+
+func (m *mockGradeManager) GetTranscript(ctx context.Context, personID int) (models.Transcript, error) {
+	args := m.Called(ctx, personID)
+	return args.Get(0).(models.Transcript), args.Error(1)
+}
+
+func newGradeRouter(service *mockGradeManager) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/grade-scale", handlers.HandleGetGradeScale(logger, service))
+	r.Put("/api/grade-scale/{letter}", handlers.HandleSetGradeScaleEntry(logger, service))
+	r.Delete("/api/grade-scale/{letter}", handlers.HandleDeleteGradeScaleEntry(logger, service))
+	r.Get("/api/course/{id}/grades", handlers.HandleGetCourseGrades(logger, service))
+	r.Put("/api/course/{id}/grades", handlers.HandlePostCourseGrades(logger, service))
+	r.Get("/api/person/{id}/transcript", handlers.HandleGetTranscript(logger, service))
+	return r
+}
+
+func TestHandleGradeScale(t *testing.T) {
+	points := 3.7
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		setup          func(m *mockGradeManager)
+		expectedStatus int
+	}{
+		{
+			name:   "Get",
+			method: "GET",
+			url:    "/api/grade-scale",
+			setup: func(m *mockGradeManager) {
+				m.On("GetGradeScale", mock.Anything).Return([]models.GradeScaleEntry{{Letter: "A-", Points: &points}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Set",
+			method: "PUT",
+			url:    "/api/grade-scale/A-",
+			body:   `{"points": 3.7}`,
+			setup: func(m *mockGradeManager) {
+				entry := models.GradeScaleEntry{Letter: "A-", Points: &points}
+				m.On("SetGradeScaleEntry", mock.Anything, entry).Return(entry, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Set Out Of Range",
+			method:         "PUT",
+			url:            "/api/grade-scale/A-",
+			body:           `{"points": -1}`,
+			setup:          func(m *mockGradeManager) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Delete Posted Letter",
+			method: "DELETE",
+			url:    "/api/grade-scale/W",
+			setup: func(m *mockGradeManager) {
+				m.On("DeleteGradeScaleEntry", mock.Anything, "W").Return(services.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockGradeManager)
+			tt.setup(mockService)
+
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newGradeRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetCourseGrades(t *testing.T) {
+	mockService := new(mockGradeManager)
+	grade := "A"
+	grades := []models.Grade{{PersonID: 3, CourseID: 1, Grade: &grade}}
+	mockService.On("GetCourseGrades", mock.Anything, 1, services.TermScope{When: services.TermPast}).Return(grades, nil)
+
+	req, _ := http.NewRequest("GET", "/api/course/1/grades?term=past", nil)
+	rr := httptest.NewRecorder()
+	newGradeRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var body []models.Grade
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, grades, body)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlePostCourseGrades(t *testing.T) {
+	termID := 2
+	submission := models.GradeSubmission{ProfessorID: 1, TermID: &termID, Grades: []models.GradeEntry{{PersonID: 3, Grade: "A"}}}
+	body := `{"professor_id": 1, "term_id": 2, "grades": [{"person_id": 3, "grade": "A"}]}`
+
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: body, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not The Professor", body: body, mockError: services.ErrForbidden, expectCall: true, expectedStatus: http.StatusForbidden, expectedType: handlers.ProblemTypeForbidden},
+		{name: "Not On Roster", body: body, mockError: services.ErrInvalidReference, expectCall: true, expectedStatus: http.StatusUnprocessableEntity, expectedType: handlers.ProblemTypeInvalidReference},
+		{name: "Duplicate Student", body: `{"professor_id": 1, "grades": [{"person_id": 3, "grade": "A"}, {"person_id": 3, "grade": "B"}]}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Body", body: `{"grades": "A"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockGradeManager)
+			if tt.expectCall {
+				mockService.On("PostGrades", mock.Anything, 1, submission).Return([]models.Grade{}, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("PUT", "/api/course/1/grades", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newGradeRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetTranscript(t *testing.T) {
+	gpa := 3.5
+	transcript := models.Transcript{PersonID: 3, Terms: []models.TranscriptTerm{}, CumulativeGPA: &gpa}
+
+	tests := []struct {
+		name           string
+		url            string
+		setup          func(m *mockGradeManager)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			url:  "/api/person/3/transcript",
+			setup: func(m *mockGradeManager) {
+				m.On("GetTranscript", mock.Anything, 3).Return(transcript, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Unknown Person",
+			url:  "/api/person/9/transcript",
+			setup: func(m *mockGradeManager) {
+				m.On("GetTranscript", mock.Anything, 9).Return(models.Transcript{}, services.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid ID",
+			url:            "/api/person/abc/transcript",
+			setup:          func(m *mockGradeManager) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockGradeManager)
+			tt.setup(mockService)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newGradeRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Transcript
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, transcript, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
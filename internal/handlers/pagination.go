@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+)
+
+// parsePage reads the limit, cursor and total query parameters of a listing.
+func parsePage(r *http.Request) (services.Page, []FieldError) {
+	queryParams := r.URL.Query()
+	var page services.Page
+	var errs []FieldError
+
+	if limit := queryParams.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > services.MaxPageLimit {
+			errs = append(errs, FieldError{
+				Field:  "limit",
+				Code:   utils.CodeOutOfRange,
+				Detail: fmt.Sprintf("must be an integer between 1 and %d", services.MaxPageLimit),
+			})
+		}
+		page.Limit = n
+	}
+	if cursor := queryParams.Get("cursor"); cursor != "" {
+		c, err := services.DecodeCursor(cursor)
+		if err != nil {
+			errs = append(errs, FieldError{Field: "cursor", Code: utils.CodeInvalid, Detail: "is not a valid cursor"})
+		}
+		page.Cursor = &c
+	}
+	if total := queryParams.Get("total"); total != "" {
+		b, err := strconv.ParseBool(total)
+		if err != nil {
+			errs = append(errs, FieldError{Field: "total", Code: utils.CodeInvalid, Detail: "must be a boolean"})
+		}
+		page.WithTotal = b
+	}
+	return page, errs
+}
+
+// setPageHeaders advertises the neighbouring pages with an RFC 8288 Link
+// header and, when it was requested, the total number of items with
+// X-Total-Count.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, info services.PageInfo) {
+	var links []string
+	if info.NextCursor != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(r, info.NextCursor)))
+	}
+	if info.PrevCursor != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(r, info.PrevCursor)))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+	if info.Total != nil {
+		w.Header().Set("X-Total-Count", strconv.Itoa(*info.Total))
+	}
+}
+
+// pageURL returns the request URL with its cursor replaced, keeping every
+// other query parameter so filters carry over between pages.
+func pageURL(r *http.Request, cursor string) string {
+	queryParams := r.URL.Query()
+	queryParams.Set("cursor", cursor)
+	return r.URL.Path + "?" + queryParams.Encode()
+}
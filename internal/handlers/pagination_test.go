@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleGetCoursesPagination(t *testing.T) {
+	cursor := services.Cursor{ID: 2}
+	total := 5
+	info := services.PageInfo{
+		NextCursor: services.EncodeCursor(services.Cursor{ID: 4}),
+		PrevCursor: services.EncodeCursor(services.Cursor{ID: 3, Backward: true}),
+		Total:      &total,
+	}
+
+	mockService := new(mockCourseGetter)
+	mockService.On("GetCourses", mock.Anything, services.CourseFilter{}, services.Page{Limit: 2, Cursor: &cursor, WithTotal: true}).
+		Return([]models.Course{{ID: 3, Name: "Course 3"}, {ID: 4, Name: "Course 4"}}, info, nil)
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	handler := handlers.HandleGetCourses(logger, mockService)
+
+	req, _ := http.NewRequest("GET", "/api/course?limit=2&total=true&cursor="+services.EncodeCursor(cursor), nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "5", rr.Header().Get("X-Total-Count"))
+	assert.Equal(t,
+		`</api/course?cursor=`+info.NextCursor+`&limit=2&total=true>; rel="next", `+
+			`</api/course?cursor=`+info.PrevCursor+`&limit=2&total=true>; rel="prev"`,
+		rr.Header().Get("Link"))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetCoursesInvalidPage(t *testing.T) {
+	mockService := new(mockCourseGetter)
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	handler := handlers.HandleGetCourses(logger, mockService)
+
+	req, _ := http.NewRequest("GET", "/api/course?limit=1000&cursor=bogus!", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var errorResponse handlers.ResponseErr
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+	assert.Equal(t, handlers.ProblemTypeInvalidParameter, errorResponse.Type)
+	assert.Len(t, errorResponse.Errors, 2)
+
+	mockService.AssertExpectations(t)
+}
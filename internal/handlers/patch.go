@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/httplog/v2"
+)
+
+// Patch document media types accepted by the PATCH routes. Plain
+// application/json, or no content type at all, is treated as a merge patch.
+const (
+	MediaTypeMergePatch = "application/merge-patch+json"
+	MediaTypeJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	errUnsupportedPatch = errors.New("unsupported patch media type")
+	errPatchTestFailed  = errors.New("patch test operation failed")
+)
+
+// patchError reports a patch document that is well formed but cannot be
+// applied to the resource, such as one naming a path that does not exist.
+type patchError struct {
+	index int
+	err   error
+}
+
+func (e *patchError) Error() string {
+	return fmt.Sprintf("operation %d: %v", e.index, e.err)
+}
+
+func (e *patchError) Unwrap() error {
+	return e.err
+}
+
+// applyPatch applies the patch in the request body to current and returns
+// the result. The body is read as an RFC 7396 merge patch or an RFC 6902
+// JSON Patch depending on its Content-Type. Fields removed by the patch are
+// left at their zero value.
+func applyPatch[T any](r *http.Request, current T) (T, error) {
+	var patched T
+
+	mediaType := MediaTypeMergePatch
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return patched, errUnsupportedPatch
+		}
+	}
+
+	b, err := json.Marshal(current)
+	if err != nil {
+		return patched, err
+	}
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return patched, err
+	}
+
+	switch mediaType {
+	case MediaTypeMergePatch, "application/json":
+		var patch any
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			return patched, err
+		}
+		doc = mergePatch(doc, patch)
+	case MediaTypeJSONPatch:
+		var ops []patchOperation
+		if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+			return patched, err
+		}
+		for i, op := range ops {
+			if doc, err = op.apply(doc); err != nil {
+				return patched, &patchError{index: i, err: err}
+			}
+		}
+	default:
+		return patched, errUnsupportedPatch
+	}
+
+	if b, err = json.Marshal(doc); err != nil {
+		return patched, err
+	}
+	if err := json.Unmarshal(b, &patched); err != nil {
+		return patched, err
+	}
+	return patched, nil
+}
+
+// encodePatchError writes the problem response for an error returned by
+// applyPatch.
+func encodePatchError(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, err error) {
+	var pe *patchError
+	switch {
+	case errors.Is(err, errUnsupportedPatch):
+		w.Header().Set("Accept-Patch", MediaTypeMergePatch+", "+MediaTypeJSONPatch)
+		EncodeProblem(w, r, logger, http.StatusUnsupportedMediaType, ProblemTypeUnsupportedMediaType,
+			fmt.Sprintf("Content-Type must be %s or %s", MediaTypeMergePatch, MediaTypeJSONPatch))
+	case errors.Is(err, errPatchTestFailed):
+		EncodeProblem(w, r, logger, http.StatusConflict, ProblemTypeConflict, err.Error())
+	case errors.As(err, &pe):
+		EncodeProblem(w, r, logger, http.StatusUnprocessableEntity, ProblemTypeInvalidPatch, err.Error())
+	default:
+		EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+	}
+}
+
+// mergePatch applies an RFC 7396 merge patch to target.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// patchOperation is a single RFC 6902 JSON Patch operation.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (op patchOperation) value() (any, error) {
+	if op.Value == nil {
+		return nil, fmt.Errorf("%s requires a value", op.Op)
+	}
+	var v any
+	err := json.Unmarshal(op.Value, &v)
+	return v, err
+}
+
+func (op patchOperation) apply(doc any) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return pointerAdd(doc, path, value)
+		case "replace":
+			if doc, _, err = pointerRemove(doc, path); err != nil {
+				return nil, err
+			}
+			return pointerAdd(doc, path, value)
+		default:
+			current, err := pointerGet(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("value at %q: %w", op.Path, errPatchTestFailed)
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err = pointerRemove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if op.Path == op.From {
+				return doc, nil
+			}
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, fmt.Errorf("cannot move %q into one of its children", op.From)
+			}
+			var value any
+			if doc, value, err = pointerRemove(doc, from); err != nil {
+				return nil, err
+			}
+			return pointerAdd(doc, path, value)
+		}
+		value, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		// Copy through JSON so the two locations do not share maps or slices.
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		var copied any
+		if err := json.Unmarshal(b, &copied); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, copied)
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses token as an index into an array of length n. Indexes up
+// to and including n are accepted when end is true, so that values can be
+// appended.
+func arrayIndex(token string, n int, end bool) (int, error) {
+	if end && token == "-" {
+		return n, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > n || (i == n && !end) || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("index %q is out of range", token)
+	}
+	return i, nil
+}
+
+func pointerGet(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch container := doc.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			doc = value
+		case []any:
+			i, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = container[i]
+		default:
+			return nil, fmt.Errorf("cannot traverse into %q", token)
+		}
+	}
+	return doc, nil
+}
+
+// pointerUpdate replaces the parent of the location named by path with the
+// result of fn and returns the updated document.
+func pointerUpdate(doc any, path []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	child, err := pointerGet(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = pointerUpdate(child, path[1:], fn); err != nil {
+		return nil, err
+	}
+	switch container := doc.(type) {
+	case map[string]any:
+		container[path[0]] = child
+	case []any:
+		i, _ := arrayIndex(path[0], len(container), false)
+		container[i] = child
+	}
+	return doc, nil
+}
+
+func pointerAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return pointerUpdate(doc, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			container[token] = value
+			return container, nil
+		case []any:
+			i, err := arrayIndex(token, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[i+1:], container[i:])
+			container[i] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar", token)
+		}
+	})
+}
+
+func pointerRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	var removed any
+	doc, err := pointerUpdate(doc, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			removed = value
+			delete(container, token)
+			return container, nil
+		case []any:
+			i, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = container[i]
+			return append(container[:i], container[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from a scalar", token)
+		}
+	})
+	return doc, removed, err
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+)
+
+type personGetter interface {
+	GetPeople(ctx context.Context, filter services.PersonFilter, page services.Page) ([]models.Person, services.PageInfo, error)
+	GetPerson(ctx context.Context, firstName, personType string) (models.Person, error)
+	GetPersonByID(ctx context.Context, id int) (models.Person, error)
+	CreatePersonWithCourses(ctx context.Context, person models.Person) (models.Person, error)
+	UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error)
+	DeletePersonByID(ctx context.Context, id int, opts services.DeleteOptions) error
+	GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error)
+}
+
+// parsePersonFilter reads the query parameters shared by every people
+// listing: type, name, first-name, last-name, age, age_min, age_max, course,
+// term and sort.
+func parsePersonFilter(r *http.Request) (services.PersonFilter, []FieldError) {
+	queryParams := r.URL.Query()
+	filter := services.PersonFilter{
+		Type:      queryParams.Get("type"),
+		FirstName: queryParams.Get("first-name"),
+		LastName:  queryParams.Get("last-name"),
+		Name:      queryParams.Get("name"),
+	}
+
+	var errs []FieldError
+	if filter.Type != "" && filter.Type != "student" && filter.Type != "professor" {
+		errs = append(errs, FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be either 'student' or 'professor'"})
+	}
+
+	for _, param := range []struct {
+		name string
+		dest *int
+	}{
+		{"age", &filter.Age},
+		{"age_min", &filter.AgeMin},
+		{"age_max", &filter.AgeMax},
+	} {
+		value := queryParams.Get(param.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			errs = append(errs, FieldError{Field: param.name, Code: utils.CodeInvalid, Detail: "must be a positive integer"})
+			continue
+		}
+		*param.dest = n
+	}
+	if filter.AgeMin != 0 && filter.AgeMax != 0 && filter.AgeMin > filter.AgeMax {
+		errs = append(errs, FieldError{Field: "age_max", Code: utils.CodeOutOfRange, Detail: "must not be less than age_min"})
+	}
+
+	// course may be repeated or given as a comma separated list.
+	for _, value := range queryParams["course"] {
+		for _, id := range strings.Split(value, ",") {
+			n, err := strconv.ParseInt(id, 10, 64)
+			if err != nil || n <= 0 {
+				errs = append(errs, FieldError{Field: "course", Code: utils.CodeInvalid, Detail: "must be a list of course IDs"})
+				break
+			}
+			filter.Courses = append(filter.Courses, n)
+		}
+	}
+
+	term, termErrs := parseTermScope(r)
+	errs = append(errs, termErrs...)
+	filter.Term = term
+
+	sort, err := services.ParsePersonSort(queryParams.Get("sort"))
+	if err != nil {
+		errs = append(errs, FieldError{Field: "sort", Code: utils.CodeInvalid, Detail: "must be a comma separated list of id, first_name, last_name, type or age, each optionally prefixed with -"})
+	}
+	filter.Sort = sort
+
+	return filter, errs
+}
+
+// lookupPerson loads the person addressed by the {id} URL parameter. Numeric
+// values are IDs; anything else is treated as a first name, as documented in
+// the README, and must match exactly one person.
+func lookupPerson(ctx context.Context, service personGetter, param string) (models.Person, error) {
+	if id, err := strconv.Atoi(param); err == nil {
+		return service.GetPersonByID(ctx, id)
+	}
+	return service.GetPerson(ctx, param, "")
+}
+
+// resolvePersonID is like lookupPerson but only needs the ID, so numeric
+// parameters are used without a database round trip.
+func resolvePersonID(ctx context.Context, service personGetter, param string) (int, error) {
+	if id, err := strconv.Atoi(param); err == nil {
+		return id, nil
+	}
+	person, err := service.GetPerson(ctx, param, "")
+	if err != nil {
+		return 0, err
+	}
+	return person.ID, nil
+}
+
+func HandleGetPeople(logger *httplog.Logger, service personGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		filter, errs := parsePersonFilter(r)
+		page, pageErrs := parsePage(r)
+		errs = append(errs, pageErrs...)
+		expand, expandErrs := parseExpand(r, "courses")
+		errs = append(errs, expandErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid person filter", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
+		people, info, err := service.GetPeople(ctx, filter, page)
+		if err != nil {
+			logger.Error("error getting all people", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		setPageHeaders(w, r, info)
+		encodePeople(w, r, logger, service, expand, people)
+	}
+}
+
+func HandleCreatePerson(logger *httplog.Logger, service personGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var person models.Person
+		if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidatePerson(person); err != nil {
+			logger.Error("invalid person data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+
+		person, err := service.CreatePersonWithCourses(ctx, person)
+		if err != nil {
+			logger.Error("error creating person", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, person.ID)
+	}
+}
+
+func HandleGetPerson(logger *httplog.Logger, service personGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		expand, errs := parseExpand(r, "courses")
+		if len(errs) > 0 {
+			logger.Error("invalid person query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
+		person, err := lookupPerson(ctx, service, chi.URLParam(r, "id"))
+		if err != nil {
+			logger.Error("error getting person", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		encodePerson(w, r, logger, service, expand, person)
+	}
+}
+
+func HandleUpdatePerson(logger *httplog.Logger, service personGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := resolvePersonID(ctx, service, chi.URLParam(r, "id"))
+		if err != nil {
+			logger.Error("error resolving person", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+
+		var person models.Person
+		if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+
+		savePerson(w, r, logger, service, id, person)
+	}
+}
+
+// HandlePatchPerson applies a merge patch or JSON Patch to the stored person,
+// leaving every field the patch does not touch, including courses,
+// unchanged.
+func HandlePatchPerson(logger *httplog.Logger, service personGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		person, err := lookupPerson(ctx, service, chi.URLParam(r, "id"))
+		if err != nil {
+			logger.Error("error fetching existing person", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+
+		patched, err := applyPatch(r, person)
+		if err != nil {
+			logger.Error("failed to apply patch", "error", err)
+			encodePatchError(w, r, logger, err)
+			return
+		}
+
+		savePerson(w, r, logger, service, person.ID, patched)
+	}
+}
+
+type personSaver interface {
+	UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error)
+}
+
+// savePerson validates person and stores it, along with its courses, under
+// the given ID, responding with the person as stored.
+func savePerson(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, service personSaver, id int, person models.Person) {
+	ctx := r.Context()
+
+	if err := utils.ValidatePerson(person); err != nil {
+		logger.Error("invalid person data", "error", err)
+		EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+		return
+	}
+
+	updatedPerson, err := service.UpdatePersonWithCourses(ctx, id, person)
+	if err != nil {
+		logger.Error("error updating person", "error", err)
+		EncodeServiceError(w, r, logger, err, "Error updating data")
+		return
+	}
+
+	EncodeResponse(w, logger, http.StatusOK, updatedPerson)
+}
+
+// parseDeleteOptions reads the reassign_advisees_to query parameter, the ID
+// of the professor who takes over the advisees of a deleted professor.
+func parseDeleteOptions(r *http.Request) (services.DeleteOptions, []FieldError) {
+	var opts services.DeleteOptions
+	value := r.URL.Query().Get("reassign_advisees_to")
+	if value == "" {
+		return opts, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return opts, []FieldError{{Field: "reassign_advisees_to", Code: utils.CodeInvalid, Detail: "must be a professor ID"}}
+	}
+	opts.ReassignAdviseesTo = id
+	return opts, nil
+}
+
+// HandleDeletePerson deletes a person. Professors who still advise students
+// can only be deleted by passing reassign_advisees_to; otherwise the request
+// fails with a professor-has-advisees problem listing the advisees.
+func HandleDeletePerson(logger *httplog.Logger, service personGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opts, errs := parseDeleteOptions(r)
+		if len(errs) > 0 {
+			logger.Error("invalid delete query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
+		id, err := resolvePersonID(ctx, service, chi.URLParam(r, "id"))
+		if err != nil {
+			logger.Error("error resolving person", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+
+		if err := service.DeletePersonByID(ctx, id, opts); err != nil {
+			logger.Error("error deleting person", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Person has successfully been deleted")
+	}
+}
@@ -0,0 +1,572 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockPersonGetter struct {
+	mock.Mock
+}
+
+func (m *mockPersonGetter) GetPeople(ctx context.Context, filter services.PersonFilter, page services.Page) ([]models.Person, services.PageInfo, error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]models.Person), args.Get(1).(services.PageInfo), args.Error(2)
+}
+
+func (m *mockPersonGetter) GetPerson(ctx context.Context, firstName, personType string) (models.Person, error) {
+	args := m.Called(ctx, firstName, personType)
+	return args.Get(0).(models.Person), args.Error(1)
+}
+
+func (m *mockPersonGetter) CreatePersonWithCourses(ctx context.Context, person models.Person) (models.Person, error) {
+	args := m.Called(ctx, person)
+	return args.Get(0).(models.Person), args.Error(1)
+}
+
+func (m *mockPersonGetter) GetPersonByID(ctx context.Context, id int) (models.Person, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Person), args.Error(1)
+}
+
+func (m *mockPersonGetter) UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error) {
+	args := m.Called(ctx, id, person)
+	return args.Get(0).(models.Person), args.Error(1)
+}
+
+func (m *mockPersonGetter) DeletePersonByID(ctx context.Context, id int, opts services.DeleteOptions) error {
+	args := m.Called(ctx, id, opts)
+	return args.Error(0)
+}
+
+func (m *mockPersonGetter) GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error) {
+	args := m.Called(ctx, personIDs)
+	return args.Get(0).(map[int][]models.Course), args.Error(1)
+}
+
+func TestHandleGetPeople(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedFilter services.PersonFilter
+		expectedStatus int
+	}{
+		{
+			name:           "No Filters",
+			query:          "",
+			expectedFilter: services.PersonFilter{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "All Filters",
+			query:          "?type=professor&name=Steve+Jobs&age=56",
+			expectedFilter: services.PersonFilter{Type: "professor", Name: "Steve Jobs", Age: 56},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Ranges, Courses and Sort",
+			query: "?age_min=18&age_max=30&course=1,2&course=3&sort=last_name,-age",
+			expectedFilter: services.PersonFilter{
+				AgeMin:  18,
+				AgeMax:  30,
+				Courses: []int64{1, 2, 3},
+				Sort:    []services.SortKey{{Column: "last_name"}, {Column: "age", Desc: true}},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Age",
+			query:          "?age=old",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Inverted Age Range",
+			query:          "?age_min=30&age_max=18",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Sort Field",
+			query:          "?sort=password",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Type",
+			query:          "?type=teacher",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Term",
+			query:          "?type=student&term=past",
+			expectedFilter: services.PersonFilter{Type: "student", Term: services.TermScope{When: services.TermPast}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Term ID",
+			query:          "?term=2",
+			expectedFilter: services.PersonFilter{Term: services.TermScope{ID: 2}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Term",
+			query:          "?term=someday",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockPersonGetter)
+			if tt.expectedStatus == http.StatusOK {
+				mockService.On("GetPeople", mock.Anything, tt.expectedFilter, services.Page{}).Return([]models.Person{}, services.PageInfo{}, nil)
+			}
+
+			logger := httplog.NewLogger("test", httplog.Options{})
+			handler := handlers.HandleGetPeople(logger, mockService)
+
+			req, _ := http.NewRequest("GET", "/api/person"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, handlers.ProblemTypeInvalidParameter, errorResponse.Type)
+				assert.Len(t, errorResponse.Errors, 1)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCreatePerson(t *testing.T) {
+	input := models.Person{FirstName: "Ada", LastName: "Lovelace", Type: "professor", Age: 36, Courses: []int64{1}}
+	created := input
+	created.ID = 7
+
+	mockService := new(mockPersonGetter)
+	mockService.On("CreatePersonWithCourses", mock.Anything, input).Return(created, nil)
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	handler := handlers.HandleCreatePerson(logger, mockService)
+
+	req, _ := http.NewRequest("POST", "/api/person", strings.NewReader(`{"first_name": "Ada", "last_name": "Lovelace", "type": "professor", "age": 36, "courses": [1]}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "7", strings.TrimSpace(rr.Body.String()))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleCreatePersonCourseFull(t *testing.T) {
+	input := models.Person{FirstName: "Ada", LastName: "Lovelace", Type: "student", Age: 36, Courses: []int64{1}}
+
+	mockService := new(mockPersonGetter)
+	mockService.On("CreatePersonWithCourses", mock.Anything, input).Return(models.Person{}, fmt.Errorf("enrolling: %w", services.ErrCourseFull))
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	handler := handlers.HandleCreatePerson(logger, mockService)
+
+	req, _ := http.NewRequest("POST", "/api/person", strings.NewReader(`{"first_name": "Ada", "last_name": "Lovelace", "type": "student", "age": 36, "courses": [1]}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetPerson(t *testing.T) {
+	tests := []struct {
+		name           string
+		personID       string
+		mockPerson     models.Person
+		mockError      error
+		expectedStatus int
+		expectedDetail string
+	}{
+		{
+			name:           "Success",
+			personID:       "1",
+			mockPerson:     models.Person{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Not Found",
+			personID:       "99",
+			mockError:      services.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedDetail: "Resource not found",
+		},
+		{
+			name:           "By Name",
+			personID:       "Steve",
+			mockPerson:     models.Person{ID: 1, FirstName: "Steve", LastName: "Jobs", Type: "professor", Age: 56, Courses: []int64{}},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockPersonGetter)
+			if personIDInt, err := strconv.Atoi(tt.personID); err == nil {
+				mockService.On("GetPersonByID", mock.Anything, personIDInt).Return(tt.mockPerson, tt.mockError)
+			} else {
+				mockService.On("GetPerson", mock.Anything, tt.personID, "").Return(tt.mockPerson, tt.mockError)
+			}
+
+			logger := httplog.NewLogger("test", httplog.Options{})
+			r := chi.NewRouter()
+			r.Get("/api/person/{id}", handlers.HandleGetPerson(logger, mockService))
+
+			req, _ := http.NewRequest("GET", "/api/person/"+tt.personID, nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var person models.Person
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &person))
+				assert.Equal(t, tt.mockPerson, person)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedDetail, errorResponse.Detail)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandlePatchPerson(t *testing.T) {
+	existing := models.Person{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1, 2}}
+	patched := existing
+	patched.Age = 21
+
+	mockService := new(mockPersonGetter)
+	mockService.On("GetPersonByID", mock.Anything, 1).Return(existing, nil)
+	mockService.On("UpdatePersonWithCourses", mock.Anything, 1, patched).Return(patched, nil)
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Patch("/api/person/{id}", handlers.HandlePatchPerson(logger, mockService))
+
+	req, _ := http.NewRequest("PATCH", "/api/person/1", strings.NewReader(`{"age": 21}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var person models.Person
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &person))
+	assert.Equal(t, patched, person)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleUpdatePersonValidation(t *testing.T) {
+	mockService := new(mockPersonGetter)
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Put("/api/person/{id}", handlers.HandleUpdatePerson(logger, mockService))
+
+	req, _ := http.NewRequest("PUT", "/api/person/1", strings.NewReader(`{"first_name": "John", "type": "student", "age": 0}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var errorResponse handlers.ResponseErr
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+	assert.Equal(t, handlers.ProblemTypeValidation, errorResponse.Type)
+	assert.Len(t, errorResponse.Errors, 2)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleUpdatePersonCreditLimit(t *testing.T) {
+	person := models.Person{FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1, 2, 3}}
+	mockService := new(mockPersonGetter)
+	mockService.On("UpdatePersonWithCourses", mock.Anything, 1, person).
+		Return(models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] %w", &services.CreditLimitError{PersonID: 1, Limit: 18, Credits: 21}))
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Put("/api/person/{id}", handlers.HandleUpdatePerson(logger, mockService))
+
+	req, _ := http.NewRequest("PUT", "/api/person/1", strings.NewReader(`{"first_name": "John", "last_name": "Doe", "type": "student", "age": 20, "courses": [1, 2, 3]}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var errorResponse handlers.ResponseErr
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+	assert.Equal(t, handlers.ProblemTypeCreditLimitExceeded, errorResponse.Type)
+	assert.Equal(t, 18, *errorResponse.CreditLimit)
+	assert.Equal(t, 21, *errorResponse.Credits)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleDeletePerson(t *testing.T) {
+	mockService := new(mockPersonGetter)
+	mockService.On("DeletePersonByID", mock.Anything, 1, services.DeleteOptions{}).Return(nil)
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Delete("/api/person/{id}", handlers.HandleDeletePerson(logger, mockService))
+
+	req, _ := http.NewRequest("DELETE", "/api/person/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"Person has successfully been deleted"`, strings.TrimSpace(rr.Body.String()))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleDeletePersonAdvisees(t *testing.T) {
+	t.Run("Has Advisees", func(t *testing.T) {
+		mockService := new(mockPersonGetter)
+		mockService.On("DeletePersonByID", mock.Anything, 1, services.DeleteOptions{}).
+			Return(fmt.Errorf("cannot delete advisor: %w", &services.AdviseesError{ProfessorID: 1, Advisees: []int{3, 4}}))
+
+		logger := httplog.NewLogger("test", httplog.Options{})
+		r := chi.NewRouter()
+		r.Delete("/api/person/{id}", handlers.HandleDeletePerson(logger, mockService))
+
+		req, _ := http.NewRequest("DELETE", "/api/person/1", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		var problem handlers.ResponseErr
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+		assert.Equal(t, handlers.ProblemTypeHasAdvisees, problem.Type)
+		assert.Equal(t, []int{3, 4}, problem.Advisees)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Reassign", func(t *testing.T) {
+		mockService := new(mockPersonGetter)
+		mockService.On("DeletePersonByID", mock.Anything, 1, services.DeleteOptions{ReassignAdviseesTo: 2}).Return(nil)
+
+		logger := httplog.NewLogger("test", httplog.Options{})
+		r := chi.NewRouter()
+		r.Delete("/api/person/{id}", handlers.HandleDeletePerson(logger, mockService))
+
+		req, _ := http.NewRequest("DELETE", "/api/person/1?reassign_advisees_to=2", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Reassign Target", func(t *testing.T) {
+		mockService := new(mockPersonGetter)
+
+		logger := httplog.NewLogger("test", httplog.Options{})
+		r := chi.NewRouter()
+		r.Delete("/api/person/{id}", handlers.HandleDeletePerson(logger, mockService))
+
+		req, _ := http.NewRequest("DELETE", "/api/person/1?reassign_advisees_to=Jeff", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var problem handlers.ResponseErr
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+		assert.Equal(t, "reassign_advisees_to", problem.Errors[0].Field)
+		mockService.AssertNotCalled(t, "DeletePersonByID")
+	})
+}
+
+func TestHandleGetPeopleExpandCourses(t *testing.T) {
+	people := []models.Person{
+		{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1, 2}},
+		{ID: 2, FirstName: "Jane", LastName: "Roe", Type: "student", Age: 21, Courses: []int64{}},
+	}
+	courses := map[int][]models.Course{
+		1: {{ID: 1, Name: "Math"}, {ID: 2, Name: "Physics"}},
+		2: {},
+	}
+
+	mockService := new(mockPersonGetter)
+	mockService.On("GetPeople", mock.Anything, services.PersonFilter{}, services.Page{}).Return(people, services.PageInfo{}, nil)
+	mockService.On("GetCoursesByPerson", mock.Anything, []int{1, 2}).Return(courses, nil).Once()
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	req, _ := http.NewRequest("GET", "/api/person?expand=courses", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetPeople(logger, mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var body []models.PersonWithCourses
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, []models.PersonWithCourses{
+		{Person: models.Person{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20}, Courses: courses[1]},
+		{Person: models.Person{ID: 2, FirstName: "Jane", LastName: "Roe", Type: "student", Age: 21}, Courses: []models.Course{}},
+	}, body)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetPersonExpand(t *testing.T) {
+	person := models.Person{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1}, Credits: 4}
+
+	t.Run("Courses", func(t *testing.T) {
+		mockService := new(mockPersonGetter)
+		mockService.On("GetPersonByID", mock.Anything, 1).Return(person, nil)
+		mockService.On("GetCoursesByPerson", mock.Anything, []int{1}).Return(map[int][]models.Course{1: {{ID: 1, Code: "MATH-101", Name: "Math", Credits: 4}}}, nil)
+
+		logger := httplog.NewLogger("test", httplog.Options{})
+		r := chi.NewRouter()
+		r.Get("/api/person/{id}", handlers.HandleGetPerson(logger, mockService))
+
+		req, _ := http.NewRequest("GET", "/api/person/1?expand=courses", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"id":1,"first_name":"John","last_name":"Doe","type":"student","age":20,"credits":4,"courses":[{"id":1,"code":"MATH-101","name":"Math","credits":4}]}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Relation", func(t *testing.T) {
+		mockService := new(mockPersonGetter)
+
+		logger := httplog.NewLogger("test", httplog.Options{})
+		r := chi.NewRouter()
+		r.Get("/api/person/{id}", handlers.HandleGetPerson(logger, mockService))
+
+		req, _ := http.NewRequest("GET", "/api/person/1?expand=people", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var errorResponse handlers.ResponseErr
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+		assert.Equal(t, "expand", errorResponse.Errors[0].Field)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHandlePatchPersonJSONPatch(t *testing.T) {
+	existing := models.Person{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1, 2}}
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expected       models.Person
+		expectedStatus int
+		expectedType   string
+	}{
+		{
+			name:           "Add Course",
+			contentType:    handlers.MediaTypeJSONPatch,
+			body:           `[{"op": "add", "path": "/courses/-", "value": 3}]`,
+			expected:       models.Person{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1, 2, 3}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Test Then Replace and Remove",
+			contentType: handlers.MediaTypeJSONPatch,
+			body: `[
+				{"op": "test", "path": "/age", "value": 20},
+				{"op": "replace", "path": "/last_name", "value": "Smith"},
+				{"op": "remove", "path": "/courses/0"}
+			]`,
+			expected:       models.Person{ID: 1, FirstName: "John", LastName: "Smith", Type: "student", Age: 20, Courses: []int64{2}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Merge Patch Null Clears Courses",
+			contentType:    handlers.MediaTypeMergePatch,
+			body:           `{"courses": null}`,
+			expected:       models.Person{ID: 1, FirstName: "John", LastName: "Doe", Type: "student", Age: 20},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Failed Test",
+			contentType:    handlers.MediaTypeJSONPatch,
+			body:           `[{"op": "test", "path": "/age", "value": 30}, {"op": "replace", "path": "/age", "value": 31}]`,
+			expectedStatus: http.StatusConflict,
+			expectedType:   handlers.ProblemTypeConflict,
+		},
+		{
+			name:           "Missing Path",
+			contentType:    handlers.MediaTypeJSONPatch,
+			body:           `[{"op": "remove", "path": "/courses/5"}]`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedType:   handlers.ProblemTypeInvalidPatch,
+		},
+		{
+			name:           "Wrong Type After Patch",
+			contentType:    handlers.MediaTypeMergePatch,
+			body:           `{"age": "old"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   handlers.ProblemTypeInvalidPayload,
+		},
+		{
+			name:           "Unsupported Media Type",
+			contentType:    "text/plain",
+			body:           `age=21`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedType:   handlers.ProblemTypeUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockPersonGetter)
+			mockService.On("GetPersonByID", mock.Anything, 1).Return(existing, nil)
+			if tt.expectedStatus == http.StatusOK {
+				mockService.On("UpdatePersonWithCourses", mock.Anything, 1, tt.expected).Return(tt.expected, nil)
+			}
+
+			logger := httplog.NewLogger("test", httplog.Options{})
+			r := chi.NewRouter()
+			r.Patch("/api/person/{id}", handlers.HandlePatchPerson(logger, mockService))
+
+			req, _ := http.NewRequest("PATCH", "/api/person/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var person models.Person
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &person))
+				assert.Equal(t, tt.expected, person)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			if tt.expectedStatus == http.StatusUnsupportedMediaType {
+				assert.Contains(t, rr.Header().Get("Accept-Patch"), handlers.MediaTypeMergePatch)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
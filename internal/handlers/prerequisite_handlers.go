@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+)
+
+type prerequisiteManager interface {
+	GetPrerequisites(ctx context.Context, courseID int) ([]models.Course, error)
+	AddPrerequisite(ctx context.Context, courseID, prerequisiteID int) error
+	RemovePrerequisite(ctx context.Context, courseID, prerequisiteID int) error
+}
+
+func HandleGetPrerequisites(logger *httplog.Logger, service prerequisiteManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid course ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course ID")
+			return
+		}
+
+		prerequisites, err := service.GetPrerequisites(ctx, id)
+		if err != nil {
+			logger.Error("error getting prerequisites", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, prerequisites)
+	}
+}
+
+// HandleAddPrerequisite makes one course a prerequisite of another. Changes
+// that would make a course require itself, directly or through other
+// courses, are rejected with a prerequisite-cycle problem listing the cycle.
+func HandleAddPrerequisite(logger *httplog.Logger, service prerequisiteManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		prerequisiteID, prerequisiteErrs := pathID(r, "prerequisiteID")
+		errs = append(errs, prerequisiteErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid prerequisite IDs", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course IDs", errs...)
+			return
+		}
+
+		if err := service.AddPrerequisite(ctx, courseID, prerequisiteID); err != nil {
+			logger.Error("error adding prerequisite", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Prerequisite has successfully been added")
+	}
+}
+
+func HandleRemovePrerequisite(logger *httplog.Logger, service prerequisiteManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		courseID, errs := pathID(r, "id")
+		prerequisiteID, prerequisiteErrs := pathID(r, "prerequisiteID")
+		errs = append(errs, prerequisiteErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid prerequisite IDs", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid course IDs", errs...)
+			return
+		}
+
+		if err := service.RemovePrerequisite(ctx, courseID, prerequisiteID); err != nil {
+			logger.Error("error removing prerequisite", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Prerequisite has successfully been removed")
+	}
+}
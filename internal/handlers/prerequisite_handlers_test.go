@@ -0,0 +1,168 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockPrerequisiteManager struct {
+	mock.Mock
+}
+
+func (m *mockPrerequisiteManager) GetPrerequisites(ctx context.Context, courseID int) ([]models.Course, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]models.Course), args.Error(1)
+}
+
+func (m *mockPrerequisiteManager) AddPrerequisite(ctx context.Context, courseID, prerequisiteID int) error {
+	args := m.Called(ctx, courseID, prerequisiteID)
+	return args.Error(0)
+}
+
+func (m *mockPrerequisiteManager) RemovePrerequisite(ctx context.Context, courseID, prerequisiteID int) error {
+	args := m.Called(ctx, courseID, prerequisiteID)
+	return args.Error(0)
+}
+
+func newPrerequisiteRouter(service *mockPrerequisiteManager) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/course/{id}/prerequisites", handlers.HandleGetPrerequisites(logger, service))
+	r.Put("/api/course/{id}/prerequisites/{prerequisiteID}", handlers.HandleAddPrerequisite(logger, service))
+	r.Delete("/api/course/{id}/prerequisites/{prerequisiteID}", handlers.HandleRemovePrerequisite(logger, service))
+	return r
+}
+
+func TestHandleGetPrerequisites(t *testing.T) {
+	prerequisites := []models.Course{{ID: 1, Name: "Programming"}}
+
+	tests := []struct {
+		name           string
+		url            string
+		setup          func(m *mockPrerequisiteManager)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			url:  "/api/course/2/prerequisites",
+			setup: func(m *mockPrerequisiteManager) {
+				m.On("GetPrerequisites", mock.Anything, 2).Return(prerequisites, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Unknown Course",
+			url:  "/api/course/9/prerequisites",
+			setup: func(m *mockPrerequisiteManager) {
+				m.On("GetPrerequisites", mock.Anything, 9).Return([]models.Course{}, services.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid ID",
+			url:            "/api/course/abc/prerequisites",
+			setup:          func(m *mockPrerequisiteManager) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockPrerequisiteManager)
+			tt.setup(mockService)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newPrerequisiteRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body []models.Course
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, prerequisites, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleAddPrerequisite(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", url: "/api/course/2/prerequisites/1", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Cycle", url: "/api/course/2/prerequisites/1", mockError: &services.PrerequisiteCycleError{Cycle: []int{2, 1, 2}}, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypePrerequisiteCycle},
+		{name: "Unknown Course", url: "/api/course/2/prerequisites/1", mockError: services.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound, expectedType: handlers.ProblemTypeNotFound},
+		{name: "Invalid IDs", url: "/api/course/0/prerequisites/abc", expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockPrerequisiteManager)
+			if tt.expectCall {
+				mockService.On("AddPrerequisite", mock.Anything, 2, 1).Return(tt.mockError)
+			}
+
+			req, _ := http.NewRequest("PUT", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newPrerequisiteRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+				if tt.expectedType == handlers.ProblemTypePrerequisiteCycle {
+					assert.Equal(t, []int{2, 1, 2}, errorResponse.Cycle)
+				}
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleRemovePrerequisite(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "Success", url: "/api/course/2/prerequisites/1", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not A Prerequisite", url: "/api/course/2/prerequisites/1", mockError: services.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "Invalid IDs", url: "/api/course/2/prerequisites/-1", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockPrerequisiteManager)
+			if tt.expectCall {
+				mockService.On("RemovePrerequisite", mock.Anything, 2, 1).Return(tt.mockError)
+			}
+
+			req, _ := http.NewRequest("DELETE", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newPrerequisiteRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -8,34 +8,43 @@ import (
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog/v2"
 )
 
 type professorGetter interface {
-	GetPeople(ctx context.Context, firstName, lastName, age, personType string) ([]models.Person, error)
+	GetPeople(ctx context.Context, filter services.PersonFilter, page services.Page) ([]models.Person, services.PageInfo, error)
 	GetPerson(ctx context.Context, firstName, personType string) (models.Person, error)
-	UpdatePerson(ctx context.Context, firstName, personType string, person models.Person) (models.Person, error)
-	CreatePerson(ctx context.Context, person models.Person) (models.Person, error)
-	DeletePerson(ctx context.Context, firstName, personType string) error
-	UpdatePersonCourses(ctx context.Context, professorID int, newCourses []int64) error
+	UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error)
+	CreatePersonWithCourses(ctx context.Context, person models.Person) (models.Person, error)
+	DeletePerson(ctx context.Context, firstName, personType string, opts services.DeleteOptions) error
+	GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error)
 }
 
 func HandleGetProfessors(logger *httplog.Logger, service professorGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		queryParams := r.URL.Query()
-		firstName := queryParams.Get("first-name")
-		lastName := queryParams.Get("last-name")
-		age := queryParams.Get("age")
+		filter, errs := parsePersonFilter(r)
+		page, pageErrs := parsePage(r)
+		errs = append(errs, pageErrs...)
+		expand, expandErrs := parseExpand(r, "courses")
+		errs = append(errs, expandErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid professor filter", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+		filter.Type = "professor"
 
-		professors, err := service.GetPeople(ctx, firstName, lastName, age, "professor")
+		professors, info, err := service.GetPeople(ctx, filter, page)
 		if err != nil {
 			logger.Error("error getting all professors", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error retrieving data"})
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
 			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, professors)
+		setPageHeaders(w, r, info)
+		encodePeople(w, r, logger, service, expand, professors)
 	}
 }
 
@@ -44,13 +53,20 @@ func HandleGetProfessor(logger *httplog.Logger, service professorGetter) http.Ha
 		ctx := r.Context()
 		nameParam := chi.URLParam(r, "firstName")
 
+		expand, errs := parseExpand(r, "courses")
+		if len(errs) > 0 {
+			logger.Error("invalid professor query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
 		professor, err := service.GetPerson(ctx, nameParam, "professor")
 		if err != nil {
 			logger.Error("error getting professor", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error retrieving data"})
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
 			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, professor)
+		encodePerson(w, r, logger, service, expand, professor)
 	}
 }
 
@@ -64,19 +80,19 @@ func HandleUpdateProfessor(logger *httplog.Logger, service professorGetter) http
 		err := json.NewDecoder(r.Body).Decode(&professor)
 		if err != nil {
 			logger.Error("failed to decode request body", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid request payload"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
 			return
 		}
 
 		// Validate the professor data
 		if err := utils.ValidatePerson(professor); err != nil {
 			logger.Error("invalid professor data", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: err.Error()})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
 			return
 		}
 
 		if professor.Type != "professor" {
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Person is not of type student"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "Person is not of type professor", FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be professor"})
 			return
 		}
 
@@ -84,25 +100,18 @@ func HandleUpdateProfessor(logger *httplog.Logger, service professorGetter) http
 		existingProfessor, err := service.GetPerson(ctx, nameParam, "professor")
 		if err != nil {
 			logger.Error("error fetching existing professor", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error updating data"})
+			EncodeServiceError(w, r, logger, err, "Error updating data")
 			return
 		}
 
 		// Set the ID from the existing professor
 		professor.ID = existingProfessor.ID
 
-		// Update the professor in the database
-		updatedProfessor, err := service.UpdatePerson(ctx, nameParam, "professor", professor)
+		// Update the professor and their courses in the database
+		updatedProfessor, err := service.UpdatePersonWithCourses(ctx, existingProfessor.ID, professor)
 		if err != nil {
 			logger.Error("error updating professor", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error updating data"})
-			return
-		}
-
-		err = service.UpdatePersonCourses(ctx, professor.ID, updatedProfessor.Courses)
-		if err != nil {
-			logger.Error("error updating professor's courses", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error updating courses"})
+			EncodeServiceError(w, r, logger, err, "Error updating data")
 			return
 		}
 
@@ -117,50 +126,79 @@ func HandleCreateProfessor(logger *httplog.Logger, service professorGetter) http
 		var professor models.Person
 		if err := json.NewDecoder(r.Body).Decode(&professor); err != nil {
 			logger.Error("failed to decode request body", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid request payload"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
 			return
 		}
 		if err := utils.ValidatePerson(professor); err != nil {
 			logger.Error("invalid professor data", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: err.Error()})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
 			return
 		}
 
 		if professor.Type != "professor" {
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Person is not of type professor"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "Person is not of type professor", FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be professor"})
 			return
 		}
-		professor, err := service.CreatePerson(ctx, professor)
+		professor, err := service.CreatePersonWithCourses(ctx, professor)
 		if err != nil {
 			logger.Error("error creating professor", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error creating data"})
+			EncodeServiceError(w, r, logger, err, "Error creating data")
 			return
 		}
+		EncodeResponse(w, logger, http.StatusOK, professor.ID)
+	}
+}
+
+// HandlePatchProfessor applies a merge patch or JSON Patch to the stored professor,
+// leaving every field the patch does not touch, including courses,
+// unchanged.
+func HandlePatchProfessor(logger *httplog.Logger, service professorGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		nameParam := chi.URLParam(r, "firstName")
 
-		if len(professor.Courses) > 0 {
-			err = service.UpdatePersonCourses(ctx, professor.ID, professor.Courses)
-			if err != nil {
-				logger.Error("error associating courses with professor", "error", err)
-				EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error associating courses"})
-				return
-			}
+		existingProfessor, err := service.GetPerson(ctx, nameParam, "professor")
+		if err != nil {
+			logger.Error("error fetching existing professor", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, professor.ID)
+
+		professor, err := applyPatch(r, existingProfessor)
+		if err != nil {
+			logger.Error("failed to apply patch", "error", err)
+			encodePatchError(w, r, logger, err)
+			return
+		}
+		if professor.Type != "professor" {
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "Person is not of type professor", FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be professor"})
+			return
+		}
+
+		savePerson(w, r, logger, service, existingProfessor.ID, professor)
 	}
 }
 
+// HandleDeleteProfessor deletes a professor. See HandleDeletePerson for
+// professors who still advise students.
 func HandleDeleteProfessor(logger *httplog.Logger, service professorGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		nameParam := chi.URLParam(r, "firstName")
 		if nameParam == "" {
 			logger.Error("invalid professor name", "error", errors.New("first name is required"))
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid professor name"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid professor name")
+			return
+		}
+		opts, errs := parseDeleteOptions(r)
+		if len(errs) > 0 {
+			logger.Error("invalid delete query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
 			return
 		}
-		if err := service.DeletePerson(ctx, nameParam, "professor"); err != nil {
+		if err := service.DeletePerson(ctx, nameParam, "professor", opts); err != nil {
 			logger.Error("error deleting professor", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error deleting data"})
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
 			return
 		}
 		EncodeResponse(w, logger, http.StatusOK, "Professor has successfully been deleted")
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog/v2"
 	"github.com/stretchr/testify/assert"
@@ -21,9 +23,9 @@ type mockProfessorGetter struct {
 	mock.Mock
 }
 
-func (m *mockProfessorGetter) GetPeople(ctx context.Context, firstName, lastName, age, personType string) ([]models.Person, error) {
-	args := m.Called(ctx, firstName, lastName, age, personType)
-	return args.Get(0).([]models.Person), args.Error(1)
+func (m *mockProfessorGetter) GetPeople(ctx context.Context, filter services.PersonFilter, page services.Page) ([]models.Person, services.PageInfo, error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]models.Person), args.Get(1).(services.PageInfo), args.Error(2)
 }
 
 func (m *mockProfessorGetter) GetPerson(ctx context.Context, firstName, personType string) (models.Person, error) {
@@ -31,23 +33,24 @@ func (m *mockProfessorGetter) GetPerson(ctx context.Context, firstName, personTy
 	return args.Get(0).(models.Person), args.Error(1)
 }
 
-func (m *mockProfessorGetter) UpdatePerson(ctx context.Context, firstName, personType string, person models.Person) (models.Person, error) {
-	args := m.Called(ctx, firstName, personType, person)
+func (m *mockProfessorGetter) UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error) {
+	args := m.Called(ctx, id, person)
 	return args.Get(0).(models.Person), args.Error(1)
 }
 
-func (m *mockProfessorGetter) CreatePerson(ctx context.Context, person models.Person) (models.Person, error) {
+func (m *mockProfessorGetter) CreatePersonWithCourses(ctx context.Context, person models.Person) (models.Person, error) {
 	args := m.Called(ctx, person)
 	return args.Get(0).(models.Person), args.Error(1)
 }
 
-func (m *mockProfessorGetter) DeletePerson(ctx context.Context, firstName, personType string) error {
-	args := m.Called(ctx, firstName, personType)
+func (m *mockProfessorGetter) DeletePerson(ctx context.Context, firstName, personType string, opts services.DeleteOptions) error {
+	args := m.Called(ctx, firstName, personType, opts)
 	return args.Error(0)
 }
 
-func (m *mockProfessorGetter) UpdatePersonCourses(ctx context.Context, studentID int, newCourses []int64) error {
-	return nil
+func (m *mockProfessorGetter) GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error) {
+	args := m.Called(ctx, personIDs)
+	return args.Get(0).(map[int][]models.Course), args.Error(1)
 }
 
 func TestHandleGetProfessors(t *testing.T) {
@@ -79,7 +82,7 @@ func TestHandleGetProfessors(t *testing.T) {
 			mockPeople:     nil,
 			mockError:      errors.New("service error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error retrieving data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error retrieving data"},
 		},
 	}
 
@@ -88,7 +91,9 @@ func TestHandleGetProfessors(t *testing.T) {
 			mockService := new(mockProfessorGetter)
 
 			// Mock GetPeople based on test scenario
-			mockService.On("GetPeople", mock.Anything, tt.firstName, tt.lastName, tt.age, "professor").Return(tt.mockPeople, tt.mockError)
+			age, _ := strconv.Atoi(tt.age)
+			filter := services.PersonFilter{Type: "professor", FirstName: tt.firstName, LastName: tt.lastName, Age: age}
+			mockService.On("GetPeople", mock.Anything, filter, services.Page{}).Return(tt.mockPeople, services.PageInfo{}, tt.mockError)
 
 			logger := httplog.NewLogger("test", httplog.Options{})
 			handler := handlers.HandleGetProfessors(logger, mockService)
@@ -111,7 +116,7 @@ func TestHandleGetProfessors(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -142,7 +147,7 @@ func TestHandleGetProfessor(t *testing.T) {
 			mockPerson:     models.Person{},
 			mockError:      errors.New("service error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error retrieving data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error retrieving data"},
 		},
 	}
 
@@ -174,7 +179,7 @@ func TestHandleGetProfessor(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -202,7 +207,7 @@ func TestHandleDeleteProfessor(t *testing.T) {
 			firstName:      "John",
 			mockError:      errors.New("service error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error deleting data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error deleting data"},
 		},
 	}
 
@@ -212,7 +217,7 @@ func TestHandleDeleteProfessor(t *testing.T) {
 
 			// Only mock DeletePerson when the request is expected to be valid
 			if tt.expectedStatus != http.StatusBadRequest {
-				mockService.On("DeletePerson", mock.Anything, tt.firstName, "professor").Return(tt.mockError)
+				mockService.On("DeletePerson", mock.Anything, tt.firstName, "professor", services.DeleteOptions{}).Return(tt.mockError)
 			}
 
 			logger := httplog.NewLogger("test", httplog.Options{})
@@ -233,7 +238,7 @@ func TestHandleDeleteProfessor(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+)
+
+type programManager interface {
+	GetPrograms(ctx context.Context) ([]models.Program, error)
+	GetProgram(ctx context.Context, id int) (models.Program, error)
+	CreateProgram(ctx context.Context, program models.Program) (models.Program, error)
+	UpdateProgram(ctx context.Context, id int, program models.Program) (models.Program, error)
+	DeleteProgram(ctx context.Context, id int) error
+	GetDeclaration(ctx context.Context, personID int) (models.ProgramDeclaration, error)
+	DeclareProgram(ctx context.Context, personID, programID int) (models.ProgramDeclaration, error)
+	RemoveDeclaration(ctx context.Context, personID int) error
+	GetDegreeAudit(ctx context.Context, personID int) (models.DegreeAudit, error)
+}
+
+func HandleGetPrograms(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		programs, err := service.GetPrograms(r.Context())
+		if err != nil {
+			logger.Error("error getting all programs", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, programs)
+	}
+}
+
+func HandleGetProgram(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid program ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid program ID")
+			return
+		}
+
+		program, err := service.GetProgram(ctx, id)
+		if err != nil {
+			logger.Error("error getting program", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, program)
+	}
+}
+
+// HandleCreateProgram creates a program from a body such as
+// {"name": "Computer Science", "required_courses": [1, 2],
+// "elective_pools": [{"name": "Design", "min_credits": 3, "courses": [3]}]}.
+func HandleCreateProgram(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var program models.Program
+		if err := json.NewDecoder(r.Body).Decode(&program); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateProgram(program); err != nil {
+			logger.Error("invalid program data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		program, err := service.CreateProgram(ctx, program)
+		if err != nil {
+			logger.Error("error creating program", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, program)
+	}
+}
+
+// HandleUpdateProgram replaces a program, including all of its required
+// courses and elective pools.
+func HandleUpdateProgram(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var program models.Program
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid program ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid program ID")
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&program); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateProgram(program); err != nil {
+			logger.Error("invalid program data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		program, err = service.UpdateProgram(ctx, id, program)
+		if err != nil {
+			logger.Error("error updating program", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, program)
+	}
+}
+
+func HandleDeleteProgram(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid program ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid program ID")
+			return
+		}
+
+		if err := service.DeleteProgram(ctx, id); err != nil {
+			logger.Error("error deleting program", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Program has successfully been deleted")
+	}
+}
+
+func HandleGetDeclaration(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		personID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		declaration, err := service.GetDeclaration(r.Context(), personID)
+		if err != nil {
+			logger.Error("error getting program declaration", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, declaration)
+	}
+}
+
+// HandleDeclareProgram makes the program named by program_id in a body such
+// as {"program_id": 1} the program of the student in the URL, replacing any
+// program the student declared before.
+func HandleDeclareProgram(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		personID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		var declaration models.ProgramDeclaration
+		if err := json.NewDecoder(r.Body).Decode(&declaration); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if declaration.ProgramID <= 0 {
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "program id must be a positive number", FieldError{Field: "program_id", Code: utils.CodeRequired, Detail: "program id must be a positive number"})
+			return
+		}
+
+		declaration, err := service.DeclareProgram(r.Context(), personID, declaration.ProgramID)
+		if err != nil {
+			logger.Error("error declaring program", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, declaration)
+	}
+}
+
+func HandleRemoveDeclaration(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		personID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		if err := service.RemoveDeclaration(r.Context(), personID); err != nil {
+			logger.Error("error removing program declaration", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Program declaration has successfully been removed")
+	}
+}
+
+// HandleGetDegreeAudit compares the completed courses of the student in the
+// URL with the requirements of their declared program, listing which
+// requirements are satisfied and what is still outstanding.
+func HandleGetDegreeAudit(logger *httplog.Logger, service programManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		personID, errs := pathID(r, "id")
+		if len(errs) > 0 {
+			logger.Error("invalid person ID", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid person ID", errs...)
+			return
+		}
+
+		audit, err := service.GetDegreeAudit(r.Context(), personID)
+		if err != nil {
+			logger.Error("error getting degree audit", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, audit)
+	}
+}
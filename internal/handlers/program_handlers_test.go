@@ -0,0 +1,242 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockProgramManager struct {
+	mock.Mock
+}
+
+func (m *mockProgramManager) GetPrograms(ctx context.Context) ([]models.Program, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Program), args.Error(1)
+}
+
+func (m *mockProgramManager) GetProgram(ctx context.Context, id int) (models.Program, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Program), args.Error(1)
+}
+
+func (m *mockProgramManager) CreateProgram(ctx context.Context, program models.Program) (models.Program, error) {
+	args := m.Called(ctx, program)
+	return args.Get(0).(models.Program), args.Error(1)
+}
+
+func (m *mockProgramManager) UpdateProgram(ctx context.Context, id int, program models.Program) (models.Program, error) {
+	args := m.Called(ctx, id, program)
+	return args.Get(0).(models.Program), args.Error(1)
+}
+
+func (m *mockProgramManager) DeleteProgram(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockProgramManager) GetDeclaration(ctx context.Context, personID int) (models.ProgramDeclaration, error) {
+	args := m.Called(ctx, personID)
+	return args.Get(0).(models.ProgramDeclaration), args.Error(1)
+}
+
+func (m *mockProgramManager) DeclareProgram(ctx context.Context, personID, programID int) (models.ProgramDeclaration, error) {
+	args := m.Called(ctx, personID, programID)
+	return args.Get(0).(models.ProgramDeclaration), args.Error(1)
+}
+
+func (m *mockProgramManager) RemoveDeclaration(ctx context.Context, personID int) error {
+	args := m.Called(ctx, personID)
+	return args.Error(0)
+}
+
+func (m *mockProgramManager) GetDegreeAudit(ctx context.Context, personID int) (models.DegreeAudit, error) {
+	args := m.Called(ctx, personID)
+	return args.Get(0).(models.DegreeAudit), args.Error(1)
+}
+
+func newProgramRouter(service *mockProgramManager) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/program", handlers.HandleGetPrograms(logger, service))
+	r.Get("/api/program/{id}", handlers.HandleGetProgram(logger, service))
+	r.Put("/api/program/{id}", handlers.HandleUpdateProgram(logger, service))
+	r.Post("/api/program", handlers.HandleCreateProgram(logger, service))
+	r.Delete("/api/program/{id}", handlers.HandleDeleteProgram(logger, service))
+	r.Get("/api/person/{id}/program", handlers.HandleGetDeclaration(logger, service))
+	r.Put("/api/person/{id}/program", handlers.HandleDeclareProgram(logger, service))
+	r.Delete("/api/person/{id}/program", handlers.HandleRemoveDeclaration(logger, service))
+	r.Get("/api/person/{id}/degree-audit", handlers.HandleGetDegreeAudit(logger, service))
+	return r
+}
+
+func TestHandleCreateProgram(t *testing.T) {
+	requested := models.Program{
+		Name:            "Computer Science",
+		RequiredCourses: []int{1, 2},
+		ElectivePools:   []models.ElectivePool{{Name: "Design electives", MinCredits: 3, Courses: []int{3}}},
+	}
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"name": "Computer Science", "required_courses": [1, 2], "elective_pools": [{"name": "Design electives", "min_credits": 3, "courses": [3]}]}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Duplicate Name", body: `{"name": "Computer Science", "required_courses": [1, 2], "elective_pools": [{"name": "Design electives", "min_credits": 3, "courses": [3]}]}`, mockError: fmt.Errorf("taken: %w", services.ErrConflict), expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeConflict},
+		{name: "Unknown Course", body: `{"name": "Computer Science", "required_courses": [1, 2], "elective_pools": [{"name": "Design electives", "min_credits": 3, "courses": [3]}]}`, mockError: fmt.Errorf("bad course: %w", services.ErrInvalidReference), expectCall: true, expectedStatus: http.StatusUnprocessableEntity, expectedType: handlers.ProblemTypeInvalidReference},
+		{name: "Pool Without Credits", body: `{"name": "Computer Science", "elective_pools": [{"name": "Design electives", "courses": [3]}]}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Courses", body: `{"name": "Computer Science", "required_courses": "CS-101"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := requested
+			created.ID = 3
+			mockService := new(mockProgramManager)
+			if tt.expectCall {
+				mockService.On("CreateProgram", mock.Anything, requested).Return(created, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("POST", "/api/program", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newProgramRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Program
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, created, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteProgram(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "Success", url: "/api/program/1", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Declared By Students", url: "/api/program/1", mockError: fmt.Errorf("declared: %w", services.ErrConflict), expectCall: true, expectedStatus: http.StatusConflict},
+		{name: "Invalid ID", url: "/api/program/abc", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockProgramManager)
+			if tt.expectCall {
+				mockService.On("DeleteProgram", mock.Anything, 1).Return(tt.mockError)
+			}
+
+			req, _ := http.NewRequest("DELETE", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newProgramRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeclareProgram(t *testing.T) {
+	declared := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", url: "/api/person/3/program", body: `{"program_id": 1}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not A Student", url: "/api/person/3/program", body: `{"program_id": 1}`, mockError: fmt.Errorf("professor: %w", services.ErrConstraintViolation), expectCall: true, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeConstraintViolation},
+		{name: "Unknown Program", url: "/api/person/3/program", body: `{"program_id": 1}`, mockError: fmt.Errorf("bad program: %w", services.ErrInvalidReference), expectCall: true, expectedStatus: http.StatusUnprocessableEntity, expectedType: handlers.ProblemTypeInvalidReference},
+		{name: "Missing Program", url: "/api/person/3/program", body: `{}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Invalid Person ID", url: "/api/person/abc/program", body: `{"program_id": 1}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockProgramManager)
+			if tt.expectCall {
+				mockService.On("DeclareProgram", mock.Anything, 3, 1).Return(models.ProgramDeclaration{PersonID: 3, ProgramID: 1, DeclaredAt: declared}, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("PUT", tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newProgramRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, `{"person_id": 3, "program_id": 1, "declared_at": "2026-09-01T12:00:00Z"}`, rr.Body.String())
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetDegreeAudit(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		databases := models.AuditCourse{CourseID: 2, Code: "CS-201", Name: "Databases", Credits: 3, Status: models.AuditInProgress}
+		audit := models.DegreeAudit{
+			PersonID:        3,
+			ProgramID:       1,
+			ProgramName:     "Computer Science",
+			RequiredCourses: []models.AuditCourse{databases},
+			ElectivePools:   []models.PoolAudit{},
+		}
+		mockService := new(mockProgramManager)
+		mockService.On("GetDegreeAudit", mock.Anything, 3).Return(audit, nil)
+
+		req, _ := http.NewRequest("GET", "/api/person/3/degree-audit", nil)
+		rr := httptest.NewRecorder()
+		newProgramRouter(mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"person_id": 3, "program_id": 1, "program_name": "Computer Science", "complete": false,
+			"required_courses": [{"course_id": 2, "code": "CS-201", "name": "Databases", "credits": 3, "status": "in_progress"}],
+			"elective_pools": []}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("No Declared Program", func(t *testing.T) {
+		mockService := new(mockProgramManager)
+		mockService.On("GetDegreeAudit", mock.Anything, 3).Return(models.DegreeAudit{}, fmt.Errorf("undeclared: %w", services.ErrNotFound))
+
+		req, _ := http.NewRequest("GET", "/api/person/3/degree-audit", nil)
+		rr := httptest.NewRecorder()
+		newProgramRouter(mockService).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,60 @@
 package handlers
 
-type problem struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// FieldError describes a single rejected field of a request.
+type FieldError struct {
+	Field  string `json:"field"`
+	Code   string `json:"code,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// decodeErrors returns the field level errors that can be recovered from a
+// JSON decoding failure, such as a string sent where a number was expected.
+func decodeErrors(err error) []FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:  typeErr.Field,
+			Code:   utils.CodeInvalid,
+			Detail: "must be of type " + typeErr.Type.String(),
+		}}
+	}
+	return nil
+}
+
+// validationErrors converts the violations of a utils.ValidationError into
+// field errors for a problem response.
+func validationErrors(err error) []FieldError {
+	var validationErr utils.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	errs := make([]FieldError, 0, len(validationErr))
+	for _, violation := range validationErr {
+		errs = append(errs, FieldError{
+			Field:  violation.Field,
+			Code:   violation.Code,
+			Detail: violation.Message,
+		})
+	}
+	return errs
+}
+
+// pathID reads the URL parameter name as the ID of a resource, which must
+// be a positive integer.
+func pathID(r *http.Request, name string) (int, []FieldError) {
+	id, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil || id <= 0 {
+		return 0, []FieldError{{Field: name, Code: utils.CodeInvalid, Detail: "must be a positive integer"}}
+	}
+	return id, nil
 }
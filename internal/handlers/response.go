@@ -4,12 +4,84 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
 	"github.com/go-chi/httplog/v2"
 )
 
+// Problem types identify the kind of failure independently of the human
+// readable detail, so clients can branch on them.
+const (
+	ProblemTypeInvalidPayload       = "/problems/invalid-payload"
+	ProblemTypeInvalidParameter     = "/problems/invalid-parameter"
+	ProblemTypeInvalidPatch         = "/problems/invalid-patch"
+	ProblemTypeUnsupportedMediaType = "/problems/unsupported-media-type"
+	ProblemTypeValidation           = "/problems/validation-error"
+	ProblemTypeNotFound             = "/problems/not-found"
+	ProblemTypeForbidden            = "/problems/forbidden"
+	ProblemTypeConflict             = "/problems/conflict"
+	ProblemTypeCourseFull           = "/problems/course-full"
+	ProblemTypePrerequisiteCycle    = "/problems/prerequisite-cycle"
+	ProblemTypeMissingPrerequisites = "/problems/missing-prerequisites"
+	ProblemTypeScheduleConflict     = "/problems/schedule-conflict"
+	ProblemTypeCreditLimitExceeded  = "/problems/credit-limit-exceeded"
+	ProblemTypeHasAdvisees          = "/problems/professor-has-advisees"
+	ProblemTypeRoomConflict         = "/problems/room-conflict"
+	ProblemTypeAmbiguousName        = "/problems/ambiguous-name"
+	ProblemTypeInvalidReference     = "/problems/invalid-reference"
+	ProblemTypeConstraintViolation  = "/problems/constraint-violation"
+	ProblemTypeUnavailable          = "/problems/unavailable"
+	ProblemTypeInternal             = "/problems/internal-error"
+)
+
+var problemTitles = map[string]string{
+	ProblemTypeInvalidPayload:       "Invalid request payload",
+	ProblemTypeInvalidParameter:     "Invalid request parameter",
+	ProblemTypeInvalidPatch:         "Patch cannot be applied to the resource",
+	ProblemTypeUnsupportedMediaType: "Unsupported media type",
+	ProblemTypeValidation:           "Validation failed",
+	ProblemTypeNotFound:             "Resource not found",
+	ProblemTypeForbidden:            "Not allowed to perform this action",
+	ProblemTypeConflict:             "Request conflicts with existing data",
+	ProblemTypeCourseFull:           "Course has no free seats",
+	ProblemTypePrerequisiteCycle:    "Change would make a course its own prerequisite",
+	ProblemTypeMissingPrerequisites: "Course prerequisites have not been completed",
+	ProblemTypeScheduleConflict:     "Course meets at the same time as another course",
+	ProblemTypeCreditLimitExceeded:  "Enrollment would exceed the student's credit limit",
+	ProblemTypeHasAdvisees:          "Professor still advises students",
+	ProblemTypeRoomConflict:         "Room is already booked at that time",
+	ProblemTypeAmbiguousName:        "More than one person matches the given name",
+	ProblemTypeInvalidReference:     "Request references a resource that does not exist",
+	ProblemTypeConstraintViolation:  "Request violates a data constraint",
+	ProblemTypeUnavailable:          "Service temporarily unavailable",
+	ProblemTypeInternal:             "Internal server error",
+}
+
+// ResponseErr is an RFC 7807 problem details document. Errors is an
+// extension member listing the individual fields that were rejected.
 type ResponseErr struct {
-	Error            string    `json:"error,omitempty"`
-	ValidationErrors []problem `json:"validation_errors,omitempty"`
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+
+	// Candidates lists the people matching an ambiguous name lookup.
+	Candidates []models.Person `json:"candidates,omitempty"`
+	// Cycle lists the course IDs of a rejected prerequisite cycle.
+	Cycle []int `json:"cycle,omitempty"`
+	// MissingPrerequisites lists the courses a student still has to
+	// complete before enrolling.
+	MissingPrerequisites []models.Course `json:"missing_prerequisites,omitempty"`
+	// Conflicts lists the meetings a course would clash with.
+	Conflicts []models.ScheduleConflict `json:"conflicts,omitempty"`
+	// CreditLimit and Credits are the credit load limit of a student and the
+	// load a rejected change would have given them.
+	CreditLimit *int `json:"credit_limit,omitempty"`
+	Credits     *int `json:"credits,omitempty"`
+	// Advisees lists the IDs of the students a professor who cannot be
+	// deleted still advises.
+	Advisees []int `json:"advisees,omitempty"`
 }
 
 func EncodeResponse(w http.ResponseWriter, logger *httplog.Logger, status int, data any) {
@@ -20,3 +92,33 @@ func EncodeResponse(w http.ResponseWriter, logger *httplog.Logger, status int, d
 		http.Error(w, `{"Error": "Internal server error"}`, http.StatusInternalServerError)
 	}
 }
+
+// EncodeProblem writes an application/problem+json response for the given
+// problem type. The request path is used as the problem instance.
+func EncodeProblem(w http.ResponseWriter, r *http.Request, logger *httplog.Logger, status int, problemType, detail string, errs ...FieldError) {
+	body := newProblem(r, status, problemType, detail)
+	body.Errors = errs
+	writeProblem(w, logger, body)
+}
+
+func newProblem(r *http.Request, status int, problemType, detail string) ResponseErr {
+	title, ok := problemTitles[problemType]
+	if !ok {
+		title = http.StatusText(status)
+	}
+	return ResponseErr{
+		Type:     problemType,
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	}
+}
+
+func writeProblem(w http.ResponseWriter, logger *httplog.Logger, body ResponseErr) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(body.Status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("Error while marshaling problem", "err", err, "problem", body)
+	}
+}
@@ -1,11 +1,14 @@
 package handlers_test
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
 	"github.com/go-chi/httplog/v2"
 	"github.com/stretchr/testify/require"
 )
@@ -45,3 +48,66 @@ func TestEncodeResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeProblem(t *testing.T) {
+	logger := httplog.NewLogger("test", httplog.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/course", nil)
+	rr := httptest.NewRecorder()
+	handlers.EncodeProblem(rr, req, logger, http.StatusBadRequest, handlers.ProblemTypeValidation, "Course name is required",
+		handlers.FieldError{Field: "name", Detail: "is required"})
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+	require.JSONEq(t, `{
+		"type": "/problems/validation-error",
+		"title": "Validation failed",
+		"status": 400,
+		"detail": "Course name is required",
+		"instance": "/api/course",
+		"errors": [{"field": "name", "detail": "is required"}]
+	}`, rr.Body.String())
+}
+
+func TestEncodeServiceError(t *testing.T) {
+	logger := httplog.NewLogger("test", httplog.Options{})
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedType   string
+		expectedDetail string
+	}{
+		{
+			name:           "Not Found",
+			err:            services.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedType:   handlers.ProblemTypeNotFound,
+			expectedDetail: "Resource not found",
+		},
+		{
+			name:           "Internal",
+			err:            errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   handlers.ProblemTypeInternal,
+			expectedDetail: "Error retrieving data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/course/1", nil)
+			rr := httptest.NewRecorder()
+			handlers.EncodeServiceError(rr, req, logger, tt.err, "Error retrieving data")
+
+			var problem handlers.ResponseErr
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			require.Equal(t, tt.expectedStatus, problem.Status)
+			require.Equal(t, tt.expectedType, problem.Type)
+			require.Equal(t, tt.expectedDetail, problem.Detail)
+			require.Equal(t, "/api/course/1", problem.Instance)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+)
+
+type roomGetter interface {
+	GetRooms(ctx context.Context) ([]models.Room, error)
+	GetRoom(ctx context.Context, id int) (models.Room, error)
+	CreateRoom(ctx context.Context, room models.Room) (models.Room, error)
+	UpdateRoom(ctx context.Context, id int, room models.Room) (models.Room, error)
+	DeleteRoom(ctx context.Context, id int) error
+	GetRoomBookings(ctx context.Context, roomID int) ([]models.RoomBooking, error)
+}
+
+func HandleGetRooms(logger *httplog.Logger, service roomGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rooms, err := service.GetRooms(r.Context())
+		if err != nil {
+			logger.Error("error getting all rooms", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, rooms)
+	}
+}
+
+func HandleGetRoom(logger *httplog.Logger, service roomGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid room ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid room ID")
+			return
+		}
+
+		room, err := service.GetRoom(ctx, id)
+		if err != nil {
+			logger.Error("error getting room", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, room)
+	}
+}
+
+func HandleCreateRoom(logger *httplog.Logger, service roomGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var room models.Room
+		if err := json.NewDecoder(r.Body).Decode(&room); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateRoom(room); err != nil {
+			logger.Error("invalid room data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		room, err := service.CreateRoom(ctx, room)
+		if err != nil {
+			logger.Error("error creating room", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, room)
+	}
+}
+
+func HandleUpdateRoom(logger *httplog.Logger, service roomGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var room models.Room
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid room ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid room ID")
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&room); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateRoom(room); err != nil {
+			logger.Error("invalid room data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		room, err = service.UpdateRoom(ctx, id, room)
+		if err != nil {
+			logger.Error("error updating room", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, room)
+	}
+}
+
+// HandleGetRoomBookings lists the course meetings booked into a room. Rooms
+// are booked through the room_id of a course's meetings.
+func HandleGetRoomBookings(logger *httplog.Logger, service roomGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid room ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid room ID")
+			return
+		}
+
+		bookings, err := service.GetRoomBookings(ctx, id)
+		if err != nil {
+			logger.Error("error getting room bookings", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, bookings)
+	}
+}
+
+func HandleDeleteRoom(logger *httplog.Logger, service roomGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid room ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid room ID")
+			return
+		}
+
+		if err := service.DeleteRoom(ctx, id); err != nil {
+			logger.Error("error deleting room", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Room has successfully been deleted")
+	}
+}
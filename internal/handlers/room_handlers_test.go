@@ -0,0 +1,185 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockRoomGetter struct {
+	mock.Mock
+}
+
+func (m *mockRoomGetter) GetRooms(ctx context.Context) ([]models.Room, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Room), args.Error(1)
+}
+
+func (m *mockRoomGetter) GetRoom(ctx context.Context, id int) (models.Room, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Room), args.Error(1)
+}
+
+func (m *mockRoomGetter) CreateRoom(ctx context.Context, room models.Room) (models.Room, error) {
+	args := m.Called(ctx, room)
+	return args.Get(0).(models.Room), args.Error(1)
+}
+
+func (m *mockRoomGetter) UpdateRoom(ctx context.Context, id int, room models.Room) (models.Room, error) {
+	args := m.Called(ctx, id, room)
+	return args.Get(0).(models.Room), args.Error(1)
+}
+
+func (m *mockRoomGetter) DeleteRoom(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockRoomGetter) GetRoomBookings(ctx context.Context, roomID int) ([]models.RoomBooking, error) {
+	args := m.Called(ctx, roomID)
+	return args.Get(0).([]models.RoomBooking), args.Error(1)
+}
+
+func newRoomRouter(service *mockRoomGetter) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/room", handlers.HandleGetRooms(logger, service))
+	r.Get("/api/room/{id}", handlers.HandleGetRoom(logger, service))
+	r.Post("/api/room", handlers.HandleCreateRoom(logger, service))
+	r.Put("/api/room/{id}", handlers.HandleUpdateRoom(logger, service))
+	r.Delete("/api/room/{id}", handlers.HandleDeleteRoom(logger, service))
+	r.Get("/api/room/{id}/bookings", handlers.HandleGetRoomBookings(logger, service))
+	return r
+}
+
+var lectureHall = models.Room{ID: 1, Building: "Main", Number: "Hall A", Seats: 120, Features: []string{"projector"}}
+
+func TestHandleGetRooms(t *testing.T) {
+	mockService := new(mockRoomGetter)
+	mockService.On("GetRooms", mock.Anything).Return([]models.Room{lectureHall}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/room", nil)
+	rr := httptest.NewRecorder()
+	newRoomRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id": 1, "building": "Main", "number": "Hall A", "seats": 120, "features": ["projector"]}]`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleCreateRoom(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"building": "Main", "number": "Hall A", "seats": 120, "features": ["projector"]}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Duplicate Number", body: `{"building": "Main", "number": "Hall A", "seats": 120, "features": ["projector"]}`, mockError: services.ErrConflict, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeConflict},
+		{name: "No Seats", body: `{"building": "Main", "number": "Hall A", "seats": 0}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Body", body: `{"seats": "many"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockRoomGetter)
+			if tt.expectCall {
+				requested := lectureHall
+				requested.ID = 0
+				mockService.On("CreateRoom", mock.Anything, requested).Return(lectureHall, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("POST", "/api/room", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newRoomRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Room
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, lectureHall, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteRoom(t *testing.T) {
+	mockService := new(mockRoomGetter)
+	mockService.On("DeleteRoom", mock.Anything, 1).Return(fmt.Errorf("still booked: %w", services.ErrConflict))
+
+	req, _ := http.NewRequest("DELETE", "/api/room/1", nil)
+	rr := httptest.NewRecorder()
+	newRoomRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetRoomBookings(t *testing.T) {
+	room := 4
+	bookings := []models.RoomBooking{{
+		CourseID:     3,
+		CourseCode:   "DES-110",
+		CourseName:   "UI Design",
+		Meeting:      models.Meeting{Days: []string{"mon", "wed"}, StartTime: "11:00", EndTime: "12:30", RoomID: &room},
+		Enrolled:     3,
+		OverCapacity: true,
+	}}
+	mockService := new(mockRoomGetter)
+	mockService.On("GetRoomBookings", mock.Anything, 4).Return(bookings, nil)
+
+	req, _ := http.NewRequest("GET", "/api/room/4/bookings", nil)
+	rr := httptest.NewRecorder()
+	newRoomRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{
+		"course_id": 3,
+		"course_code": "DES-110",
+		"course_name": "UI Design",
+		"meeting": {"days": ["mon", "wed"], "start_time": "11:00", "end_time": "12:30", "room_id": 4},
+		"enrolled": 3,
+		"over_capacity": true
+	}]`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestEncodeRoomConflict(t *testing.T) {
+	room := 4
+	conflicts := []models.ScheduleConflict{{
+		CourseID:   3,
+		CourseName: "UI Design",
+		Meeting:    models.Meeting{Days: []string{"mon"}, StartTime: "11:00", EndTime: "12:30", RoomID: &room},
+	}}
+	err := fmt.Errorf("[in services.UpdateCourse] %w", &services.RoomConflictError{CourseID: 1, Conflicts: conflicts})
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	req := httptest.NewRequest("PUT", "/api/course/1", nil)
+	rr := httptest.NewRecorder()
+	handlers.EncodeServiceError(rr, req, logger, err, "Error updating data")
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var problem handlers.ResponseErr
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, handlers.ProblemTypeRoomConflict, problem.Type)
+	assert.Equal(t, conflicts, problem.Conflicts)
+}
@@ -8,34 +8,43 @@ import (
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog/v2"
 )
 
 type studentGetter interface {
-	GetPeople(ctx context.Context, firstName, lastName, age, personType string) ([]models.Person, error)
+	GetPeople(ctx context.Context, filter services.PersonFilter, page services.Page) ([]models.Person, services.PageInfo, error)
 	GetPerson(ctx context.Context, firstName, personType string) (models.Person, error)
-	UpdatePerson(ctx context.Context, firstName, personType string, person models.Person) (models.Person, error)
-	CreatePerson(ctx context.Context, person models.Person) (models.Person, error)
-	DeletePerson(ctx context.Context, firstName, personType string) error
-	UpdatePersonCourses(ctx context.Context, studentID int, newCourses []int64) error
+	UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error)
+	CreatePersonWithCourses(ctx context.Context, person models.Person) (models.Person, error)
+	DeletePerson(ctx context.Context, firstName, personType string, opts services.DeleteOptions) error
+	GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error)
 }
 
 func HandleGetStudents(logger *httplog.Logger, service studentGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		queryParams := r.URL.Query()
-		firstName := queryParams.Get("first-name")
-		lastName := queryParams.Get("last-name")
-		age := queryParams.Get("age")
+		filter, errs := parsePersonFilter(r)
+		page, pageErrs := parsePage(r)
+		errs = append(errs, pageErrs...)
+		expand, expandErrs := parseExpand(r, "courses")
+		errs = append(errs, expandErrs...)
+		if len(errs) > 0 {
+			logger.Error("invalid student filter", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+		filter.Type = "student"
 
-		students, err := service.GetPeople(ctx, firstName, lastName, age, "student")
+		students, info, err := service.GetPeople(ctx, filter, page)
 		if err != nil {
 			logger.Error("error getting all students", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error retrieving data"})
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
 			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, students)
+		setPageHeaders(w, r, info)
+		encodePeople(w, r, logger, service, expand, students)
 	}
 }
 
@@ -44,13 +53,20 @@ func HandleGetStudent(logger *httplog.Logger, service studentGetter) http.Handle
 		ctx := r.Context()
 		nameParam := chi.URLParam(r, "firstName")
 
+		expand, errs := parseExpand(r, "courses")
+		if len(errs) > 0 {
+			logger.Error("invalid student query", "errors", errs)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid query parameters", errs...)
+			return
+		}
+
 		student, err := service.GetPerson(ctx, nameParam, "student")
 		if err != nil {
 			logger.Error("error getting student", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error retrieving data"})
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
 			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, student)
+		encodePerson(w, r, logger, service, expand, student)
 	}
 }
 
@@ -64,19 +80,19 @@ func HandleUpdateStudent(logger *httplog.Logger, service studentGetter) http.Han
 		err := json.NewDecoder(r.Body).Decode(&student)
 		if err != nil {
 			logger.Error("failed to decode request body", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid request payload"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
 			return
 		}
 
 		// Validate the student data
 		if err := utils.ValidatePerson(student); err != nil {
 			logger.Error("invalid student data", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: err.Error()})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
 			return
 		}
 
 		if student.Type != "student" {
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Person is not of type student"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "Person is not of type student", FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be student"})
 			return
 		}
 
@@ -84,25 +100,18 @@ func HandleUpdateStudent(logger *httplog.Logger, service studentGetter) http.Han
 		existingStudent, err := service.GetPerson(ctx, nameParam, "student")
 		if err != nil {
 			logger.Error("error fetching existing student", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error updating data"})
+			EncodeServiceError(w, r, logger, err, "Error updating data")
 			return
 		}
 
 		// Set the ID from the existing student
 		student.ID = existingStudent.ID
 
-		// Update the student in the database
-		updatedStudent, err := service.UpdatePerson(ctx, nameParam, "student", student)
+		// Update the student and their courses in the database
+		updatedStudent, err := service.UpdatePersonWithCourses(ctx, existingStudent.ID, student)
 		if err != nil {
 			logger.Error("error updating student", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error updating data"})
-			return
-		}
-
-		err = service.UpdatePersonCourses(ctx, student.ID, updatedStudent.Courses)
-		if err != nil {
-			logger.Error("error updating student's courses", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error updating courses"})
+			EncodeServiceError(w, r, logger, err, "Error updating data")
 			return
 		}
 
@@ -117,35 +126,56 @@ func HandleCreateStudent(logger *httplog.Logger, service studentGetter) http.Han
 		var student models.Person
 		if err := json.NewDecoder(r.Body).Decode(&student); err != nil {
 			logger.Error("failed to decode request body", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid request payload"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
 			return
 		}
 		if err := utils.ValidatePerson(student); err != nil {
 			logger.Error("invalid student data", "error", err)
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: err.Error()})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
 			return
 		}
 
 		if student.Type != "student" {
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Person is not of type student"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "Person is not of type student", FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be student"})
 			return
 		}
-		student, err := service.CreatePerson(ctx, student)
+		student, err := service.CreatePersonWithCourses(ctx, student)
 		if err != nil {
 			logger.Error("error creating student", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error creating data"})
+			EncodeServiceError(w, r, logger, err, "Error creating data")
 			return
 		}
+		EncodeResponse(w, logger, http.StatusOK, student.ID)
+	}
+}
 
-		if len(student.Courses) > 0 {
-			err = service.UpdatePersonCourses(ctx, student.ID, student.Courses)
-			if err != nil {
-				logger.Error("error associating courses with student", "error", err)
-				EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error associating courses"})
-				return
-			}
+// HandlePatchStudent applies a merge patch or JSON Patch to the stored student,
+// leaving every field the patch does not touch, including courses,
+// unchanged.
+func HandlePatchStudent(logger *httplog.Logger, service studentGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		nameParam := chi.URLParam(r, "firstName")
+
+		existingStudent, err := service.GetPerson(ctx, nameParam, "student")
+		if err != nil {
+			logger.Error("error fetching existing student", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
 		}
-		EncodeResponse(w, logger, http.StatusOK, student.ID)
+
+		student, err := applyPatch(r, existingStudent)
+		if err != nil {
+			logger.Error("failed to apply patch", "error", err)
+			encodePatchError(w, r, logger, err)
+			return
+		}
+		if student.Type != "student" {
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, "Person is not of type student", FieldError{Field: "type", Code: utils.CodeInvalid, Detail: "must be student"})
+			return
+		}
+
+		savePerson(w, r, logger, service, existingStudent.ID, student)
 	}
 }
 
@@ -155,12 +185,12 @@ func HandleDeleteStudent(logger *httplog.Logger, service studentGetter) http.Han
 		nameParam := chi.URLParam(r, "firstName")
 		if nameParam == "" {
 			logger.Error("invalid student name", "error", errors.New("first name is required"))
-			EncodeResponse(w, logger, http.StatusBadRequest, ResponseErr{Error: "Invalid student name"})
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid student name")
 			return
 		}
-		if err := service.DeletePerson(ctx, nameParam, "student"); err != nil {
+		if err := service.DeletePerson(ctx, nameParam, "student", services.DeleteOptions{}); err != nil {
 			logger.Error("error deleting student", "error", err)
-			EncodeResponse(w, logger, http.StatusInternalServerError, ResponseErr{Error: "Error deleting data"})
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
 			return
 		}
 		EncodeResponse(w, logger, http.StatusOK, "Student has successfully been deleted")
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog/v2"
 	"github.com/stretchr/testify/assert"
@@ -21,9 +23,9 @@ type mockStudentGetter struct {
 	mock.Mock
 }
 
-func (m *mockStudentGetter) GetPeople(ctx context.Context, firstName, lastName, age, personType string) ([]models.Person, error) {
-	args := m.Called(ctx, firstName, lastName, age, personType)
-	return args.Get(0).([]models.Person), args.Error(1)
+func (m *mockStudentGetter) GetPeople(ctx context.Context, filter services.PersonFilter, page services.Page) ([]models.Person, services.PageInfo, error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]models.Person), args.Get(1).(services.PageInfo), args.Error(2)
 }
 
 func (m *mockStudentGetter) GetPerson(ctx context.Context, firstName, personType string) (models.Person, error) {
@@ -31,23 +33,24 @@ func (m *mockStudentGetter) GetPerson(ctx context.Context, firstName, personType
 	return args.Get(0).(models.Person), args.Error(1)
 }
 
-func (m *mockStudentGetter) UpdatePerson(ctx context.Context, firstName, personType string, person models.Person) (models.Person, error) {
-	args := m.Called(ctx, firstName, personType, person)
+func (m *mockStudentGetter) UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error) {
+	args := m.Called(ctx, id, person)
 	return args.Get(0).(models.Person), args.Error(1)
 }
 
-func (m *mockStudentGetter) CreatePerson(ctx context.Context, person models.Person) (models.Person, error) {
+func (m *mockStudentGetter) CreatePersonWithCourses(ctx context.Context, person models.Person) (models.Person, error) {
 	args := m.Called(ctx, person)
 	return args.Get(0).(models.Person), args.Error(1)
 }
 
-func (m *mockStudentGetter) DeletePerson(ctx context.Context, firstName, personType string) error {
-	args := m.Called(ctx, firstName, personType)
+func (m *mockStudentGetter) DeletePerson(ctx context.Context, firstName, personType string, opts services.DeleteOptions) error {
+	args := m.Called(ctx, firstName, personType, opts)
 	return args.Error(0)
 }
 
-func (m *mockStudentGetter) UpdatePersonCourses(ctx context.Context, studentID int, newCourses []int64) error {
-	return nil
+func (m *mockStudentGetter) GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error) {
+	args := m.Called(ctx, personIDs)
+	return args.Get(0).(map[int][]models.Course), args.Error(1)
 }
 
 func TestHandleDeleteStudent(t *testing.T) {
@@ -65,12 +68,19 @@ func TestHandleDeleteStudent(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   `"Student has successfully been deleted"`,
 		},
+		{
+			name:           "Student Not Found",
+			firstName:      "Nobody",
+			mockError:      services.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   handlers.ResponseErr{Detail: "Resource not found"},
+		},
 		{
 			name:           "Error Deleting Student",
 			firstName:      "John",
 			mockError:      errors.New("database error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error deleting data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error deleting data"},
 		},
 	}
 
@@ -80,7 +90,7 @@ func TestHandleDeleteStudent(t *testing.T) {
 
 			// Only mock DeletePerson when the request is expected to be valid
 
-			mockService.On("DeletePerson", mock.Anything, tt.firstName, "student").Return(tt.mockError)
+			mockService.On("DeletePerson", mock.Anything, tt.firstName, "student", services.DeleteOptions{}).Return(tt.mockError)
 
 			logger := httplog.NewLogger("test")
 			handler := handlers.HandleDeleteStudent(logger, mockService)
@@ -100,7 +110,7 @@ func TestHandleDeleteStudent(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -137,7 +147,7 @@ func TestHandleGetStudents(t *testing.T) {
 			mockPeople:     nil,
 			mockError:      errors.New("service error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error retrieving data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error retrieving data"},
 		},
 	}
 
@@ -146,7 +156,9 @@ func TestHandleGetStudents(t *testing.T) {
 			mockService := new(mockStudentGetter)
 
 			// Mock GetPeople based on test scenario
-			mockService.On("GetPeople", mock.Anything, tt.firstName, tt.lastName, tt.age, "student").Return(tt.mockPeople, tt.mockError)
+			age, _ := strconv.Atoi(tt.age)
+			filter := services.PersonFilter{Type: "student", FirstName: tt.firstName, LastName: tt.lastName, Age: age}
+			mockService.On("GetPeople", mock.Anything, filter, services.Page{}).Return(tt.mockPeople, services.PageInfo{}, tt.mockError)
 
 			logger := httplog.NewLogger("test", httplog.Options{})
 			handler := handlers.HandleGetStudents(logger, mockService)
@@ -169,7 +181,7 @@ func TestHandleGetStudents(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
 			}
 
 			mockService.AssertExpectations(t)
@@ -200,7 +212,7 @@ func TestHandleGetStudent(t *testing.T) {
 			mockPerson:     models.Person{},
 			mockError:      errors.New("service error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   handlers.ResponseErr{Error: "Error retrieving data"},
+			expectedBody:   handlers.ResponseErr{Detail: "Error retrieving data"},
 		},
 	}
 
@@ -232,9 +244,117 @@ func TestHandleGetStudent(t *testing.T) {
 				var errorResponse handlers.ResponseErr
 				err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Error, errorResponse.Error)
+				assert.Equal(t, tt.expectedBody.(handlers.ResponseErr).Detail, errorResponse.Detail)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetStudentAmbiguous(t *testing.T) {
+	candidates := []models.Person{
+		{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 67},
+		{ID: 6, FirstName: "Bill", LastName: "Nye", Type: "student", Age: 40},
+	}
+	mockService := new(mockStudentGetter)
+	mockService.On("GetPerson", mock.Anything, "Bill", "student").Return(models.Person{}, &services.AmbiguousError{Candidates: candidates})
+
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/students/{firstName}", handlers.HandleGetStudent(logger, mockService))
+
+	req, _ := http.NewRequest("GET", "/students/Bill", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var errorResponse handlers.ResponseErr
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+	assert.Equal(t, handlers.ProblemTypeAmbiguousName, errorResponse.Type)
+	assert.Equal(t, candidates, errorResponse.Candidates)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlePatchStudent(t *testing.T) {
+	existing := models.Person{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 67, Courses: []int64{1}}
+
+	t.Run("Success", func(t *testing.T) {
+		patched := existing
+		patched.Age = 68
+
+		mockService := new(mockStudentGetter)
+		mockService.On("GetPerson", mock.Anything, "Bill", "student").Return(existing, nil)
+		mockService.On("UpdatePersonWithCourses", mock.Anything, 4, patched).Return(patched, nil)
+
+		logger := httplog.NewLogger("test", httplog.Options{})
+		r := chi.NewRouter()
+		r.Patch("/api/student/{firstName}", handlers.HandlePatchStudent(logger, mockService))
+
+		req, _ := http.NewRequest("PATCH", "/api/student/Bill", strings.NewReader(`{"age": 68}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Type Change Rejected", func(t *testing.T) {
+		mockService := new(mockStudentGetter)
+		mockService.On("GetPerson", mock.Anything, "Bill", "student").Return(existing, nil)
+
+		logger := httplog.NewLogger("test", httplog.Options{})
+		r := chi.NewRouter()
+		r.Patch("/api/student/{firstName}", handlers.HandlePatchStudent(logger, mockService))
+
+		req, _ := http.NewRequest("PATCH", "/api/student/Bill", strings.NewReader(`{"type": "professor"}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHandleUpdateStudent(t *testing.T) {
+	existing := models.Person{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 67, Courses: []int64{1}}
+	update := models.Person{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 68, Courses: []int64{1, 2}}
+
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "Unknown Course", mockError: services.ErrInvalidReference, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockStudentGetter)
+			mockService.On("GetPerson", mock.Anything, "Bill", "student").Return(existing, nil)
+			if tt.mockError != nil {
+				mockService.On("UpdatePersonWithCourses", mock.Anything, 4, update).Return(models.Person{}, tt.mockError)
+			} else {
+				mockService.On("UpdatePersonWithCourses", mock.Anything, 4, update).Return(update, nil)
 			}
 
+			logger := httplog.NewLogger("test", httplog.Options{})
+			r := chi.NewRouter()
+			r.Put("/api/student/{firstName}", handlers.HandleUpdateStudent(logger, mockService))
+
+			body, _ := json.Marshal(update)
+			req, _ := http.NewRequest("PUT", "/api/student/Bill", strings.NewReader(string(body)))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var student models.Person
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &student))
+				assert.Equal(t, update, student)
+			}
 			mockService.AssertExpectations(t)
 		})
 	}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+)
+
+type termGetter interface {
+	GetTerms(ctx context.Context) ([]models.Term, error)
+	GetTerm(ctx context.Context, id int) (models.Term, error)
+	CreateTerm(ctx context.Context, term models.Term) (models.Term, error)
+	UpdateTerm(ctx context.Context, id int, term models.Term) (models.Term, error)
+	DeleteTerm(ctx context.Context, id int) error
+}
+
+// parseTermScope reads the term query parameter shared by the enrollment
+// listings.
+func parseTermScope(r *http.Request) (services.TermScope, []FieldError) {
+	term, err := services.ParseTermScope(r.URL.Query().Get("term"))
+	if err != nil {
+		return services.TermScope{}, []FieldError{{Field: "term", Code: utils.CodeInvalid, Detail: "must be a term ID or one of current, past or future"}}
+	}
+	return term, nil
+}
+
+func HandleGetTerms(logger *httplog.Logger, service termGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		terms, err := service.GetTerms(r.Context())
+		if err != nil {
+			logger.Error("error getting all terms", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, terms)
+	}
+}
+
+func HandleGetTerm(logger *httplog.Logger, service termGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid term ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid term ID")
+			return
+		}
+
+		term, err := service.GetTerm(ctx, id)
+		if err != nil {
+			logger.Error("error getting term", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error retrieving data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, term)
+	}
+}
+
+func HandleCreateTerm(logger *httplog.Logger, service termGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var term models.Term
+		if err := json.NewDecoder(r.Body).Decode(&term); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateTerm(term); err != nil {
+			logger.Error("invalid term data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		term, err := service.CreateTerm(ctx, term)
+		if err != nil {
+			logger.Error("error creating term", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error creating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, term)
+	}
+}
+
+func HandleUpdateTerm(logger *httplog.Logger, service termGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var term models.Term
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid term ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid term ID")
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&term); err != nil {
+			logger.Error("failed to decode request body", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidPayload, "Invalid request payload", decodeErrors(err)...)
+			return
+		}
+		if err := utils.ValidateTerm(term); err != nil {
+			logger.Error("invalid term data", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeValidation, err.Error(), validationErrors(err)...)
+			return
+		}
+		term, err = service.UpdateTerm(ctx, id, term)
+		if err != nil {
+			logger.Error("error updating term", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error updating data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, term)
+	}
+}
+
+func HandleDeleteTerm(logger *httplog.Logger, service termGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idParam := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			logger.Error("invalid term ID", "error", err)
+			EncodeProblem(w, r, logger, http.StatusBadRequest, ProblemTypeInvalidParameter, "Invalid term ID")
+			return
+		}
+
+		if err := service.DeleteTerm(ctx, id); err != nil {
+			logger.Error("error deleting term", "error", err)
+			EncodeServiceError(w, r, logger, err, "Error deleting data")
+			return
+		}
+		EncodeResponse(w, logger, http.StatusOK, "Term has successfully been deleted")
+	}
+}
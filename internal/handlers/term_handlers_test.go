@@ -0,0 +1,198 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTermGetter struct {
+	mock.Mock
+}
+
+func (m *mockTermGetter) GetTerms(ctx context.Context) ([]models.Term, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Term), args.Error(1)
+}
+
+func (m *mockTermGetter) GetTerm(ctx context.Context, id int) (models.Term, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Term), args.Error(1)
+}
+
+func (m *mockTermGetter) CreateTerm(ctx context.Context, term models.Term) (models.Term, error) {
+	args := m.Called(ctx, term)
+	return args.Get(0).(models.Term), args.Error(1)
+}
+
+func (m *mockTermGetter) UpdateTerm(ctx context.Context, id int, term models.Term) (models.Term, error) {
+	args := m.Called(ctx, id, term)
+	return args.Get(0).(models.Term), args.Error(1)
+}
+
+func (m *mockTermGetter) DeleteTerm(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func newTermRouter(service *mockTermGetter) *chi.Mux {
+	logger := httplog.NewLogger("test", httplog.Options{})
+	r := chi.NewRouter()
+	r.Get("/api/term", handlers.HandleGetTerms(logger, service))
+	r.Get("/api/term/{id}", handlers.HandleGetTerm(logger, service))
+	r.Post("/api/term", handlers.HandleCreateTerm(logger, service))
+	r.Put("/api/term/{id}", handlers.HandleUpdateTerm(logger, service))
+	r.Delete("/api/term/{id}", handlers.HandleDeleteTerm(logger, service))
+	return r
+}
+
+var fall2026 = models.Term{
+	ID:        1,
+	Name:      "Fall 2026",
+	StartDate: models.Date{Time: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+	EndDate:   models.Date{Time: time.Date(2026, 12, 18, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestHandleGetTerms(t *testing.T) {
+	mockService := new(mockTermGetter)
+	mockService.On("GetTerms", mock.Anything).Return([]models.Term{fall2026}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/term", nil)
+	rr := httptest.NewRecorder()
+	newTermRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id": 1, "name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-12-18"}]`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetTerm(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "Success", url: "/api/term/1", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Not Found", url: "/api/term/1", mockError: services.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "Invalid ID", url: "/api/term/abc", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockTermGetter)
+			if tt.expectCall {
+				mockService.On("GetTerm", mock.Anything, 1).Return(fall2026, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			newTermRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Term
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, fall2026, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCreateTerm(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Success", body: `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-12-18"}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "Duplicate Name", body: `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-12-18"}`, mockError: services.ErrConflict, expectCall: true, expectedStatus: http.StatusConflict, expectedType: handlers.ProblemTypeConflict},
+		{name: "Ends Before It Starts", body: `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-01-01"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeValidation},
+		{name: "Malformed Date", body: `{"name": "Fall 2026", "start_date": "September", "end_date": "2026-12-18"}`, expectedStatus: http.StatusBadRequest, expectedType: handlers.ProblemTypeInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockTermGetter)
+			if tt.expectCall {
+				requested := fall2026
+				requested.ID = 0
+				mockService.On("CreateTerm", mock.Anything, requested).Return(fall2026, tt.mockError)
+			}
+
+			req, _ := http.NewRequest("POST", "/api/term", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			newTermRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.Term
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, fall2026, body)
+			} else {
+				var errorResponse handlers.ResponseErr
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				assert.Equal(t, tt.expectedType, errorResponse.Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateTerm(t *testing.T) {
+	mockService := new(mockTermGetter)
+	requested := fall2026
+	requested.ID = 0
+	mockService.On("UpdateTerm", mock.Anything, 1, requested).Return(fall2026, nil)
+
+	req, _ := http.NewRequest("PUT", "/api/term/1", strings.NewReader(`{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-12-18"}`))
+	rr := httptest.NewRecorder()
+	newTermRouter(mockService).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleDeleteTerm(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "Has Enrollments", mockError: services.ErrConflict, expectedStatus: http.StatusConflict},
+		{name: "Error", mockError: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockTermGetter)
+			mockService.On("DeleteTerm", mock.Anything, 1).Return(tt.mockError)
+
+			req, _ := http.NewRequest("DELETE", "/api/term/1", nil)
+			rr := httptest.NewRecorder()
+			newTermRouter(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package utils_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/handlers/utils"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
@@ -44,6 +46,17 @@ func TestValidatePerson(t *testing.T) {
 			},
 			expectErr: "last name is required",
 		},
+		{
+			name: "Max Credits Not Positive",
+			person: models.Person{
+				FirstName:  "John",
+				LastName:   "Doe",
+				Type:       "student",
+				Age:        20,
+				MaxCredits: new(int),
+			},
+			expectErr: "max credits must be between 1 and 40",
+		},
 		{
 			name: "Invalid Type",
 			person: models.Person{
@@ -62,7 +75,33 @@ func TestValidatePerson(t *testing.T) {
 				Type:      "student",
 				Age:       -1, // Invalid age
 			},
-			expectErr: "age must be a positive number",
+			expectErr: "age must be at least 1",
+		},
+		{
+			name: "Age Too High",
+			person: models.Person{
+				FirstName: "John",
+				LastName:  "Doe",
+				Type:      "student",
+				Age:       200,
+			},
+			expectErr: "age must be at most 150",
+		},
+		{
+			name: "Invalid Course IDs",
+			person: models.Person{
+				FirstName: "John",
+				LastName:  "Doe",
+				Type:      "student",
+				Age:       20,
+				Courses:   []int64{1, -2, 1},
+			},
+			expectErr: "course id must be a positive number; course id 1 is listed more than once",
+		},
+		{
+			name:      "Multiple Violations",
+			person:    models.Person{Type: "teacher"},
+			expectErr: "first name is required; last name is required; type must be either 'student' or 'professor'; age must be at least 1",
 		},
 	}
 
@@ -79,3 +118,392 @@ func TestValidatePerson(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePersonViolations(t *testing.T) {
+	err := utils.ValidatePerson(models.Person{
+		FirstName: strings.Repeat("a", utils.MaxNameLength+1),
+		LastName:  "Doe",
+		Type:      "student",
+		Age:       20,
+		Courses:   []int64{3, 3},
+	})
+
+	var validationErr utils.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, utils.ValidationError{
+		{Field: "first_name", Code: utils.CodeTooLong, Message: "first name must be at most 100 characters"},
+		{Field: "courses[1]", Code: utils.CodeDuplicate, Message: "course id 3 is listed more than once"},
+	}, validationErr)
+}
+
+func TestValidateCourse(t *testing.T) {
+	tests := []struct {
+		name      string
+		course    models.Course
+		expectErr string
+	}{
+		{
+			name:      "Valid Course",
+			course:    models.Course{Code: "CS-101", Name: "Programming"},
+			expectErr: "",
+		},
+		{
+			name:      "Missing Code",
+			course:    models.Course{Name: "Programming"},
+			expectErr: "course code is required",
+		},
+		{
+			name:      "Malformed Code",
+			course:    models.Course{Code: "cs 101", Name: "Programming"},
+			expectErr: "course code must be capital letters, a hyphen and a number, such as CS-101",
+		},
+		{
+			name:      "Missing Name",
+			course:    models.Course{Code: "CS-101", Name: "  "},
+			expectErr: "course name is required",
+		},
+		{
+			name:      "Name Too Long",
+			course:    models.Course{Code: "CS-101", Name: strings.Repeat("a", utils.MaxNameLength+1)},
+			expectErr: "course name must be at most 100 characters",
+		},
+		{
+			name:      "Capacity Not Positive",
+			course:    models.Course{Code: "CS-101", Name: "Programming", Capacity: new(int)},
+			expectErr: "course capacity must be a positive number",
+		},
+		{
+			name:      "Credits Out Of Range",
+			course:    models.Course{Code: "CS-101", Name: "Programming", Credits: utils.MaxCourseCredits + 1},
+			expectErr: "course credits must be between 0 and 20",
+		},
+		{
+			name:      "Valid Meetings",
+			course:    models.Course{Code: "CS-101", Name: "Programming", Meetings: []models.Meeting{{Days: []string{"mon", "wed"}, StartTime: "09:00", EndTime: "10:30", Location: "Room 101"}}},
+			expectErr: "",
+		},
+		{
+			name:      "Meeting Without Days Or Times",
+			course:    models.Course{Code: "CS-101", Name: "Programming", Meetings: []models.Meeting{{}}},
+			expectErr: "meeting days are required; meeting start time is required; meeting end time is required",
+		},
+		{
+			name:      "Invalid Meeting Days",
+			course:    models.Course{Code: "CS-101", Name: "Programming", Meetings: []models.Meeting{{Days: []string{"mon", "funday", "mon"}, StartTime: "09:00", EndTime: "10:00"}}},
+			expectErr: "meeting day must be one of mon, tue, wed, thu, fri, sat, sun; meeting day mon is listed more than once",
+		},
+		{
+			name:      "Meeting Ends Before It Starts",
+			course:    models.Course{Code: "CS-101", Name: "Programming", Meetings: []models.Meeting{{Days: []string{"fri"}, StartTime: "14:00", EndTime: "13:00"}}},
+			expectErr: "meeting end time must be after its start time",
+		},
+		{
+			name:      "Malformed Meeting Time",
+			course:    models.Course{Code: "CS-101", Name: "Programming", Meetings: []models.Meeting{{Days: []string{"fri"}, StartTime: "9am", EndTime: "10:00"}}},
+			expectErr: "meeting start time must be formatted as HH:MM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateCourse(tt.course)
+
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Equal(t, tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateDepartment(t *testing.T) {
+	tests := []struct {
+		name       string
+		department models.Department
+		expectErr  string
+	}{
+		{
+			name:       "Valid Department",
+			department: models.Department{Code: "CS", Name: "Computer Science"},
+			expectErr:  "",
+		},
+		{
+			name:       "Missing Fields",
+			department: models.Department{},
+			expectErr:  "department code is required; department name is required",
+		},
+		{
+			name:       "Lowercase Code",
+			department: models.Department{Code: "cs", Name: "Computer Science"},
+			expectErr:  "department code must be 1 to 8 capital letters, such as CS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateDepartment(tt.department)
+
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Equal(t, tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateRoom(t *testing.T) {
+	tests := []struct {
+		name      string
+		room      models.Room
+		expectErr string
+	}{
+		{
+			name:      "Valid Room",
+			room:      models.Room{Building: "Main", Number: "101", Seats: 40, Features: []string{"projector"}},
+			expectErr: "",
+		},
+		{
+			name:      "Missing Fields",
+			room:      models.Room{},
+			expectErr: "room building is required; room number is required; room seats must be a positive number",
+		},
+		{
+			name:      "Bad Features",
+			room:      models.Room{Building: "Main", Number: "101", Seats: 40, Features: []string{"projector", " ", "projector"}},
+			expectErr: "room feature must not be empty; room feature projector is listed more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateRoom(tt.room)
+
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Equal(t, tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateTerm(t *testing.T) {
+	date := func(s string) models.Date {
+		d, _ := time.Parse(models.DateLayout, s)
+		return models.Date{Time: d}
+	}
+
+	tests := []struct {
+		name      string
+		term      models.Term
+		expectErr string
+	}{
+		{
+			name:      "Valid Term",
+			term:      models.Term{Name: "Fall 2026", StartDate: date("2026-09-01"), EndDate: date("2026-12-18")},
+			expectErr: "",
+		},
+		{
+			name:      "Missing Fields",
+			term:      models.Term{},
+			expectErr: "term name is required; term start date is required; term end date is required",
+		},
+		{
+			name:      "Ends Before It Starts",
+			term:      models.Term{Name: "Fall 2026", StartDate: date("2026-09-01"), EndDate: date("2026-08-31")},
+			expectErr: "term end date must not be before its start date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateTerm(tt.term)
+
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Equal(t, tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateEnrollment(t *testing.T) {
+	tests := []struct {
+		name       string
+		enrollment models.Enrollment
+		expectErr  string
+	}{
+		{
+			name:       "Valid Enrollment",
+			enrollment: models.Enrollment{PersonID: 1, CourseID: 2},
+			expectErr:  "",
+		},
+		{
+			name:       "Missing Both",
+			enrollment: models.Enrollment{},
+			expectErr:  "person id must be a positive number; course id must be a positive number",
+		},
+		{
+			name:       "Teaching Assistant",
+			enrollment: models.Enrollment{PersonID: 1, CourseID: 2, Role: models.RoleTeachingAssistant},
+			expectErr:  "",
+		},
+		{
+			name:       "Unknown Role",
+			enrollment: models.Enrollment{PersonID: 1, CourseID: 2, Role: "lecturer"},
+			expectErr:  "role must be one of instructor, co_instructor, teaching_assistant, student",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateEnrollment(tt.enrollment)
+
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Equal(t, tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateGradeSubmission(t *testing.T) {
+	termID := 0
+	tests := []struct {
+		name       string
+		submission models.GradeSubmission
+		expectErr  string
+	}{
+		{
+			name:       "Valid Submission",
+			submission: models.GradeSubmission{ProfessorID: 1, Grades: []models.GradeEntry{{PersonID: 3, Grade: "A"}, {PersonID: 4, Grade: "B+"}}},
+			expectErr:  "",
+		},
+		{
+			name:       "Missing Professor And Grades",
+			submission: models.GradeSubmission{TermID: &termID},
+			expectErr:  "professor id must be a positive number; term id must be a positive number; at least one grade is required",
+		},
+		{
+			name:       "Invalid Grades",
+			submission: models.GradeSubmission{ProfessorID: 1, Grades: []models.GradeEntry{{PersonID: 3, Grade: "A"}, {PersonID: 3, Grade: ""}, {PersonID: 0, Grade: "EXCELLENT"}}},
+			expectErr:  "person 3 is graded more than once; grade is required; person id must be a positive number; grade must be at most 5 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateGradeSubmission(tt.submission)
+
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Equal(t, tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateGradeScaleEntry(t *testing.T) {
+	points := 6.0
+	require.NoError(t, utils.ValidateGradeScaleEntry(models.GradeScaleEntry{Letter: "W"}))
+	require.EqualError(t, utils.ValidateGradeScaleEntry(models.GradeScaleEntry{Letter: " ", Points: &points}),
+		"grade is required; grade points must be between 0 and 5")
+}
+
+func TestValidateAttendanceSubmission(t *testing.T) {
+	tests := []struct {
+		name       string
+		submission models.AttendanceSubmission
+		expectErr  string
+	}{
+		{
+			name:       "Valid Submission",
+			submission: models.AttendanceSubmission{ProfessorID: 1, Records: []models.AttendanceEntry{{PersonID: 3, Status: "present"}, {PersonID: 4, Status: "late"}}},
+			expectErr:  "",
+		},
+		{
+			name:       "Whole Roster",
+			submission: models.AttendanceSubmission{ProfessorID: 1, DefaultStatus: "present"},
+			expectErr:  "",
+		},
+		{
+			name:       "Missing Professor And Records",
+			submission: models.AttendanceSubmission{},
+			expectErr:  "professor id must be a positive number; at least one record is required without a default status",
+		},
+		{
+			name:       "Invalid Records",
+			submission: models.AttendanceSubmission{ProfessorID: 1, DefaultStatus: "here", Records: []models.AttendanceEntry{{PersonID: 3, Status: "absent"}, {PersonID: 3, Status: ""}}},
+			expectErr:  "attendance status must be one of present, absent, late, excused; person 3 is listed more than once; attendance status is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateAttendanceSubmission(tt.submission)
+
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Equal(t, tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateClassSession(t *testing.T) {
+	require.NoError(t, utils.ValidateClassSession(models.ClassSession{Date: models.Date{Time: time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)}}))
+	require.EqualError(t, utils.ValidateClassSession(models.ClassSession{Topic: strings.Repeat("a", utils.MaxNameLength+1)}),
+		"session date is required; session topic must be at most 100 characters")
+}
+
+func TestValidateAssignment(t *testing.T) {
+	termID := -1
+	require.NoError(t, utils.ValidateAssignment(models.Assignment{Title: "Homework 1", DueAt: time.Date(2026, 9, 14, 23, 59, 0, 0, time.UTC), MaxPoints: 100}))
+	require.EqualError(t, utils.ValidateAssignment(models.Assignment{Title: " ", MaxPoints: 1001, TermID: &termID}),
+		"assignment title is required; assignment due date is required; max points must be greater than 0 and at most 1000; term id must be a positive number")
+}
+
+func TestValidateSubmission(t *testing.T) {
+	require.NoError(t, utils.ValidateSubmission(models.Submission{PersonID: 3, FileRef: "uploads/hw1-3.pdf"}))
+	require.EqualError(t, utils.ValidateSubmission(models.Submission{Content: " ", FileRef: strings.Repeat("a", utils.MaxFileRefLength+1)}),
+		"person id must be a positive number; file reference must be at most 500 characters")
+	require.EqualError(t, utils.ValidateSubmission(models.Submission{PersonID: 3}),
+		"submission content or file reference is required")
+}
+
+func TestValidateSubmissionScore(t *testing.T) {
+	negative := -1.0
+	require.EqualError(t, utils.ValidateSubmissionScore(models.SubmissionScore{}),
+		"professor id must be a positive number; score is required")
+	require.EqualError(t, utils.ValidateSubmissionScore(models.SubmissionScore{ProfessorID: 1, Score: &negative}),
+		"score must not be negative")
+}
+
+func TestValidateProgram(t *testing.T) {
+	require.NoError(t, utils.ValidateProgram(models.Program{
+		Name:            "Computer Science",
+		RequiredCourses: []int{1, 2},
+		ElectivePools:   []models.ElectivePool{{Name: "Design electives", MinCredits: 3, Courses: []int{3}}},
+	}))
+	require.EqualError(t, utils.ValidateProgram(models.Program{
+		Name:            " ",
+		RequiredCourses: []int{1, 1},
+		ElectivePools: []models.ElectivePool{
+			{Name: "Design electives", MinCredits: 3, Courses: []int{0}},
+			{Name: "Design electives"},
+		},
+	}), `program name is required; course id 1 is listed more than once; course id must be a positive number; elective pool "Design electives" is listed more than once; elective pool minimum credits must be a positive number; elective pool needs at least one course`)
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+const (
+	MaxAssignmentPoints = 1000
+	MaxContentLength    = 10000
+	MaxFileRefLength    = 500
+)
+
+// ValidateAssignment checks every field of an assignment and returns a
+// ValidationError listing all violations, or nil if the assignment is valid.
+func ValidateAssignment(assignment models.Assignment) error {
+	var v validator
+
+	if strings.TrimSpace(assignment.Title) == "" {
+		v.add("title", CodeRequired, "assignment title is required")
+	} else if utf8.RuneCountInString(assignment.Title) > MaxNameLength {
+		v.add("title", CodeTooLong, fmt.Sprintf("assignment title must be at most %d characters", MaxNameLength))
+	}
+	if assignment.DueAt.IsZero() {
+		v.add("due_at", CodeRequired, "assignment due date is required")
+	}
+	if assignment.MaxPoints <= 0 || assignment.MaxPoints > MaxAssignmentPoints {
+		v.add("max_points", CodeOutOfRange, fmt.Sprintf("max points must be greater than 0 and at most %d", MaxAssignmentPoints))
+	}
+	if assignment.TermID != nil && *assignment.TermID <= 0 {
+		v.add("term_id", CodeInvalid, "term id must be a positive number")
+	}
+
+	return v.err()
+}
+
+// ValidateSubmission checks that a submission names the submitting student
+// and carries either text or a file reference, and returns a
+// ValidationError listing all violations, or nil if it is valid.
+func ValidateSubmission(submission models.Submission) error {
+	var v validator
+
+	if submission.PersonID <= 0 {
+		v.add("person_id", CodeRequired, "person id must be a positive number")
+	}
+	if strings.TrimSpace(submission.Content) == "" && strings.TrimSpace(submission.FileRef) == "" {
+		v.add("content", CodeRequired, "submission content or file reference is required")
+	}
+	if utf8.RuneCountInString(submission.Content) > MaxContentLength {
+		v.add("content", CodeTooLong, fmt.Sprintf("submission content must be at most %d characters", MaxContentLength))
+	}
+	if utf8.RuneCountInString(submission.FileRef) > MaxFileRefLength {
+		v.add("file_ref", CodeTooLong, fmt.Sprintf("file reference must be at most %d characters", MaxFileRefLength))
+	}
+
+	return v.err()
+}
+
+// ValidateSubmissionScore checks that a score names the scoring professor
+// and is not negative, and returns a ValidationError listing all
+// violations, or nil if it is valid. Whether the score fits the assignment
+// is checked when it is stored.
+func ValidateSubmissionScore(score models.SubmissionScore) error {
+	var v validator
+
+	if score.ProfessorID <= 0 {
+		v.add("professor_id", CodeRequired, "professor id must be a positive number")
+	}
+	if score.Score == nil {
+		v.add("score", CodeRequired, "score is required")
+	} else if *score.Score < 0 {
+		v.add("score", CodeOutOfRange, "score must not be negative")
+	}
+
+	return v.err()
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+// ValidateClassSession checks a class session and returns a ValidationError
+// listing all violations, or nil if the session is valid.
+func ValidateClassSession(session models.ClassSession) error {
+	var v validator
+
+	if session.Date.IsZero() {
+		v.add("date", CodeRequired, "session date is required")
+	}
+	if utf8.RuneCountInString(session.Topic) > MaxNameLength {
+		v.add("topic", CodeTooLong, fmt.Sprintf("session topic must be at most %d characters", MaxNameLength))
+	}
+	if session.TermID != nil && *session.TermID <= 0 {
+		v.add("term_id", CodeInvalid, "term id must be a positive number")
+	}
+
+	return v.err()
+}
+
+// ValidateAttendanceSubmission checks that an attendance submission names
+// the professor taking attendance, lists every student at most once with a
+// known status and lists at least one student unless a default status
+// covers the roster, and returns a ValidationError listing all violations,
+// or nil if it is valid.
+func ValidateAttendanceSubmission(submission models.AttendanceSubmission) error {
+	var v validator
+
+	if submission.ProfessorID <= 0 {
+		v.add("professor_id", CodeRequired, "professor id must be a positive number")
+	}
+	if submission.DefaultStatus != "" {
+		validateAttendanceStatus(&v, "default_status", submission.DefaultStatus)
+	} else if len(submission.Records) == 0 {
+		v.add("records", CodeRequired, "at least one record is required without a default status")
+	}
+
+	seen := make(map[int]bool, len(submission.Records))
+	for i, entry := range submission.Records {
+		field := fmt.Sprintf("records[%d]", i)
+		if entry.PersonID <= 0 {
+			v.add(field+".person_id", CodeRequired, "person id must be a positive number")
+		} else if seen[entry.PersonID] {
+			v.add(field+".person_id", CodeDuplicate, fmt.Sprintf("person %d is listed more than once", entry.PersonID))
+		}
+		seen[entry.PersonID] = true
+		validateAttendanceStatus(&v, field+".status", entry.Status)
+	}
+
+	return v.err()
+}
+
+func validateAttendanceStatus(v *validator, field, status string) {
+	if status == "" {
+		v.add(field, CodeRequired, "attendance status is required")
+	} else if !slices.Contains(models.AttendanceStatuses, status) {
+		v.add(field, CodeInvalid, fmt.Sprintf("attendance status must be one of %s", strings.Join(models.AttendanceStatuses, ", ")))
+	}
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+// MaxCourseCredits is the most credit hours a single course can be worth.
+const MaxCourseCredits = 20
+
+// courseCodePattern matches course codes, a department code followed by a
+// hyphen and a number with an optional letter suffix, such as CS-101 or
+// MATH-2010H.
+var courseCodePattern = regexp.MustCompile(`^[A-Z]{1,8}-[0-9]{1,4}[A-Z]?$`)
+
+// ValidateCourse checks every field of a course and returns a
+// ValidationError listing all violations, or nil if the course is valid.
+func ValidateCourse(course models.Course) error {
+	var v validator
+
+	if course.Code == "" {
+		v.add("code", CodeRequired, "course code is required")
+	} else if !courseCodePattern.MatchString(course.Code) {
+		v.add("code", CodeInvalid, "course code must be capital letters, a hyphen and a number, such as CS-101")
+	}
+
+	if strings.TrimSpace(course.Name) == "" {
+		v.add("name", CodeRequired, "course name is required")
+	} else if utf8.RuneCountInString(course.Name) > MaxNameLength {
+		v.add("name", CodeTooLong, fmt.Sprintf("course name must be at most %d characters", MaxNameLength))
+	}
+
+	if course.DepartmentID != nil && *course.DepartmentID < 1 {
+		v.add("department_id", CodeInvalid, "department id must be a positive number")
+	}
+
+	if course.Capacity != nil && *course.Capacity < 1 {
+		v.add("capacity", CodeOutOfRange, "course capacity must be a positive number")
+	}
+
+	if course.Credits < 0 || course.Credits > MaxCourseCredits {
+		v.add("credits", CodeOutOfRange, fmt.Sprintf("course credits must be between 0 and %d", MaxCourseCredits))
+	}
+
+	for i, meeting := range course.Meetings {
+		validateMeeting(&v, fmt.Sprintf("meetings[%d]", i), meeting)
+	}
+
+	return v.err()
+}
+
+// validateMeeting checks a meeting pattern of a course. Field is the JSON
+// path of the meeting.
+func validateMeeting(v *validator, field string, meeting models.Meeting) {
+	if len(meeting.Days) == 0 {
+		v.add(field+".days", CodeRequired, "meeting days are required")
+	}
+	seen := make(map[string]bool, len(meeting.Days))
+	for i, day := range meeting.Days {
+		dayField := fmt.Sprintf("%s.days[%d]", field, i)
+		if !slices.Contains(models.Weekdays, day) {
+			v.add(dayField, CodeInvalid, fmt.Sprintf("meeting day must be one of %s", strings.Join(models.Weekdays, ", ")))
+			continue
+		}
+		if seen[day] {
+			v.add(dayField, CodeDuplicate, fmt.Sprintf("meeting day %s is listed more than once", day))
+			continue
+		}
+		seen[day] = true
+	}
+
+	start, startErr := time.Parse(models.TimeLayout, meeting.StartTime)
+	if meeting.StartTime == "" {
+		v.add(field+".start_time", CodeRequired, "meeting start time is required")
+	} else if startErr != nil {
+		v.add(field+".start_time", CodeInvalid, "meeting start time must be formatted as HH:MM")
+	}
+	end, endErr := time.Parse(models.TimeLayout, meeting.EndTime)
+	if meeting.EndTime == "" {
+		v.add(field+".end_time", CodeRequired, "meeting end time is required")
+	} else if endErr != nil {
+		v.add(field+".end_time", CodeInvalid, "meeting end time must be formatted as HH:MM")
+	}
+	if startErr == nil && endErr == nil && !end.After(start) {
+		v.add(field+".end_time", CodeOutOfRange, "meeting end time must be after its start time")
+	}
+
+	if utf8.RuneCountInString(meeting.Location) > MaxNameLength {
+		v.add(field+".location", CodeTooLong, fmt.Sprintf("meeting location must be at most %d characters", MaxNameLength))
+	}
+
+	if meeting.RoomID != nil && *meeting.RoomID < 1 {
+		v.add(field+".room_id", CodeInvalid, "meeting room id must be a positive number")
+	}
+}
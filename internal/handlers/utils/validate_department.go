@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+// departmentCodePattern matches department codes, a few capital letters such
+// as CS.
+var departmentCodePattern = regexp.MustCompile(`^[A-Z]{1,8}$`)
+
+// ValidateDepartment checks every field of a department and returns a
+// ValidationError listing all violations, or nil if the department is
+// valid.
+func ValidateDepartment(department models.Department) error {
+	var v validator
+
+	if department.Code == "" {
+		v.add("code", CodeRequired, "department code is required")
+	} else if !departmentCodePattern.MatchString(department.Code) {
+		v.add("code", CodeInvalid, "department code must be 1 to 8 capital letters, such as CS")
+	}
+
+	if strings.TrimSpace(department.Name) == "" {
+		v.add("name", CodeRequired, "department name is required")
+	} else if utf8.RuneCountInString(department.Name) > MaxNameLength {
+		v.add("name", CodeTooLong, fmt.Sprintf("department name must be at most %d characters", MaxNameLength))
+	}
+
+	return v.err()
+}
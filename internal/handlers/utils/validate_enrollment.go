@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+// ValidateEnrollment checks that an enrollment names both a person and a
+// course and that its role, if any, is a known one, and returns a ValidationError listing all violations, or nil if the
+// enrollment is valid.
+func ValidateEnrollment(enrollment models.Enrollment) error {
+	var v validator
+
+	if enrollment.PersonID <= 0 {
+		v.add("person_id", CodeRequired, "person id must be a positive number")
+	}
+	if enrollment.CourseID <= 0 {
+		v.add("course_id", CodeRequired, "course id must be a positive number")
+	}
+	if enrollment.TermID != nil && *enrollment.TermID <= 0 {
+		v.add("term_id", CodeInvalid, "term id must be a positive number")
+	}
+	if enrollment.Role != "" && !slices.Contains(models.Roles, enrollment.Role) {
+		v.add("role", CodeInvalid, fmt.Sprintf("role must be one of %s", strings.Join(models.Roles, ", ")))
+	}
+
+	return v.err()
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+const (
+	MaxGradeLetterLength = 5
+	MaxGradePoints       = 5
+)
+
+// ValidateGradeScaleEntry checks a letter of the grading scale and returns
+// a ValidationError listing all violations, or nil if it is valid.
+func ValidateGradeScaleEntry(entry models.GradeScaleEntry) error {
+	var v validator
+
+	validateGradeLetter(&v, "letter", entry.Letter)
+	if entry.Points != nil && (*entry.Points < 0 || *entry.Points > MaxGradePoints) {
+		v.add("points", CodeOutOfRange, fmt.Sprintf("grade points must be between 0 and %d", MaxGradePoints))
+	}
+
+	return v.err()
+}
+
+// ValidateGradeSubmission checks that a grade submission names the posting
+// professor and grades every student at most once, and returns a
+// ValidationError listing all violations, or nil if it is valid.
+func ValidateGradeSubmission(submission models.GradeSubmission) error {
+	var v validator
+
+	if submission.ProfessorID <= 0 {
+		v.add("professor_id", CodeRequired, "professor id must be a positive number")
+	}
+	if submission.TermID != nil && *submission.TermID <= 0 {
+		v.add("term_id", CodeInvalid, "term id must be a positive number")
+	}
+	if len(submission.Grades) == 0 {
+		v.add("grades", CodeRequired, "at least one grade is required")
+	}
+
+	seen := make(map[int]bool, len(submission.Grades))
+	for i, entry := range submission.Grades {
+		field := fmt.Sprintf("grades[%d]", i)
+		if entry.PersonID <= 0 {
+			v.add(field+".person_id", CodeRequired, "person id must be a positive number")
+		} else if seen[entry.PersonID] {
+			v.add(field+".person_id", CodeDuplicate, fmt.Sprintf("person %d is graded more than once", entry.PersonID))
+		}
+		seen[entry.PersonID] = true
+		validateGradeLetter(&v, field+".grade", entry.Grade)
+	}
+
+	return v.err()
+}
+
+func validateGradeLetter(v *validator, field, letter string) {
+	if strings.TrimSpace(letter) == "" {
+		v.add(field, CodeRequired, "grade is required")
+	} else if utf8.RuneCountInString(letter) > MaxGradeLetterLength {
+		v.add(field, CodeTooLong, fmt.Sprintf("grade must be at most %d characters", MaxGradeLetterLength))
+	}
+}
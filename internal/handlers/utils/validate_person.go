@@ -1,32 +1,74 @@
 package utils
 
 import (
-	"errors"
+	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
 )
 
+const (
+	MaxNameLength = 100
+	MinAge        = 1
+	MaxAge        = 150
+	MaxCreditLoad = 40
+)
+
+// ValidatePerson checks every field of a person and returns a
+// ValidationError listing all violations, or nil if the person is valid.
 func ValidatePerson(person models.Person) error {
+	var v validator
+
 	// Validate FirstName
 	if strings.TrimSpace(person.FirstName) == "" {
-		return errors.New("first name is required")
+		v.add("first_name", CodeRequired, "first name is required")
+	} else if utf8.RuneCountInString(person.FirstName) > MaxNameLength {
+		v.add("first_name", CodeTooLong, fmt.Sprintf("first name must be at most %d characters", MaxNameLength))
 	}
 
 	// Validate LastName
 	if strings.TrimSpace(person.LastName) == "" {
-		return errors.New("last name is required")
+		v.add("last_name", CodeRequired, "last name is required")
+	} else if utf8.RuneCountInString(person.LastName) > MaxNameLength {
+		v.add("last_name", CodeTooLong, fmt.Sprintf("last name must be at most %d characters", MaxNameLength))
 	}
 
 	// Validate Type (must be either "student" or "professor")
 	if person.Type != "student" && person.Type != "professor" {
-		return errors.New("type must be either 'student' or 'professor'")
+		v.add("type", CodeInvalid, "type must be either 'student' or 'professor'")
+	}
+
+	// Validate Age (must be within human bounds)
+	if person.Age < MinAge {
+		v.add("age", CodeOutOfRange, fmt.Sprintf("age must be at least %d", MinAge))
+	} else if person.Age > MaxAge {
+		v.add("age", CodeOutOfRange, fmt.Sprintf("age must be at most %d", MaxAge))
 	}
 
-	// Validate Age (must be a positive integer)
-	if person.Age <= 0 {
-		return errors.New("age must be a positive number")
+	if person.MaxCredits != nil && (*person.MaxCredits < 1 || *person.MaxCredits > MaxCreditLoad) {
+		v.add("max_credits", CodeOutOfRange, fmt.Sprintf("max credits must be between 1 and %d", MaxCreditLoad))
 	}
 
-	return nil
+	validateCourseIDs(&v, person.Courses)
+
+	return v.err()
+}
+
+// validateCourseIDs rejects course IDs that can never reference a course and
+// IDs listed more than once.
+func validateCourseIDs(v *validator, courses []int64) {
+	seen := make(map[int64]bool, len(courses))
+	for i, id := range courses {
+		field := fmt.Sprintf("courses[%d]", i)
+		if id <= 0 {
+			v.add(field, CodeInvalid, "course id must be a positive number")
+			continue
+		}
+		if seen[id] {
+			v.add(field, CodeDuplicate, fmt.Sprintf("course id %d is listed more than once", id))
+			continue
+		}
+		seen[id] = true
+	}
 }
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+// ValidateProgram checks a program and its requirements and returns a
+// ValidationError listing all violations, or nil if the program is valid.
+// A course may be required and also belong to elective pools, but it is
+// listed at most once in each.
+func ValidateProgram(program models.Program) error {
+	var v validator
+
+	if strings.TrimSpace(program.Name) == "" {
+		v.add("name", CodeRequired, "program name is required")
+	} else if utf8.RuneCountInString(program.Name) > MaxNameLength {
+		v.add("name", CodeTooLong, fmt.Sprintf("program name must be at most %d characters", MaxNameLength))
+	}
+
+	validateRequirementCourses(&v, "required_courses", program.RequiredCourses)
+
+	names := make(map[string]bool, len(program.ElectivePools))
+	for i, pool := range program.ElectivePools {
+		field := fmt.Sprintf("elective_pools[%d]", i)
+		if strings.TrimSpace(pool.Name) == "" {
+			v.add(field+".name", CodeRequired, "elective pool name is required")
+		} else if utf8.RuneCountInString(pool.Name) > MaxNameLength {
+			v.add(field+".name", CodeTooLong, fmt.Sprintf("elective pool name must be at most %d characters", MaxNameLength))
+		} else if names[pool.Name] {
+			v.add(field+".name", CodeDuplicate, fmt.Sprintf("elective pool %q is listed more than once", pool.Name))
+		}
+		names[pool.Name] = true
+		if pool.MinCredits <= 0 {
+			v.add(field+".min_credits", CodeOutOfRange, "elective pool minimum credits must be a positive number")
+		}
+		if len(pool.Courses) == 0 {
+			v.add(field+".courses", CodeRequired, "elective pool needs at least one course")
+		}
+		validateRequirementCourses(&v, field+".courses", pool.Courses)
+	}
+
+	return v.err()
+}
+
+// validateRequirementCourses rejects course IDs that can never reference a
+// course and IDs listed more than once.
+func validateRequirementCourses(v *validator, field string, courses []int) {
+	seen := make(map[int]bool, len(courses))
+	for i, id := range courses {
+		field := fmt.Sprintf("%s[%d]", field, i)
+		if id <= 0 {
+			v.add(field, CodeInvalid, "course id must be a positive number")
+			continue
+		}
+		if seen[id] {
+			v.add(field, CodeDuplicate, fmt.Sprintf("course id %d is listed more than once", id))
+			continue
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+// ValidateRoom checks every field of a room and returns a ValidationError
+// listing all violations, or nil if the room is valid.
+func ValidateRoom(room models.Room) error {
+	var v validator
+
+	if strings.TrimSpace(room.Building) == "" {
+		v.add("building", CodeRequired, "room building is required")
+	} else if utf8.RuneCountInString(room.Building) > MaxNameLength {
+		v.add("building", CodeTooLong, fmt.Sprintf("room building must be at most %d characters", MaxNameLength))
+	}
+
+	if strings.TrimSpace(room.Number) == "" {
+		v.add("number", CodeRequired, "room number is required")
+	} else if utf8.RuneCountInString(room.Number) > MaxNameLength {
+		v.add("number", CodeTooLong, fmt.Sprintf("room number must be at most %d characters", MaxNameLength))
+	}
+
+	if room.Seats < 1 {
+		v.add("seats", CodeOutOfRange, "room seats must be a positive number")
+	}
+
+	seen := make(map[string]bool, len(room.Features))
+	for i, feature := range room.Features {
+		field := fmt.Sprintf("features[%d]", i)
+		switch {
+		case strings.TrimSpace(feature) == "":
+			v.add(field, CodeRequired, "room feature must not be empty")
+		case utf8.RuneCountInString(feature) > MaxNameLength:
+			v.add(field, CodeTooLong, fmt.Sprintf("room feature must be at most %d characters", MaxNameLength))
+		case seen[feature]:
+			v.add(field, CodeDuplicate, fmt.Sprintf("room feature %s is listed more than once", feature))
+		}
+		seen[feature] = true
+	}
+
+	return v.err()
+}
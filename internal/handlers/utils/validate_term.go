@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+// ValidateTerm checks every field of a term and returns a ValidationError
+// listing all violations, or nil if the term is valid.
+func ValidateTerm(term models.Term) error {
+	var v validator
+
+	if strings.TrimSpace(term.Name) == "" {
+		v.add("name", CodeRequired, "term name is required")
+	} else if utf8.RuneCountInString(term.Name) > MaxNameLength {
+		v.add("name", CodeTooLong, fmt.Sprintf("term name must be at most %d characters", MaxNameLength))
+	}
+
+	if term.StartDate.IsZero() {
+		v.add("start_date", CodeRequired, "term start date is required")
+	}
+	if term.EndDate.IsZero() {
+		v.add("end_date", CodeRequired, "term end date is required")
+	} else if term.EndDate.Before(term.StartDate.Time) {
+		v.add("end_date", CodeOutOfRange, "term end date must not be before its start date")
+	}
+
+	return v.err()
+}
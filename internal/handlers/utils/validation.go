@@ -0,0 +1,48 @@
+package utils
+
+import "strings"
+
+// Violation codes reported by the validators.
+const (
+	CodeRequired   = "required"
+	CodeTooLong    = "too_long"
+	CodeOutOfRange = "out_of_range"
+	CodeInvalid    = "invalid"
+	CodeDuplicate  = "duplicate"
+)
+
+// Violation describes a single field that failed validation. Field is the
+// JSON path of the field, for example "courses[2]".
+type Violation struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError collects every violation found while validating a value.
+type ValidationError []Violation
+
+func (v ValidationError) Error() string {
+	msgs := make([]string, 0, len(v))
+	for _, violation := range v {
+		msgs = append(msgs, violation.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// validator accumulates violations so validation functions can report every
+// problem with a value instead of stopping at the first one.
+type validator struct {
+	violations ValidationError
+}
+
+func (v *validator) add(field, code, message string) {
+	v.violations = append(v.violations, Violation{Field: field, Code: code, Message: message})
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return v.violations
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Advising records that a professor advises a student. Every student has at
+// most one advisor.
+type Advising struct {
+	StudentID  int       `json:"student_id"`
+	AdvisorID  int       `json:"advisor_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Assignment is a piece of work students of a course hand in during a term.
+type Assignment struct {
+	ID        int       `json:"id"`
+	CourseID  int       `json:"course_id"`
+	TermID    *int      `json:"term_id,omitempty"`
+	Title     string    `json:"title"`
+	DueAt     time.Time `json:"due_at"`
+	MaxPoints float64   `json:"max_points"`
+}
+
+// Submission is the work a student handed in for an assignment, either as
+// text, as a reference to a file stored elsewhere, or both. Late is true
+// when it was submitted after the due date of the assignment. Score, ScoredBy
+// and ScoredAt are set once a professor scores it.
+type Submission struct {
+	AssignmentID int        `json:"assignment_id"`
+	PersonID     int        `json:"person_id"`
+	Content      string     `json:"content,omitempty"`
+	FileRef      string     `json:"file_ref,omitempty"`
+	SubmittedAt  time.Time  `json:"submitted_at"`
+	Late         bool       `json:"late"`
+	Score        *float64   `json:"score"`
+	ScoredBy     *int       `json:"scored_by,omitempty"`
+	ScoredAt     *time.Time `json:"scored_at,omitempty"`
+}
+
+// SubmissionScore is a professor scoring a submission to an assignment of
+// a course they teach.
+type SubmissionScore struct {
+	ProfessorID int      `json:"professor_id"`
+	Score       *float64 `json:"score"`
+}
@@ -0,0 +1,76 @@
+package models
+
+// Attendance statuses a student can have at a class session.
+const (
+	AttendancePresent = "present"
+	AttendanceAbsent  = "absent"
+	AttendanceLate    = "late"
+	AttendanceExcused = "excused"
+)
+
+// AttendanceStatuses lists every attendance status.
+var AttendanceStatuses = []string{AttendancePresent, AttendanceAbsent, AttendanceLate, AttendanceExcused}
+
+// ClassSession is a single class of a course in a term, such as the lecture
+// on a given day. Attendance is taken per session.
+type ClassSession struct {
+	ID       int    `json:"id"`
+	CourseID int    `json:"course_id"`
+	TermID   *int   `json:"term_id,omitempty"`
+	Date     Date   `json:"date"`
+	Topic    string `json:"topic,omitempty"`
+}
+
+// AttendanceRecord is the attendance of one student at a class session.
+type AttendanceRecord struct {
+	SessionID int    `json:"session_id"`
+	PersonID  int    `json:"person_id"`
+	Status    string `json:"status"`
+}
+
+// AttendanceSubmission is a professor taking attendance at a session of a
+// course they teach. Students of the course who are not listed in Records
+// get DefaultStatus, if it is set, so a whole roster can be marked present
+// by listing only the exceptions.
+type AttendanceSubmission struct {
+	ProfessorID   int               `json:"professor_id"`
+	DefaultStatus string            `json:"default_status,omitempty"`
+	Records       []AttendanceEntry `json:"records"`
+}
+
+// AttendanceEntry is the status of one student in an AttendanceSubmission.
+type AttendanceEntry struct {
+	PersonID int    `json:"person_id"`
+	Status   string `json:"status"`
+}
+
+// AttendanceRate summarizes the attendance of a student in a course in a
+// term. Rate is the percentage of the sessions with a record, not counting
+// excused absences, that the student attended, late or not. It is nil while
+// no such session has been recorded.
+type AttendanceRate struct {
+	PersonID int      `json:"person_id"`
+	CourseID int      `json:"course_id"`
+	TermID   *int     `json:"term_id,omitempty"`
+	Present  int      `json:"present"`
+	Late     int      `json:"late"`
+	Absent   int      `json:"absent"`
+	Excused  int      `json:"excused"`
+	Rate     *float64 `json:"rate"`
+}
+
+// CourseAttendance is the attendance report of a course: the rate of each
+// student and the rate of the course as a whole.
+type CourseAttendance struct {
+	CourseID int              `json:"course_id"`
+	Rate     *float64         `json:"rate"`
+	Students []AttendanceRate `json:"students"`
+}
+
+// PersonAttendance is the attendance report of a student: the rate in each
+// of their courses and their rate over all of them.
+type PersonAttendance struct {
+	PersonID int              `json:"person_id"`
+	Rate     *float64         `json:"rate"`
+	Courses  []AttendanceRate `json:"courses"`
+}
@@ -1,6 +1,85 @@
 package models
 
 type Course struct {
-	ID   int    `json:"id"`
+	ID int `json:"id"`
+	// Code identifies the course to people, for example CS-101. Codes are
+	// unique.
+	Code string `json:"code"`
 	Name string `json:"name"`
+	// DepartmentID is the department offering the course, if any.
+	DepartmentID *int `json:"department_id,omitempty"`
+	// Capacity is the number of students the course can take. A nil
+	// Capacity means the course is unlimited.
+	Capacity *int `json:"capacity,omitempty"`
+	// Credits is the number of credit hours the course is worth.
+	Credits int `json:"credits"`
+	// Meetings is when and where the course meets each week.
+	Meetings []Meeting `json:"meetings,omitempty"`
+	// Instructors is the teaching staff of the course in the current term,
+	// including teaching assistants.
+	Instructors []Instructor `json:"instructors,omitempty"`
+	// RoomWarnings lists the rooms the course is booked into that are too
+	// small for the students taking it in the current term.
+	RoomWarnings []RoomWarning `json:"room_warnings,omitempty"`
+}
+
+// Instructor is a person teaching a course and their role in it.
+type Instructor struct {
+	PersonID  int    `json:"person_id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+}
+
+// Weekdays are the days a Meeting can be on, in week order.
+var Weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// TimeLayout is the format of a Meeting's start and end times.
+const TimeLayout = "15:04"
+
+// Meeting is a weekly meeting pattern of a course, for example lectures on
+// Monday and Wednesday from 09:00 to 10:30. Times are HH:MM and the end time
+// is exclusive, so back-to-back meetings do not overlap.
+type Meeting struct {
+	Days      []string `json:"days"`
+	StartTime string   `json:"start_time"`
+	EndTime   string   `json:"end_time"`
+	Location  string   `json:"location,omitempty"`
+	// RoomID is the room booked for the meeting, if any. A room cannot be
+	// booked for two overlapping meetings.
+	RoomID *int `json:"room_id,omitempty"`
+}
+
+// ScheduleConflict is a meeting of a course a person already takes that
+// overlaps the course they are enrolling in.
+type ScheduleConflict struct {
+	CourseID   int     `json:"course_id"`
+	CourseName string  `json:"course_name"`
+	Meeting    Meeting `json:"meeting"`
+}
+
+// CourseWithPeople is a Course with the people enrolled in it. It is
+// returned when a client asks for ?expand=people.
+type CourseWithPeople struct {
+	Course
+	People []Person `json:"people"`
+}
+
+// Roster lists the people enrolled in a course, the teaching staff apart
+// from the students. Professors holds everyone with a teaching role in the
+// course, including students who assist in it, and Students everyone taking
+// it as a student.
+type Roster struct {
+	CourseID       int           `json:"course_id"`
+	Professors     []RosterEntry `json:"professors"`
+	Students       []RosterEntry `json:"students"`
+	ProfessorCount int           `json:"professor_count"`
+	StudentCount   int           `json:"student_count"`
+}
+
+// RosterEntry is a person on a course roster and the role they hold in the
+// course.
+type RosterEntry struct {
+	Person
+	Role string `json:"role"`
 }
@@ -0,0 +1,10 @@
+package models
+
+// Department is an academic department offering courses. Code is a short
+// unique abbreviation, such as CS, usually used as the prefix of the codes
+// of its courses.
+type Department struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
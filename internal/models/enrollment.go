@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Enrollment statuses.
+const (
+	EnrollmentStatusEnrolled   = "enrolled"
+	EnrollmentStatusWaitlisted = "waitlisted"
+)
+
+// Roles a person can hold in a course. Instructors and co-instructors are
+// professors and students take the course as students; teaching assistants
+// can be either.
+const (
+	RoleInstructor        = "instructor"
+	RoleCoInstructor      = "co_instructor"
+	RoleTeachingAssistant = "teaching_assistant"
+	RoleStudent           = "student"
+)
+
+// Roles are the roles a person can hold in a course, teaching roles first.
+var Roles = []string{RoleInstructor, RoleCoInstructor, RoleTeachingAssistant, RoleStudent}
+
+// Enrollment records that a person takes or teaches a course, or, when
+// Status is waitlisted, that they are waiting for a seat in it. Role is the
+// part they play in the course. TermID is the term the course is taken in,
+// or nil for enrollments not tied to a term. For waitlisted people
+// EnrolledAt is when they joined the waitlist and WaitlistPosition is their
+// place in the term's waitlist, starting at 1.
+type Enrollment struct {
+	PersonID         int       `json:"person_id"`
+	CourseID         int       `json:"course_id"`
+	TermID           *int      `json:"term_id,omitempty"`
+	Role             string    `json:"role"`
+	Status           string    `json:"status"`
+	EnrolledAt       time.Time `json:"enrolled_at"`
+	WaitlistPosition int       `json:"waitlist_position,omitempty"`
+}
@@ -0,0 +1,61 @@
+package models
+
+// GradeScaleEntry is a letter grade of the grading scale and the grade
+// points it is worth. A nil Points marks a letter, such as W for withdrawn,
+// that does not count towards a GPA.
+type GradeScaleEntry struct {
+	Letter string   `json:"letter"`
+	Points *float64 `json:"points"`
+}
+
+// Grade is the outcome of an enrollment. Grade is nil until one is posted.
+// GradePoints is copied from the grading scale when the grade is posted, so
+// later changes to the scale do not rewrite past grades.
+type Grade struct {
+	PersonID    int      `json:"person_id"`
+	CourseID    int      `json:"course_id"`
+	TermID      *int     `json:"term_id,omitempty"`
+	Grade       *string  `json:"grade"`
+	GradePoints *float64 `json:"grade_points"`
+}
+
+// GradeSubmission is a professor posting grades for students of a course
+// they teach in a term.
+type GradeSubmission struct {
+	ProfessorID int          `json:"professor_id"`
+	TermID      *int         `json:"term_id,omitempty"`
+	Grades      []GradeEntry `json:"grades"`
+}
+
+// GradeEntry is the letter grade of one student in a GradeSubmission.
+type GradeEntry struct {
+	PersonID int    `json:"person_id"`
+	Grade    string `json:"grade"`
+}
+
+// Transcript is a person's courses grouped by term, oldest first, with
+// enrollments not tied to a term at the start. GPAs are averages of the
+// grade points of graded courses weighted by their credits and are nil
+// while nothing counts towards them.
+type Transcript struct {
+	PersonID      int              `json:"person_id"`
+	Terms         []TranscriptTerm `json:"terms"`
+	CumulativeGPA *float64         `json:"cumulative_gpa"`
+}
+
+// TranscriptTerm is one term of a Transcript.
+type TranscriptTerm struct {
+	TermID   *int               `json:"term_id,omitempty"`
+	TermName string             `json:"term_name,omitempty"`
+	Courses  []TranscriptCourse `json:"courses"`
+	GPA      *float64           `json:"gpa"`
+}
+
+// TranscriptCourse is one course of a TranscriptTerm.
+type TranscriptCourse struct {
+	CourseID    int      `json:"course_id"`
+	CourseName  string   `json:"course_name"`
+	Credits     int      `json:"credits"`
+	Grade       *string  `json:"grade"`
+	GradePoints *float64 `json:"grade_points"`
+}
@@ -7,4 +7,18 @@ type Person struct {
 	Type      string  `json:"type"`
 	Age       int     `json:"age"`
 	Courses   []int64 `json:"courses"`
+	// MaxCredits is the credit load limit of a student. A nil MaxCredits
+	// means the default limit applies.
+	MaxCredits *int `json:"max_credits,omitempty"`
+	// Credits is the total credit hours of the courses the person takes in
+	// the current term, including courses not tied to a term. It is computed
+	// and ignored on input.
+	Credits int `json:"credits"`
+}
+
+// PersonWithCourses is a Person with its courses embedded in full rather
+// than as IDs. It is returned when a client asks for ?expand=courses.
+type PersonWithCourses struct {
+	Person
+	Courses []Course `json:"courses"`
 }
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// Program is a degree program: the courses a student must complete to
+// graduate and the elective pools they must earn credits from.
+type Program struct {
+	ID              int            `json:"id"`
+	Name            string         `json:"name"`
+	RequiredCourses []int          `json:"required_courses"`
+	ElectivePools   []ElectivePool `json:"elective_pools"`
+}
+
+// ElectivePool is a set of courses a student picks from until they have
+// earned at least MinCredits credit hours in them.
+type ElectivePool struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name"`
+	MinCredits int    `json:"min_credits"`
+	Courses    []int  `json:"courses"`
+}
+
+// ProgramDeclaration is a student declaring the program they study.
+type ProgramDeclaration struct {
+	PersonID   int       `json:"person_id"`
+	ProgramID  int       `json:"program_id"`
+	DeclaredAt time.Time `json:"declared_at"`
+}
+
+// Statuses of a course in a DegreeAudit.
+const (
+	AuditCompleted   = "completed"
+	AuditInProgress  = "in_progress"
+	AuditOutstanding = "outstanding"
+)
+
+// DegreeAudit compares the courses a student completed with the
+// requirements of their program. Complete is true once every required
+// course and every elective pool is satisfied.
+type DegreeAudit struct {
+	PersonID        int           `json:"person_id"`
+	ProgramID       int           `json:"program_id"`
+	ProgramName     string        `json:"program_name"`
+	Complete        bool          `json:"complete"`
+	RequiredCourses []AuditCourse `json:"required_courses"`
+	ElectivePools   []PoolAudit   `json:"elective_pools"`
+}
+
+// AuditCourse is a course of a DegreeAudit and whether the student has
+// completed it, is taking it or still has to take it.
+type AuditCourse struct {
+	CourseID int    `json:"course_id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Credits  int    `json:"credits"`
+	Status   string `json:"status"`
+}
+
+// PoolAudit is an elective pool of a DegreeAudit. Counted lists the
+// completed courses whose credits count towards the pool and, while the
+// pool is not satisfied, Remaining lists the courses still available to
+// the student.
+type PoolAudit struct {
+	PoolID           int           `json:"pool_id"`
+	Name             string        `json:"name"`
+	MinCredits       int           `json:"min_credits"`
+	EarnedCredits    int           `json:"earned_credits"`
+	RemainingCredits int           `json:"remaining_credits"`
+	Satisfied        bool          `json:"satisfied"`
+	Counted          []AuditCourse `json:"counted"`
+	Remaining        []AuditCourse `json:"remaining"`
+}
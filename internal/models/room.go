@@ -0,0 +1,31 @@
+package models
+
+// Room is a room courses can be booked into. Seats is how many people it
+// seats and Features lists its equipment, such as projector or whiteboard.
+type Room struct {
+	ID       int      `json:"id"`
+	Building string   `json:"building"`
+	Number   string   `json:"number"`
+	Seats    int      `json:"seats"`
+	Features []string `json:"features"`
+}
+
+// RoomBooking is a meeting of a course held in a room. Enrolled counts the
+// students taking the course in the current term, and OverCapacity is set
+// when they do not all fit in the room.
+type RoomBooking struct {
+	CourseID     int     `json:"course_id"`
+	CourseCode   string  `json:"course_code"`
+	CourseName   string  `json:"course_name"`
+	Meeting      Meeting `json:"meeting"`
+	Enrolled     int     `json:"enrolled"`
+	OverCapacity bool    `json:"over_capacity"`
+}
+
+// RoomWarning warns that more students take a course in the current term
+// than a room it is booked into seats.
+type RoomWarning struct {
+	RoomID   int `json:"room_id"`
+	Seats    int `json:"seats"`
+	Enrolled int `json:"enrolled"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Term is an academic term, such as a semester, during which courses are
+// taught. Both dates are inclusive.
+type Term struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	StartDate Date   `json:"start_date"`
+	EndDate   Date   `json:"end_date"`
+}
+
+// DateLayout is the format of a Date in JSON.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day. It is encoded in JSON as
+// YYYY-MM-DD and stored in DATE columns.
+type Date struct {
+	time.Time
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Format(DateLayout))
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*d = Date{}
+		return nil
+	}
+	t, err := time.Parse(DateLayout, *s)
+	if err != nil {
+		return fmt.Errorf("date must be formatted as YYYY-MM-DD: %w", err)
+	}
+	*d = Date{t}
+	return nil
+}
+
+func (d *Date) Scan(src any) error {
+	t, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a date", src)
+	}
+	*d = Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	return d.Format(DateLayout), nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+// GetAdvisor returns the advisor of the student with the given ID. Students
+// without an advisor give ErrNotFound, as do unknown people.
+func (p PersonService) GetAdvisor(ctx context.Context, studentID int) (models.Person, error) {
+	row := p.Database.QueryRowContext(ctx, personSelect+`
+	WHERE p.id = (SELECT advisor_id FROM student_advisor WHERE student_id = $1)
+	GROUP BY id, first_name, last_name, type, age;
+	`, studentID)
+	var advisor models.Person
+	if err := row.Scan(&advisor.ID, &advisor.FirstName, &advisor.LastName, &advisor.Type, &advisor.Age, pq.Array(&advisor.Courses), &advisor.MaxCredits, &advisor.Credits); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Person{}, fmt.Errorf("[in services.GetAdvisor] person %d has no advisor: %w", studentID, classify(err))
+		}
+		return models.Person{}, fmt.Errorf("[in services.GetAdvisor] failed to scan advisor: %w", classify(err))
+	}
+	return advisor, nil
+}
+
+// GetAdvisees returns the students advised by the professor with the given
+// ID, ordered by ID. It fails with ErrNotFound unless the ID is that of a
+// professor.
+func (p PersonService) GetAdvisees(ctx context.Context, professorID int) ([]models.Person, error) {
+	var isProfessor bool
+	err := p.Database.QueryRowContext(ctx, `
+	SELECT EXISTS(SELECT 1 FROM person WHERE id = $1 AND type = 'professor')
+	`, professorID).Scan(&isProfessor)
+	if err != nil {
+		return []models.Person{}, fmt.Errorf("[in services.GetAdvisees] failed to check professor: %w", classify(err))
+	}
+	if !isProfessor {
+		return []models.Person{}, fmt.Errorf("[in services.GetAdvisees] professor with ID %d does not exist: %w", professorID, ErrNotFound)
+	}
+
+	rows, err := p.Database.QueryContext(ctx, personSelect+`
+	JOIN student_advisor sa ON sa.student_id = p.id
+	WHERE sa.advisor_id = $1
+	GROUP BY id, first_name, last_name, type, age
+	ORDER BY p.id;
+	`, professorID)
+	if err != nil {
+		return []models.Person{}, fmt.Errorf("[in services.GetAdvisees] failed to get advisees: %w", classify(err))
+	}
+	defer rows.Close()
+
+	advisees := []models.Person{}
+	for rows.Next() {
+		var advisee models.Person
+		if err := rows.Scan(&advisee.ID, &advisee.FirstName, &advisee.LastName, &advisee.Type, &advisee.Age, pq.Array(&advisee.Courses), &advisee.MaxCredits, &advisee.Credits); err != nil {
+			return []models.Person{}, fmt.Errorf("[in services.GetAdvisees] failed to scan advisee: %w", classify(err))
+		}
+		advisees = append(advisees, advisee)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Person{}, fmt.Errorf("[in services.GetAdvisees] failed to scan advisees: %w", classify(err))
+	}
+	return advisees, nil
+}
+
+// AssignAdvisor makes the professor with ID advisorID the advisor of the
+// student with ID studentID, replacing any advisor they had. An unknown
+// student gives ErrNotFound and an unknown advisor ErrInvalidReference;
+// advising someone who is not a student, or by someone who is not a
+// professor, is a constraint violation. Both people are locked until the
+// advisor is assigned, so neither can change type in between.
+func (p PersonService) AssignAdvisor(ctx context.Context, studentID, advisorID int) (models.Advising, error) {
+	tx, err := p.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] failed to start transaction: %w", classify(err))
+	}
+
+	var studentType, advisorType sql.NullString
+	err = tx.QueryRowContext(ctx, `
+	SELECT (SELECT type FROM person WHERE id = $1 FOR SHARE), (SELECT type FROM person WHERE id = $2 FOR SHARE)
+	`, studentID, advisorID).Scan(&studentType, &advisorType)
+	if err != nil {
+		tx.Rollback()
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] failed to get people: %w", classify(err))
+	}
+	switch {
+	case !studentType.Valid:
+		tx.Rollback()
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] person with ID %d does not exist: %w", studentID, ErrNotFound)
+	case !advisorType.Valid:
+		tx.Rollback()
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] advisor with ID %d does not exist: %w", advisorID, ErrInvalidReference)
+	case studentType.String != "student":
+		tx.Rollback()
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] person %d is not a student: %w", studentID, ErrConstraintViolation)
+	case advisorType.String != "professor":
+		tx.Rollback()
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] person %d is not a professor: %w", advisorID, ErrConstraintViolation)
+	}
+
+	advising := models.Advising{StudentID: studentID, AdvisorID: advisorID}
+	err = tx.QueryRowContext(ctx, `
+	INSERT INTO student_advisor (student_id, advisor_id)
+	VALUES ($1, $2)
+	ON CONFLICT (student_id) DO UPDATE
+	SET advisor_id = EXCLUDED.advisor_id, assigned_at = now()
+	RETURNING assigned_at
+	`, studentID, advisorID).Scan(&advising.AssignedAt)
+	if err != nil {
+		tx.Rollback()
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] failed to assign advisor: %w", classify(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Advising{}, fmt.Errorf("[in services.AssignAdvisor] failed to commit transaction: %w", classify(err))
+	}
+	return advising, nil
+}
+
+// RemoveAdvisor removes the advisor of the student with the given ID. It
+// fails with ErrNotFound if the student has no advisor.
+func (p PersonService) RemoveAdvisor(ctx context.Context, studentID int) error {
+	result, err := p.Database.ExecContext(ctx, `
+	DELETE FROM student_advisor
+	WHERE student_id = $1
+	`, studentID)
+	if err != nil {
+		return fmt.Errorf("[in services.RemoveAdvisor] failed to remove advisor: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.RemoveAdvisor] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.RemoveAdvisor] person %d has no advisor: %w", studentID, ErrNotFound)
+	}
+	return nil
+}
+
+// checkAdvising fails with ErrConstraintViolation if the person would keep
+// advising students without being a professor, or keep an advisor without
+// being a student, once their type is personType.
+func checkAdvising(ctx context.Context, tx *sql.Tx, personID int, personType string) error {
+	if personType != "professor" {
+		advisees, err := queryIDs(ctx, tx, `SELECT student_id FROM student_advisor WHERE advisor_id = $1 ORDER BY student_id`, personID)
+		if err != nil {
+			return fmt.Errorf("failed to get advisees: %w", err)
+		}
+		if len(advisees) > 0 {
+			return fmt.Errorf("person %d advises %d students and must stay a professor: %w", personID, len(advisees), ErrConstraintViolation)
+		}
+	}
+	if personType != "student" {
+		var advised bool
+		err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM student_advisor WHERE student_id = $1)
+		`, personID).Scan(&advised)
+		if err != nil {
+			return fmt.Errorf("failed to check advisor: %w", classify(err))
+		}
+		if advised {
+			return fmt.Errorf("person %d has an advisor and must stay a student: %w", personID, ErrConstraintViolation)
+		}
+	}
+	return nil
+}
+
+// releaseAdvisees hands the advisees of the person about to be deleted to the
+// professor with ID reassignTo, or fails with an AdviseesError when they
+// have advisees and reassignTo is zero. Their own advisor is removed.
+func releaseAdvisees(ctx context.Context, tx *sql.Tx, personID, reassignTo int) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM student_advisor WHERE student_id = $1`, personID); err != nil {
+		return fmt.Errorf("failed to remove advisor: %w", classify(err))
+	}
+
+	if reassignTo == 0 {
+		advisees, err := queryIDs(ctx, tx, `SELECT student_id FROM student_advisor WHERE advisor_id = $1 ORDER BY student_id`, personID)
+		if err != nil {
+			return fmt.Errorf("failed to get advisees: %w", err)
+		}
+		if len(advisees) > 0 {
+			return fmt.Errorf("cannot delete advisor: %w", &AdviseesError{ProfessorID: personID, Advisees: advisees})
+		}
+		return nil
+	}
+
+	var isProfessor bool
+	err := tx.QueryRowContext(ctx, `
+	SELECT EXISTS(SELECT 1 FROM person WHERE id = $1 AND id <> $2 AND type = 'professor')
+	`, reassignTo, personID).Scan(&isProfessor)
+	if err != nil {
+		return fmt.Errorf("failed to check new advisor: %w", classify(err))
+	}
+	if !isProfessor {
+		return fmt.Errorf("advisees cannot be reassigned to person %d, who is not another professor: %w", reassignTo, ErrInvalidReference)
+	}
+	_, err = tx.ExecContext(ctx, `
+	UPDATE student_advisor
+	SET advisor_id = $2, assigned_at = now()
+	WHERE advisor_id = $1
+	`, personID, reassignTo)
+	if err != nil {
+		return fmt.Errorf("failed to reassign advisees: %w", classify(err))
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var personRowColumns = []string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}
+
+// expectNoAdvisees expects the person about to be deleted to lose their own
+// advisor and to advise no students.
+func expectNoAdvisees(mock sqlmock.Sqlmock, personID int) {
+	mock.ExpectExec(`DELETE FROM student_advisor WHERE student_id = \$1`).
+		WithArgs(personID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT student_id FROM student_advisor WHERE advisor_id = \$1`).
+		WithArgs(personID).
+		WillReturnRows(sqlmock.NewRows([]string{"student_id"}))
+}
+
+func TestGetAdvisor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT p.id, .* FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE p.id = \(SELECT advisor_id FROM student_advisor WHERE student_id = \$1\)`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(personRowColumns).
+				AddRow(1, "Steve", "Jobs", "professor", 56, pq.Array([]int64{1, 2}), nil, 0))
+
+		advisor, err := service.GetAdvisor(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, models.Person{ID: 1, FirstName: "Steve", LastName: "Jobs", Type: "professor", Age: 56, Courses: []int64{1, 2}}, advisor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Advisor", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`WHERE p.id = \(SELECT advisor_id FROM student_advisor WHERE student_id = \$1\)`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(personRowColumns))
+
+		_, err := service.GetAdvisor(ctx, 3)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Contains(t, err.Error(), "has no advisor")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAdvisees(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM person WHERE id = \$1 AND type = 'professor'\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`JOIN student_advisor sa ON sa.student_id = p.id WHERE sa.advisor_id = \$1 GROUP BY id, first_name, last_name, type, age ORDER BY p.id`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(personRowColumns).
+				AddRow(3, "Larry", "Page", "student", 51, pq.Array([]int64{1}), nil, 3).
+				AddRow(4, "Bill", "Gates", "student", 67, pq.Array([]int64{}), 12, 0))
+
+		advisees, err := service.GetAdvisees(ctx, 1)
+		assert.NoError(t, err)
+		maxCredits := 12
+		assert.Equal(t, []models.Person{
+			{ID: 3, FirstName: "Larry", LastName: "Page", Type: "student", Age: 51, Courses: []int64{1}, Credits: 3},
+			{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 67, Courses: []int64{}, MaxCredits: &maxCredits},
+		}, advisees)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not A Professor", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM person WHERE id = \$1 AND type = 'professor'\)`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.GetAdvisees(ctx, 3)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAssignAdvisor(t *testing.T) {
+	ctx := context.Background()
+	assignedAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		studentType any
+		advisorType any
+		expectedErr error
+	}{
+		{name: "Success", studentType: "student", advisorType: "professor"},
+		{name: "Unknown Student", studentType: nil, advisorType: "professor", expectedErr: services.ErrNotFound},
+		{name: "Unknown Advisor", studentType: "student", advisorType: nil, expectedErr: services.ErrInvalidReference},
+		{name: "Person Not A Student", studentType: "professor", advisorType: "professor", expectedErr: services.ErrConstraintViolation},
+		{name: "Advisor Not A Professor", studentType: "student", advisorType: "student", expectedErr: services.ErrConstraintViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockPersonService(t)
+			defer service.Database.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT \(SELECT type FROM person WHERE id = \$1 FOR SHARE\), \(SELECT type FROM person WHERE id = \$2 FOR SHARE\)`).
+				WithArgs(3, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"student_type", "advisor_type"}).AddRow(tt.studentType, tt.advisorType))
+			if tt.expectedErr == nil {
+				mock.ExpectQuery(`INSERT INTO student_advisor \(student_id, advisor_id\) VALUES \(\$1, \$2\) ON CONFLICT \(student_id\) DO UPDATE SET advisor_id = EXCLUDED.advisor_id, assigned_at = now\(\) RETURNING assigned_at`).
+					WithArgs(3, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"assigned_at"}).AddRow(assignedAt))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			advising, err := service.AssignAdvisor(ctx, 3, 1)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.Advising{StudentID: 3, AdvisorID: 1, AssignedAt: assignedAt}, advising)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRemoveAdvisor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`DELETE FROM student_advisor WHERE student_id = \$1`).
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, service.RemoveAdvisor(ctx, 3))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Advisor", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`DELETE FROM student_advisor WHERE student_id = \$1`).
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, service.RemoveAdvisor(ctx, 3), services.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeletePersonWithAdvisees(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Blocked", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM student_advisor WHERE student_id = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT student_id FROM student_advisor WHERE advisor_id = \$1`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"student_id"}).AddRow(3).AddRow(4))
+		mock.ExpectRollback()
+
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
+		assert.ErrorIs(t, err, services.ErrConflict)
+		var advisees *services.AdviseesError
+		if assert.ErrorAs(t, err, &advisees) {
+			assert.Equal(t, []int{3, 4}, advisees.Advisees)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Reassigned", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM student_advisor WHERE student_id = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM person WHERE id = \$1 AND id <> \$2 AND type = 'professor'\)`).
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec(`UPDATE student_advisor SET advisor_id = \$2, assigned_at = now\(\) WHERE advisor_id = \$1`).
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM "course_waitlist" WHERE "person_id" = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`DELETE FROM "person_course" WHERE "person_id" = \$1 RETURNING "course_id"`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}))
+		mock.ExpectExec(`DELETE FROM "person" WHERE "id" = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{ReassignAdviseesTo: 2})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Reassigned To Non Professor", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM student_advisor WHERE student_id = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM person WHERE id = \$1 AND id <> \$2 AND type = 'professor'\)`).
+			WithArgs(3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{ReassignAdviseesTo: 3})
+		assert.ErrorIs(t, err, services.ErrInvalidReference)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Failed To Get Advisees", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM student_advisor WHERE student_id = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT student_id FROM student_advisor WHERE advisor_id = \$1`).
+			WithArgs(1).
+			WillReturnError(fmt.Errorf("connection lost"))
+		mock.ExpectRollback()
+
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
+		assert.ErrorContains(t, err, "failed to get advisees")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
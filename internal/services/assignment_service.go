@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+type AssignmentService struct {
+	Database *sql.DB
+}
+
+func NewAssignmentService(db *sql.DB) *AssignmentService {
+	return &AssignmentService{
+		Database: db,
+	}
+}
+
+// submissionColumns selects a models.Submission from submission s joined
+// with its assignment a. A submission is late when it was handed in after
+// the due date, so moving the due date updates the late flags.
+const submissionColumns = `s.assignment_id, s.person_id, s.content, s.file_ref, s.submitted_at,
+	s.submitted_at > a.due_at, s.score, s.scored_by, s.scored_at`
+
+func scanSubmission(scan func(dest ...any) error) (models.Submission, error) {
+	var submission models.Submission
+	err := scan(&submission.AssignmentID, &submission.PersonID, &submission.Content, &submission.FileRef, &submission.SubmittedAt,
+		&submission.Late, &submission.Score, &submission.ScoredBy, &submission.ScoredAt)
+	return submission, err
+}
+
+// GetAssignments returns the assignments of the course in the terms selected
+// by term, ordered by due date.
+func (s AssignmentService) GetAssignments(ctx context.Context, courseID int, term TermScope) ([]models.Assignment, error) {
+	var exists bool
+	err := s.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
+    `, courseID).Scan(&exists)
+	if err != nil {
+		return []models.Assignment{}, fmt.Errorf("[in services.GetAssignments] failed to check course existence: %w", classify(err))
+	}
+	if !exists {
+		return []models.Assignment{}, fmt.Errorf("[in services.GetAssignments] course with ID %d does not exist: %w", courseID, ErrNotFound)
+	}
+
+	query := `
+	SELECT id, course_id, term_id, title, due_at, max_points
+		FROM assignment
+		WHERE course_id = $1`
+	cond, args := term.condition("term_id", []interface{}{courseID})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	query += " ORDER BY due_at, id"
+
+	rows, err := s.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []models.Assignment{}, fmt.Errorf("[in services.GetAssignments] failed to get assignments: %w", classify(err))
+	}
+	defer rows.Close()
+
+	assignments := []models.Assignment{}
+	for rows.Next() {
+		var assignment models.Assignment
+		if err := rows.Scan(&assignment.ID, &assignment.CourseID, &assignment.TermID, &assignment.Title, &assignment.DueAt, &assignment.MaxPoints); err != nil {
+			return []models.Assignment{}, fmt.Errorf("[in services.GetAssignments] failed to scan assignment: %w", classify(err))
+		}
+		assignments = append(assignments, assignment)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Assignment{}, fmt.Errorf("[in services.GetAssignments] failed to scan assignments: %w", classify(err))
+	}
+	return assignments, nil
+}
+
+func (s AssignmentService) GetAssignment(ctx context.Context, courseID, assignmentID int) (models.Assignment, error) {
+	var assignment models.Assignment
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT id, course_id, term_id, title, due_at, max_points
+		FROM assignment
+		WHERE id = $1 AND course_id = $2
+	`, assignmentID, courseID).Scan(&assignment.ID, &assignment.CourseID, &assignment.TermID, &assignment.Title, &assignment.DueAt, &assignment.MaxPoints)
+	if err != nil {
+		return models.Assignment{}, fmt.Errorf("[in services.GetAssignment] failed to get assignment %d of course %d: %w", assignmentID, courseID, classify(err))
+	}
+	return assignment, nil
+}
+
+// CreateAssignment adds an assignment to the assignment's course. An unknown
+// course gives ErrNotFound and an unknown term ErrInvalidReference.
+func (s AssignmentService) CreateAssignment(ctx context.Context, assignment models.Assignment) (models.Assignment, error) {
+	var exists bool
+	err := s.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
+    `, assignment.CourseID).Scan(&exists)
+	if err != nil {
+		return models.Assignment{}, fmt.Errorf("[in services.CreateAssignment] failed to check course existence: %w", classify(err))
+	}
+	if !exists {
+		return models.Assignment{}, fmt.Errorf("[in services.CreateAssignment] course with ID %d does not exist: %w", assignment.CourseID, ErrNotFound)
+	}
+
+	err = s.Database.QueryRowContext(ctx, `
+	INSERT INTO assignment (course_id, term_id, title, due_at, max_points)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id
+	`, assignment.CourseID, assignment.TermID, assignment.Title, assignment.DueAt, assignment.MaxPoints).Scan(&assignment.ID)
+	if err != nil {
+		return models.Assignment{}, fmt.Errorf("[in services.CreateAssignment] failed to create assignment: %w", classify(err))
+	}
+	return assignment, nil
+}
+
+// UpdateAssignment changes the title, due date and maximum points of an
+// assignment of the course. Its term cannot change. Moving the due date
+// changes which submissions are late; lowering the maximum points leaves
+// existing scores as they are.
+func (s AssignmentService) UpdateAssignment(ctx context.Context, assignment models.Assignment) (models.Assignment, error) {
+	err := s.Database.QueryRowContext(ctx, `
+	UPDATE assignment
+	SET title = $3, due_at = $4, max_points = $5
+	WHERE id = $1 AND course_id = $2
+	RETURNING term_id
+	`, assignment.ID, assignment.CourseID, assignment.Title, assignment.DueAt, assignment.MaxPoints).Scan(&assignment.TermID)
+	if err != nil {
+		return models.Assignment{}, fmt.Errorf("[in services.UpdateAssignment] failed to update assignment %d of course %d: %w", assignment.ID, assignment.CourseID, classify(err))
+	}
+	return assignment, nil
+}
+
+// DeleteAssignment deletes an assignment of the course along with its
+// submissions.
+func (s AssignmentService) DeleteAssignment(ctx context.Context, courseID, assignmentID int) error {
+	result, err := s.Database.ExecContext(ctx, `
+	DELETE FROM assignment
+	WHERE id = $1 AND course_id = $2
+	`, assignmentID, courseID)
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteAssignment] failed to delete assignment: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteAssignment] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.DeleteAssignment] course %d has no assignment with ID %d: %w", courseID, assignmentID, ErrNotFound)
+	}
+	return nil
+}
+
+// GetSubmissions returns the submissions to an assignment of the course
+// ordered by person.
+func (s AssignmentService) GetSubmissions(ctx context.Context, courseID, assignmentID int) ([]models.Submission, error) {
+	var exists bool
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT EXISTS(SELECT 1 FROM assignment WHERE id = $1 AND course_id = $2)
+	`, assignmentID, courseID).Scan(&exists)
+	if err != nil {
+		return []models.Submission{}, fmt.Errorf("[in services.GetSubmissions] failed to check assignment existence: %w", classify(err))
+	}
+	if !exists {
+		return []models.Submission{}, fmt.Errorf("[in services.GetSubmissions] course %d has no assignment with ID %d: %w", courseID, assignmentID, ErrNotFound)
+	}
+
+	rows, err := s.Database.QueryContext(ctx, `
+	SELECT `+submissionColumns+`
+		FROM submission s
+		JOIN assignment a ON a.id = s.assignment_id
+		WHERE s.assignment_id = $1
+		ORDER BY s.person_id
+	`, assignmentID)
+	if err != nil {
+		return []models.Submission{}, fmt.Errorf("[in services.GetSubmissions] failed to get submissions: %w", classify(err))
+	}
+	defer rows.Close()
+
+	submissions := []models.Submission{}
+	for rows.Next() {
+		submission, err := scanSubmission(rows.Scan)
+		if err != nil {
+			return []models.Submission{}, fmt.Errorf("[in services.GetSubmissions] failed to scan submission: %w", classify(err))
+		}
+		submissions = append(submissions, submission)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Submission{}, fmt.Errorf("[in services.GetSubmissions] failed to scan submissions: %w", classify(err))
+	}
+	return submissions, nil
+}
+
+// Submit hands in the work of a student for an assignment of the course,
+// stamped with the current time. Only students of the course in the
+// assignment's term may submit; anyone else gets ErrForbidden. Submitting
+// again replaces the earlier work until it has been scored, after which
+// the submission is final and resubmitting is a conflict.
+func (s AssignmentService) Submit(ctx context.Context, courseID, assignmentID int, submission models.Submission) (models.Submission, error) {
+	var termID *int
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT term_id FROM assignment WHERE id = $1 AND course_id = $2
+	`, assignmentID, courseID).Scan(&termID)
+	if err != nil {
+		return models.Submission{}, fmt.Errorf("[in services.Submit] failed to get assignment %d of course %d: %w", assignmentID, courseID, classify(err))
+	}
+
+	var enrolled bool
+	err = s.Database.QueryRowContext(ctx, `
+	SELECT EXISTS(
+		SELECT 1 FROM person_course pc
+		WHERE pc.person_id = $1 AND pc.course_id = $2 AND pc.term_id IS NOT DISTINCT FROM $3
+		AND pc.role = 'student'
+	)
+	`, submission.PersonID, courseID, termID).Scan(&enrolled)
+	if err != nil {
+		return models.Submission{}, fmt.Errorf("[in services.Submit] failed to check enrollment: %w", classify(err))
+	}
+	if !enrolled {
+		return models.Submission{}, fmt.Errorf("[in services.Submit] person %d is not a student of course %d in the term: %w", submission.PersonID, courseID, ErrForbidden)
+	}
+
+	submission, err = scanSubmission(s.Database.QueryRowContext(ctx, `
+	WITH s AS (
+		INSERT INTO submission (assignment_id, person_id, content, file_ref)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (assignment_id, person_id) DO UPDATE
+		SET content = EXCLUDED.content, file_ref = EXCLUDED.file_ref, submitted_at = now()
+		WHERE submission.score IS NULL
+		RETURNING *
+	)
+	SELECT `+submissionColumns+`
+		FROM s
+		JOIN assignment a ON a.id = s.assignment_id
+	`, assignmentID, submission.PersonID, submission.Content, submission.FileRef).Scan)
+	if err != nil {
+		err = classify(err)
+		// No row comes back when the upsert skipped an already scored
+		// submission.
+		if errors.Is(err, ErrNotFound) {
+			err = withKind(ErrConflict, fmt.Errorf("submission of person %d has already been scored", submission.PersonID))
+		}
+		return models.Submission{}, fmt.Errorf("[in services.Submit] failed to save submission: %w", err)
+	}
+	return submission, nil
+}
+
+// ScoreSubmission scores the submission of a student to an assignment of
+// the course. Only a professor who is an instructor or co-instructor of the
+// course in the assignment's term may score; anyone else, including teaching
+// assistants, gets ErrForbidden. Scores above the maximum points of the
+// assignment are a constraint violation.
+func (s AssignmentService) ScoreSubmission(ctx context.Context, courseID, assignmentID, personID int, score models.SubmissionScore) (models.Submission, error) {
+	var termID *int
+	var maxPoints float64
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT term_id, max_points FROM assignment WHERE id = $1 AND course_id = $2
+	`, assignmentID, courseID).Scan(&termID, &maxPoints)
+	if err != nil {
+		return models.Submission{}, fmt.Errorf("[in services.ScoreSubmission] failed to get assignment %d of course %d: %w", assignmentID, courseID, classify(err))
+	}
+
+	var teaches bool
+	err = s.Database.QueryRowContext(ctx, `
+	SELECT EXISTS(
+		SELECT 1 FROM person_course pc
+		JOIN person p ON p.id = pc.person_id
+		WHERE pc.person_id = $1 AND pc.course_id = $2 AND pc.term_id IS NOT DISTINCT FROM $3
+		AND pc.role IN ('instructor', 'co_instructor') AND p.type = 'professor'
+	)
+	`, score.ProfessorID, courseID, termID).Scan(&teaches)
+	if err != nil {
+		return models.Submission{}, fmt.Errorf("[in services.ScoreSubmission] failed to check professor: %w", classify(err))
+	}
+	if !teaches {
+		return models.Submission{}, fmt.Errorf("[in services.ScoreSubmission] person %d does not teach course %d in the term: %w", score.ProfessorID, courseID, ErrForbidden)
+	}
+	if *score.Score > maxPoints {
+		return models.Submission{}, fmt.Errorf("[in services.ScoreSubmission] score %g is above the %g points of assignment %d: %w", *score.Score, maxPoints, assignmentID, ErrConstraintViolation)
+	}
+
+	submission, err := scanSubmission(s.Database.QueryRowContext(ctx, `
+	WITH s AS (
+		UPDATE submission
+		SET score = $3, scored_by = $4, scored_at = now()
+		WHERE assignment_id = $1 AND person_id = $2
+		RETURNING *
+	)
+	SELECT `+submissionColumns+`
+		FROM s
+		JOIN assignment a ON a.id = s.assignment_id
+	`, assignmentID, personID, *score.Score, score.ProfessorID).Scan)
+	if err != nil {
+		return models.Submission{}, fmt.Errorf("[in services.ScoreSubmission] failed to score submission of person %d: %w", personID, classify(err))
+	}
+	return submission, nil
+}
@@ -0,0 +1,274 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/stretchr/testify/require"
+)
+
+var submissionRowColumns = []string{"assignment_id", "person_id", "content", "file_ref", "submitted_at", "late", "score", "scored_by", "scored_at"}
+
+func TestNewAssignmentService(t *testing.T) {
+	var mockDB *sql.DB
+
+	assignmentService := services.NewAssignmentService(mockDB)
+
+	require.NotNil(t, assignmentService)
+	require.Equal(t, mockDB, assignmentService.Database)
+}
+
+func TestGetAssignments(t *testing.T) {
+	service, mock := newMockAssignmentService(t)
+	defer service.Database.Close()
+
+	due := time.Date(2026, 9, 14, 23, 59, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT id, course_id, term_id, title, due_at, max_points FROM assignment WHERE course_id = \$1 AND term_id = \$2 ORDER BY due_at, id`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "course_id", "term_id", "title", "due_at", "max_points"}).
+			AddRow(1, 1, 2, "Homework 1", due, 100.0))
+
+	assignments, err := service.GetAssignments(context.Background(), 1, services.TermScope{ID: 2})
+	require.NoError(t, err)
+	termID := 2
+	require.Equal(t, []models.Assignment{{ID: 1, CourseID: 1, TermID: &termID, Title: "Homework 1", DueAt: due, MaxPoints: 100}}, assignments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateAssignment(t *testing.T) {
+	termID := 2
+	due := time.Date(2026, 9, 28, 23, 59, 0, 0, time.UTC)
+	assignment := models.Assignment{CourseID: 1, TermID: &termID, Title: "Homework 2", DueAt: due, MaxPoints: 100}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`INSERT INTO assignment \(course_id, term_id, title, due_at, max_points\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING id`).
+			WithArgs(1, 2, "Homework 2", due, 100.0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+		created, err := service.CreateAssignment(context.Background(), assignment)
+		require.NoError(t, err)
+		require.Equal(t, 4, created.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Course Not Found", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.CreateAssignment(context.Background(), assignment)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteAssignment(t *testing.T) {
+	service, mock := newMockAssignmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectExec(`DELETE FROM assignment WHERE id = \$1 AND course_id = \$2`).
+		WithArgs(9, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := service.DeleteAssignment(context.Background(), 1, 9)
+	require.ErrorIs(t, err, services.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubmissions(t *testing.T) {
+	service, mock := newMockAssignmentService(t)
+	defer service.Database.Close()
+
+	submitted := time.Date(2026, 9, 15, 8, 30, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM assignment WHERE id = \$1 AND course_id = \$2\)`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT s.assignment_id, .* s.submitted_at > a.due_at, .* FROM submission s JOIN assignment a ON a.id = s.assignment_id WHERE s.assignment_id = \$1 ORDER BY s.person_id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(submissionRowColumns).
+			AddRow(1, 4, "", "uploads/hw1-4.pdf", submitted, true, nil, nil, nil))
+
+	submissions, err := service.GetSubmissions(context.Background(), 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []models.Submission{{AssignmentID: 1, PersonID: 4, FileRef: "uploads/hw1-4.pdf", SubmittedAt: submitted, Late: true}}, submissions)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubmit(t *testing.T) {
+	termQuery := `SELECT term_id FROM assignment WHERE id = \$1 AND course_id = \$2`
+	enrolledQuery := `SELECT EXISTS\( SELECT 1 FROM person_course pc WHERE pc.person_id = \$1 AND pc.course_id = \$2 AND pc.term_id IS NOT DISTINCT FROM \$3 AND pc.role = 'student' \)`
+	saveQuery := `WITH s AS \( INSERT INTO submission \(assignment_id, person_id, content, file_ref\) VALUES \(\$1, \$2, \$3, \$4\) ON CONFLICT \(assignment_id, person_id\) DO UPDATE SET content = EXCLUDED.content, file_ref = EXCLUDED.file_ref, submitted_at = now\(\) WHERE submission.score IS NULL RETURNING \* \) SELECT .* FROM s JOIN assignment a ON a.id = s.assignment_id`
+	submission := models.Submission{PersonID: 3, Content: "We propose a course planner."}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		submitted := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(termQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id"}).AddRow(2))
+		mock.ExpectQuery(enrolledQuery).WithArgs(3, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(saveQuery).
+			WithArgs(2, 3, "We propose a course planner.", "").
+			WillReturnRows(sqlmock.NewRows(submissionRowColumns).
+				AddRow(2, 3, "We propose a course planner.", "", submitted, false, nil, nil, nil))
+
+		saved, err := service.Submit(context.Background(), 1, 2, submission)
+		require.NoError(t, err)
+		require.Equal(t, models.Submission{AssignmentID: 2, PersonID: 3, Content: "We propose a course planner.", SubmittedAt: submitted}, saved)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Assignment Not Found", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(termQuery).WithArgs(9, 1).WillReturnError(sql.ErrNoRows)
+
+		_, err := service.Submit(context.Background(), 1, 9, submission)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Enrolled", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(termQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id"}).AddRow(2))
+		mock.ExpectQuery(enrolledQuery).WithArgs(3, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.Submit(context.Background(), 1, 2, submission)
+		require.ErrorIs(t, err, services.ErrForbidden)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Scored", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(termQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id"}).AddRow(2))
+		mock.ExpectQuery(enrolledQuery).WithArgs(3, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(saveQuery).WillReturnRows(sqlmock.NewRows(submissionRowColumns))
+
+		_, err := service.Submit(context.Background(), 1, 2, submission)
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NotErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestScoreSubmission(t *testing.T) {
+	assignmentQuery := `SELECT term_id, max_points FROM assignment WHERE id = \$1 AND course_id = \$2`
+	teachesQuery := `SELECT EXISTS\( SELECT 1 FROM person_course pc JOIN person p ON p.id = pc.person_id WHERE pc.person_id = \$1 AND pc.course_id = \$2 AND pc.term_id IS NOT DISTINCT FROM \$3 AND pc.role IN \('instructor', 'co_instructor'\) AND p.type = 'professor' \)`
+	scoreQuery := `WITH s AS \( UPDATE submission SET score = \$3, scored_by = \$4, scored_at = now\(\) WHERE assignment_id = \$1 AND person_id = \$2 RETURNING \* \)`
+	points := 88.5
+	score := models.SubmissionScore{ProfessorID: 1, Score: &points}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		submitted := time.Date(2026, 9, 15, 8, 30, 0, 0, time.UTC)
+		scored := time.Date(2026, 9, 16, 10, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(assignmentQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id", "max_points"}).AddRow(2, 100.0))
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(scoreQuery).
+			WithArgs(1, 4, 88.5, 1).
+			WillReturnRows(sqlmock.NewRows(submissionRowColumns).
+				AddRow(1, 4, "", "uploads/hw1-4.pdf", submitted, true, 88.5, 1, scored))
+
+		submission, err := service.ScoreSubmission(context.Background(), 1, 1, 4, score)
+		require.NoError(t, err)
+		professor := 1
+		require.Equal(t, models.Submission{
+			AssignmentID: 1,
+			PersonID:     4,
+			FileRef:      "uploads/hw1-4.pdf",
+			SubmittedAt:  submitted,
+			Late:         true,
+			Score:        &points,
+			ScoredBy:     &professor,
+			ScoredAt:     &scored,
+		}, submission)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not The Course Professor", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(assignmentQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id", "max_points"}).AddRow(2, 100.0))
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.ScoreSubmission(context.Background(), 1, 1, 4, score)
+		require.ErrorIs(t, err, services.ErrForbidden)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Above Max Points", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(assignmentQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id", "max_points"}).AddRow(2, 20.0))
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		_, err := service.ScoreSubmission(context.Background(), 1, 2, 4, score)
+		require.ErrorIs(t, err, services.ErrConstraintViolation)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Submitted", func(t *testing.T) {
+		service, mock := newMockAssignmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(assignmentQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id", "max_points"}).AddRow(2, 100.0))
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(scoreQuery).WithArgs(1, 5, 88.5, 1).WillReturnError(sql.ErrNoRows)
+
+		_, err := service.ScoreSubmission(context.Background(), 1, 1, 5, score)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateAssignment(t *testing.T) {
+	service, mock := newMockAssignmentService(t)
+	defer service.Database.Close()
+
+	due := time.Date(2026, 9, 30, 23, 59, 0, 0, time.UTC)
+	mock.ExpectQuery(`UPDATE assignment SET title = \$3, due_at = \$4, max_points = \$5 WHERE id = \$1 AND course_id = \$2 RETURNING term_id`).
+		WithArgs(4, 1, "Homework 2", due, 80.0).
+		WillReturnRows(sqlmock.NewRows([]string{"term_id"}).AddRow(2))
+
+	updated, err := service.UpdateAssignment(context.Background(), models.Assignment{ID: 4, CourseID: 1, Title: "Homework 2", DueAt: due, MaxPoints: 80})
+	require.NoError(t, err)
+	require.Equal(t, 2, *updated.TermID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockAssignmentService(t *testing.T) (services.AssignmentService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.AssignmentService{Database: db}
+
+	return service, mock
+}
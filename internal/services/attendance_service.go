@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+type AttendanceService struct {
+	Database *sql.DB
+}
+
+func NewAttendanceService(db *sql.DB) *AttendanceService {
+	return &AttendanceService{
+		Database: db,
+	}
+}
+
+// GetSessions returns the class sessions of the course in the terms selected
+// by term, ordered by date.
+func (a AttendanceService) GetSessions(ctx context.Context, courseID int, term TermScope) ([]models.ClassSession, error) {
+	var exists bool
+	err := a.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
+    `, courseID).Scan(&exists)
+	if err != nil {
+		return []models.ClassSession{}, fmt.Errorf("[in services.GetSessions] failed to check course existence: %w", classify(err))
+	}
+	if !exists {
+		return []models.ClassSession{}, fmt.Errorf("[in services.GetSessions] course with ID %d does not exist: %w", courseID, ErrNotFound)
+	}
+
+	query := `
+	SELECT id, course_id, term_id, date, topic
+		FROM class_session
+		WHERE course_id = $1`
+	cond, args := term.condition("term_id", []interface{}{courseID})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	query += " ORDER BY date, id"
+
+	rows, err := a.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []models.ClassSession{}, fmt.Errorf("[in services.GetSessions] failed to get sessions: %w", classify(err))
+	}
+	defer rows.Close()
+
+	sessions := []models.ClassSession{}
+	for rows.Next() {
+		var session models.ClassSession
+		if err := rows.Scan(&session.ID, &session.CourseID, &session.TermID, &session.Date, &session.Topic); err != nil {
+			return []models.ClassSession{}, fmt.Errorf("[in services.GetSessions] failed to scan session: %w", classify(err))
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.ClassSession{}, fmt.Errorf("[in services.GetSessions] failed to scan sessions: %w", classify(err))
+	}
+	return sessions, nil
+}
+
+// CreateSession adds a class session to the session's course. An unknown
+// course gives ErrNotFound and an unknown term ErrInvalidReference.
+func (a AttendanceService) CreateSession(ctx context.Context, session models.ClassSession) (models.ClassSession, error) {
+	var exists bool
+	err := a.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
+    `, session.CourseID).Scan(&exists)
+	if err != nil {
+		return models.ClassSession{}, fmt.Errorf("[in services.CreateSession] failed to check course existence: %w", classify(err))
+	}
+	if !exists {
+		return models.ClassSession{}, fmt.Errorf("[in services.CreateSession] course with ID %d does not exist: %w", session.CourseID, ErrNotFound)
+	}
+
+	err = a.Database.QueryRowContext(ctx, `
+	INSERT INTO class_session (course_id, term_id, date, topic)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id
+	`, session.CourseID, session.TermID, session.Date, session.Topic).Scan(&session.ID)
+	if err != nil {
+		return models.ClassSession{}, fmt.Errorf("[in services.CreateSession] failed to create session: %w", classify(err))
+	}
+	return session, nil
+}
+
+// UpdateSession changes the date and topic of a session of the course. The
+// term of a session cannot change, since its attendance was taken from the
+// students of that term.
+func (a AttendanceService) UpdateSession(ctx context.Context, session models.ClassSession) (models.ClassSession, error) {
+	err := a.Database.QueryRowContext(ctx, `
+	UPDATE class_session
+	SET date = $3, topic = $4
+	WHERE id = $1 AND course_id = $2
+	RETURNING term_id
+	`, session.ID, session.CourseID, session.Date, session.Topic).Scan(&session.TermID)
+	if err != nil {
+		return models.ClassSession{}, fmt.Errorf("[in services.UpdateSession] failed to update session %d of course %d: %w", session.ID, session.CourseID, classify(err))
+	}
+	return session, nil
+}
+
+// DeleteSession deletes a session of the course along with its attendance.
+func (a AttendanceService) DeleteSession(ctx context.Context, courseID, sessionID int) error {
+	result, err := a.Database.ExecContext(ctx, `
+	DELETE FROM class_session
+	WHERE id = $1 AND course_id = $2
+	`, sessionID, courseID)
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteSession] failed to delete session: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteSession] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.DeleteSession] course %d has no session with ID %d: %w", courseID, sessionID, ErrNotFound)
+	}
+	return nil
+}
+
+// GetAttendance returns the attendance taken at a session of the course,
+// ordered by person.
+func (a AttendanceService) GetAttendance(ctx context.Context, courseID, sessionID int) ([]models.AttendanceRecord, error) {
+	var exists bool
+	err := a.Database.QueryRowContext(ctx, `
+	SELECT EXISTS(SELECT 1 FROM class_session WHERE id = $1 AND course_id = $2)
+	`, sessionID, courseID).Scan(&exists)
+	if err != nil {
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.GetAttendance] failed to check session existence: %w", classify(err))
+	}
+	if !exists {
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.GetAttendance] course %d has no session with ID %d: %w", courseID, sessionID, ErrNotFound)
+	}
+
+	records, err := queryAttendance(ctx, a.Database, sessionID)
+	if err != nil {
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.GetAttendance] %w", err)
+	}
+	return records, nil
+}
+
+// RecordAttendance stores the attendance of the submission for a session of
+// the course, replacing what was recorded before, and returns the whole
+// attendance of the session. Only a professor who is an instructor or
+// co-instructor of the course in the session's term may take attendance;
+// anyone else, including teaching assistants, gets ErrForbidden. Every listed
+// person must be a student of the course in that term, otherwise nothing is
+// stored and the call fails with ErrInvalidReference.
+func (a AttendanceService) RecordAttendance(ctx context.Context, courseID, sessionID int, submission models.AttendanceSubmission) ([]models.AttendanceRecord, error) {
+	tx, err := a.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] failed to start transaction: %w", classify(err))
+	}
+
+	// Locking the session makes concurrent submissions for it apply one
+	// after the other.
+	var termID *int
+	err = tx.QueryRowContext(ctx, `
+	SELECT term_id FROM class_session WHERE id = $1 AND course_id = $2 FOR UPDATE
+	`, sessionID, courseID).Scan(&termID)
+	if err != nil {
+		tx.Rollback()
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] failed to get session %d of course %d: %w", sessionID, courseID, classify(err))
+	}
+
+	var teaches bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT EXISTS(
+		SELECT 1 FROM person_course pc
+		JOIN person p ON p.id = pc.person_id
+		WHERE pc.person_id = $1 AND pc.course_id = $2 AND pc.term_id IS NOT DISTINCT FROM $3
+		AND pc.role IN ('instructor', 'co_instructor') AND p.type = 'professor'
+	)
+	`, submission.ProfessorID, courseID, termID).Scan(&teaches)
+	if err != nil {
+		tx.Rollback()
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] failed to check professor: %w", classify(err))
+	}
+	if !teaches {
+		tx.Rollback()
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] person %d does not teach course %d in the term: %w", submission.ProfessorID, courseID, ErrForbidden)
+	}
+
+	listed := make([]int, 0, len(submission.Records))
+	for _, entry := range submission.Records {
+		result, err := tx.ExecContext(ctx, `
+		INSERT INTO attendance (session_id, person_id, status)
+		SELECT $1, pc.person_id, $5
+			FROM person_course pc
+			WHERE pc.role = 'student'
+			AND pc.person_id = $2 AND pc.course_id = $3 AND pc.term_id IS NOT DISTINCT FROM $4
+		ON CONFLICT (session_id, person_id) DO UPDATE
+		SET status = EXCLUDED.status
+		`, sessionID, entry.PersonID, courseID, termID, entry.Status)
+		if err != nil {
+			tx.Rollback()
+			return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] failed to record attendance: %w", classify(err))
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] failed to get affected rows: %w", classify(err))
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] person %d is not a student of course %d in the term: %w", entry.PersonID, courseID, ErrInvalidReference)
+		}
+		listed = append(listed, entry.PersonID)
+	}
+
+	if submission.DefaultStatus != "" {
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO attendance (session_id, person_id, status)
+		SELECT $1, pc.person_id, $4
+			FROM person_course pc
+			WHERE pc.role = 'student'
+			AND pc.course_id = $2 AND pc.term_id IS NOT DISTINCT FROM $3
+			AND pc.person_id <> ALL($5)
+		ON CONFLICT (session_id, person_id) DO UPDATE
+		SET status = EXCLUDED.status
+		`, sessionID, courseID, termID, submission.DefaultStatus, pq.Array(listed))
+		if err != nil {
+			tx.Rollback()
+			return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] failed to record roster attendance: %w", classify(err))
+		}
+	}
+
+	records, err := queryAttendance(ctx, tx, sessionID)
+	if err != nil {
+		tx.Rollback()
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return []models.AttendanceRecord{}, fmt.Errorf("[in services.RecordAttendance] failed to commit transaction: %w", classify(err))
+	}
+	return records, nil
+}
+
+func queryAttendance(ctx context.Context, q queryer, sessionID int) ([]models.AttendanceRecord, error) {
+	rows, err := q.QueryContext(ctx, `
+	SELECT session_id, person_id, status
+		FROM attendance
+		WHERE session_id = $1
+		ORDER BY person_id
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", classify(err))
+	}
+	defer rows.Close()
+
+	records := []models.AttendanceRecord{}
+	for rows.Next() {
+		var record models.AttendanceRecord
+		if err := rows.Scan(&record.SessionID, &record.PersonID, &record.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan attendance: %w", classify(err))
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan attendance: %w", classify(err))
+	}
+	return records, nil
+}
+
+// GetCourseAttendance returns the attendance rates of the students of the
+// course in the terms selected by term, ordered by term and person.
+func (a AttendanceService) GetCourseAttendance(ctx context.Context, courseID int, term TermScope) (models.CourseAttendance, error) {
+	var exists bool
+	err := a.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
+    `, courseID).Scan(&exists)
+	if err != nil {
+		return models.CourseAttendance{}, fmt.Errorf("[in services.GetCourseAttendance] failed to check course existence: %w", classify(err))
+	}
+	if !exists {
+		return models.CourseAttendance{}, fmt.Errorf("[in services.GetCourseAttendance] course with ID %d does not exist: %w", courseID, ErrNotFound)
+	}
+
+	rates, err := a.attendanceRates(ctx, "pc.course_id", courseID, term, "pc.term_id NULLS FIRST, pc.person_id")
+	if err != nil {
+		return models.CourseAttendance{}, fmt.Errorf("[in services.GetCourseAttendance] %w", err)
+	}
+	return models.CourseAttendance{CourseID: courseID, Rate: overallRate(rates), Students: rates}, nil
+}
+
+// GetPersonAttendance returns the attendance rates of the person in the
+// courses they take as a student in the terms selected by term, ordered by
+// term and course.
+func (a AttendanceService) GetPersonAttendance(ctx context.Context, personID int, term TermScope) (models.PersonAttendance, error) {
+	var exists bool
+	err := a.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "person" WHERE "id" = $1)
+    `, personID).Scan(&exists)
+	if err != nil {
+		return models.PersonAttendance{}, fmt.Errorf("[in services.GetPersonAttendance] failed to check person existence: %w", classify(err))
+	}
+	if !exists {
+		return models.PersonAttendance{}, fmt.Errorf("[in services.GetPersonAttendance] person with ID %d does not exist: %w", personID, ErrNotFound)
+	}
+
+	rates, err := a.attendanceRates(ctx, "pc.person_id", personID, term, "pc.term_id NULLS FIRST, pc.course_id")
+	if err != nil {
+		return models.PersonAttendance{}, fmt.Errorf("[in services.GetPersonAttendance] %w", err)
+	}
+	return models.PersonAttendance{PersonID: personID, Rate: overallRate(rates), Courses: rates}, nil
+}
+
+// attendanceRates counts the attendance of every student enrollment whose
+// column equals id, in the order given by orderBy. Enrollments without any
+// recorded session are included with zero counts.
+func (a AttendanceService) attendanceRates(ctx context.Context, column string, id int, term TermScope, orderBy string) ([]models.AttendanceRate, error) {
+	query := `
+	SELECT pc.person_id, pc.course_id, pc.term_id,
+		COUNT(*) FILTER (WHERE at.status = 'present'),
+		COUNT(*) FILTER (WHERE at.status = 'late'),
+		COUNT(*) FILTER (WHERE at.status = 'absent'),
+		COUNT(*) FILTER (WHERE at.status = 'excused')
+		FROM person_course pc
+		LEFT JOIN class_session s ON s.course_id = pc.course_id AND s.term_id IS NOT DISTINCT FROM pc.term_id
+		LEFT JOIN attendance at ON at.session_id = s.id AND at.person_id = pc.person_id
+		WHERE pc.role = 'student' AND ` + column + ` = $1`
+	cond, args := term.condition("pc.term_id", []interface{}{id})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	query += " GROUP BY pc.person_id, pc.course_id, pc.term_id ORDER BY " + orderBy
+
+	rows, err := a.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance rates: %w", classify(err))
+	}
+	defer rows.Close()
+
+	rates := []models.AttendanceRate{}
+	for rows.Next() {
+		var rate models.AttendanceRate
+		if err := rows.Scan(&rate.PersonID, &rate.CourseID, &rate.TermID, &rate.Present, &rate.Late, &rate.Absent, &rate.Excused); err != nil {
+			return nil, fmt.Errorf("failed to scan attendance rate: %w", classify(err))
+		}
+		rate.Rate = attendanceRate(rate.Present+rate.Late, rate.Absent)
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan attendance rates: %w", classify(err))
+	}
+	return rates, nil
+}
+
+// overallRate is the attendance rate over all of rates.
+func overallRate(rates []models.AttendanceRate) *float64 {
+	var attended, absent int
+	for _, rate := range rates {
+		attended += rate.Present + rate.Late
+		absent += rate.Absent
+	}
+	return attendanceRate(attended, absent)
+}
+
+// attendanceRate returns the percentage of sessions attended rounded to two
+// decimal places, or nil if no session counts.
+func attendanceRate(attended, absent int) *float64 {
+	if attended+absent == 0 {
+		return nil
+	}
+	rate := math.Round(float64(attended)/float64(attended+absent)*10000) / 100
+	return &rate
+}
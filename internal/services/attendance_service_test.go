@@ -0,0 +1,270 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAttendanceService(t *testing.T) {
+	var mockDB *sql.DB
+
+	attendanceService := services.NewAttendanceService(mockDB)
+
+	require.NotNil(t, attendanceService)
+	require.Equal(t, mockDB, attendanceService.Database)
+}
+
+func TestGetSessions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		date := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`SELECT id, course_id, term_id, date, topic FROM class_session WHERE course_id = \$1 AND term_id = \$2 ORDER BY date, id`).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "course_id", "term_id", "date", "topic"}).
+				AddRow(1, 1, 2, date, "Introduction"))
+
+		sessions, err := service.GetSessions(context.Background(), 1, services.TermScope{ID: 2})
+		require.NoError(t, err)
+		termID := 2
+		require.Equal(t, []models.ClassSession{{ID: 1, CourseID: 1, TermID: &termID, Date: models.Date{Time: date}, Topic: "Introduction"}}, sessions)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Course Not Found", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.GetSessions(context.Background(), 9, services.TermScope{})
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateSession(t *testing.T) {
+	termID := 2
+	session := models.ClassSession{CourseID: 1, TermID: &termID, Date: models.Date{Time: time.Date(2026, 9, 8, 0, 0, 0, 0, time.UTC)}, Topic: "Control flow"}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`INSERT INTO class_session \(course_id, term_id, date, topic\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id`).
+			WithArgs(1, 2, "2026-09-08", "Control flow").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+		created, err := service.CreateSession(context.Background(), session)
+		require.NoError(t, err)
+		require.Equal(t, 4, created.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Term", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`INSERT INTO class_session`).
+			WillReturnError(&pq.Error{Code: "23503"})
+
+		_, err := service.CreateSession(context.Background(), session)
+		require.ErrorIs(t, err, services.ErrInvalidReference)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateSession(t *testing.T) {
+	service, mock := newMockAttendanceService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`UPDATE class_session SET date = \$3, topic = \$4 WHERE id = \$1 AND course_id = \$2 RETURNING term_id`).
+		WithArgs(9, 1, "2026-09-09", "").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := service.UpdateSession(context.Background(), models.ClassSession{ID: 9, CourseID: 1, Date: models.Date{Time: time.Date(2026, 9, 9, 0, 0, 0, 0, time.UTC)}})
+	require.ErrorIs(t, err, services.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSession(t *testing.T) {
+	service, mock := newMockAttendanceService(t)
+	defer service.Database.Close()
+
+	mock.ExpectExec(`DELETE FROM class_session WHERE id = \$1 AND course_id = \$2`).
+		WithArgs(4, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, service.DeleteSession(context.Background(), 1, 4))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordAttendance(t *testing.T) {
+	lockQuery := `SELECT term_id FROM class_session WHERE id = \$1 AND course_id = \$2 FOR UPDATE`
+	teachesQuery := `SELECT EXISTS\( SELECT 1 FROM person_course pc JOIN person p ON p.id = pc.person_id WHERE pc.person_id = \$1 AND pc.course_id = \$2 AND pc.term_id IS NOT DISTINCT FROM \$3 AND pc.role IN \('instructor', 'co_instructor'\) AND p.type = 'professor' \)`
+	recordQuery := `INSERT INTO attendance \(session_id, person_id, status\) SELECT \$1, pc.person_id, \$5 FROM person_course pc WHERE pc.role = 'student' AND pc.person_id = \$2 AND pc.course_id = \$3 AND pc.term_id IS NOT DISTINCT FROM \$4 ON CONFLICT \(session_id, person_id\) DO UPDATE SET status = EXCLUDED.status`
+	rosterQuery := `INSERT INTO attendance \(session_id, person_id, status\) SELECT \$1, pc.person_id, \$4 FROM person_course pc WHERE pc.role = 'student' AND pc.course_id = \$2 AND pc.term_id IS NOT DISTINCT FROM \$3 AND pc.person_id <> ALL\(\$5\) ON CONFLICT`
+	submission := models.AttendanceSubmission{ProfessorID: 1, DefaultStatus: "present", Records: []models.AttendanceEntry{{PersonID: 4, Status: "late"}}}
+
+	t.Run("Whole Roster", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id"}).AddRow(2))
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec(recordQuery).WithArgs(2, 4, 1, 2, "late").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(rosterQuery).WithArgs(2, 1, 2, "present", pq.Array([]int{4})).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(`SELECT session_id, person_id, status FROM attendance WHERE session_id = \$1 ORDER BY person_id`).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"session_id", "person_id", "status"}).
+				AddRow(2, 3, "present").
+				AddRow(2, 4, "late").
+				AddRow(2, 5, "present"))
+		mock.ExpectCommit()
+
+		records, err := service.RecordAttendance(context.Background(), 1, 2, submission)
+		require.NoError(t, err)
+		require.Equal(t, []models.AttendanceRecord{
+			{SessionID: 2, PersonID: 3, Status: "present"},
+			{SessionID: 2, PersonID: 4, Status: "late"},
+			{SessionID: 2, PersonID: 5, Status: "present"},
+		}, records)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Session Not Found", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(9, 1).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := service.RecordAttendance(context.Background(), 1, 9, submission)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not The Course Professor", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id"}).AddRow(2))
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		_, err := service.RecordAttendance(context.Background(), 1, 2, submission)
+		require.ErrorIs(t, err, services.ErrForbidden)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not On Roster", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"term_id"}).AddRow(2))
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec(recordQuery).WithArgs(2, 4, 1, 2, "late").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := service.RecordAttendance(context.Background(), 1, 2, submission)
+		require.ErrorIs(t, err, services.ErrInvalidReference)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetCourseAttendance(t *testing.T) {
+	service, mock := newMockAttendanceService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`FROM person_course pc LEFT JOIN class_session s ON .* LEFT JOIN attendance at ON .* WHERE pc.role = 'student' AND pc.course_id = \$1 AND pc.term_id = \$2 GROUP BY pc.person_id, pc.course_id, pc.term_id ORDER BY pc.term_id NULLS FIRST, pc.person_id`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"person_id", "course_id", "term_id", "present", "late", "absent", "excused"}).
+			AddRow(3, 1, 2, 2, 0, 0, 0).
+			AddRow(4, 1, 2, 1, 0, 2, 0).
+			AddRow(5, 1, 2, 0, 0, 0, 1))
+
+	report, err := service.GetCourseAttendance(context.Background(), 1, services.TermScope{ID: 2})
+	require.NoError(t, err)
+	require.Len(t, report.Students, 3)
+	require.Equal(t, 100.0, *report.Students[0].Rate)
+	require.Equal(t, 33.33, *report.Students[1].Rate)
+	require.Nil(t, report.Students[2].Rate)
+	require.Equal(t, 60.0, *report.Rate)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPersonAttendance(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "person" WHERE "id" = \$1\)`).
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`WHERE pc.role = 'student' AND pc.person_id = \$1 GROUP BY pc.person_id, pc.course_id, pc.term_id ORDER BY pc.term_id NULLS FIRST, pc.course_id`).
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"person_id", "course_id", "term_id", "present", "late", "absent", "excused"}).
+				AddRow(4, 1, 2, 1, 0, 1, 0).
+				AddRow(4, 2, 2, 1, 1, 0, 0))
+
+		report, err := service.GetPersonAttendance(context.Background(), 4, services.TermScope{})
+		require.NoError(t, err)
+		termID := 2
+		rate := 50.0
+		require.Equal(t, models.AttendanceRate{PersonID: 4, CourseID: 1, TermID: &termID, Present: 1, Absent: 1, Rate: &rate}, report.Courses[0])
+		require.Equal(t, 100.0, *report.Courses[1].Rate)
+		require.Equal(t, 75.0, *report.Rate)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Person Not Found", func(t *testing.T) {
+		service, mock := newMockAttendanceService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "person" WHERE "id" = \$1\)`).
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.GetPersonAttendance(context.Background(), 9, services.TermScope{})
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func newMockAttendanceService(t *testing.T) (services.AttendanceService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.AttendanceService{Database: db}
+
+	return service, mock
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+// enrolledStudents counts the students enrolled in the course $1 in the
+// term $2. Only people taking the course as students count against its
+// capacity; the teaching staff always get a seat. The capacity applies to
+// each term separately.
+const enrolledStudents = `SELECT COUNT(*)
+		FROM person_course pc
+		WHERE pc.course_id = $1 AND pc.term_id IS NOT DISTINCT FROM $2 AND pc.role = 'student'`
+
+// lockCourse locks the course row for the rest of tx. Every change to a
+// course's enrollments takes this lock first, so seat counts read after it
+// cannot be invalidated by a concurrent enrollment. It returns the course's
+// capacity, which is invalid for unlimited courses.
+func lockCourse(ctx context.Context, tx *sql.Tx, courseID int) (sql.NullInt64, error) {
+	var capacity sql.NullInt64
+	err := tx.QueryRowContext(ctx, `SELECT capacity FROM course WHERE id = $1 FOR UPDATE`, courseID).Scan(&capacity)
+	if err != nil {
+		return capacity, fmt.Errorf("failed to lock course %d: %w", courseID, classify(err))
+	}
+	return capacity, nil
+}
+
+// enrollmentRole returns the role the person joins a course in: role, or
+// when it is empty the default for their type, instructor for professors and
+// student for students. Instructors and co-instructors must be professors
+// and students must be students; anything else is a constraint violation.
+func enrollmentRole(ctx context.Context, tx *sql.Tx, personID int, role string) (string, error) {
+	var personType string
+	err := tx.QueryRowContext(ctx, `SELECT type FROM person WHERE id = $1`, personID).Scan(&personType)
+	if err != nil {
+		return "", fmt.Errorf("failed to get person %d: %w", personID, classify(err))
+	}
+
+	switch role {
+	case "":
+		if personType == "professor" {
+			return models.RoleInstructor, nil
+		}
+		return models.RoleStudent, nil
+	case models.RoleInstructor, models.RoleCoInstructor:
+		if personType != "professor" {
+			return "", fmt.Errorf("person %d is not a professor and cannot be a %s: %w", personID, role, ErrConstraintViolation)
+		}
+	case models.RoleStudent:
+		if personType != "student" {
+			return "", fmt.Errorf("person %d is not a student and cannot take a course as one: %w", personID, ErrConstraintViolation)
+		}
+	}
+	return role, nil
+}
+
+// typeRoles lists the roles each type of person can hold in a course.
+var typeRoles = map[string][]string{
+	"professor": {models.RoleInstructor, models.RoleCoInstructor, models.RoleTeachingAssistant},
+	"student":   {models.RoleTeachingAssistant, models.RoleStudent},
+}
+
+// checkPersonRoles fails with ErrConstraintViolation if the person holds a
+// role in a course that a person of the given type cannot hold. Waiting for
+// a seat counts as holding the student role, which promotion would give.
+func checkPersonRoles(ctx context.Context, tx *sql.Tx, personID int, personType string) error {
+	allowed := typeRoles[personType]
+	if allowed == nil {
+		allowed = []string{}
+	}
+	var roles []string
+	err := tx.QueryRowContext(ctx, `
+	SELECT COALESCE(ARRAY_AGG(DISTINCT role ORDER BY role), '{}')
+		FROM (
+			SELECT role FROM person_course WHERE person_id = $1
+			UNION ALL
+			SELECT 'student' FROM course_waitlist WHERE person_id = $1
+		) held
+		WHERE role <> ALL($2)
+	`, personID, pq.Array(allowed)).Scan(pq.Array(&roles))
+	if err != nil {
+		return fmt.Errorf("failed to get roles of person %d: %w", personID, classify(err))
+	}
+	if len(roles) > 0 {
+		return fmt.Errorf("person %d holds the course roles %s, which a %s cannot hold: %w", personID, strings.Join(roles, ", "), personType, ErrConstraintViolation)
+	}
+	return nil
+}
+
+// reserveSeat locks the course and reports whether someone joining it in the
+// given role can take a seat in it in the given term.
+func reserveSeat(ctx context.Context, tx *sql.Tx, courseID int, termID *int, role string) (bool, error) {
+	capacity, err := lockCourse(ctx, tx, courseID)
+	if err != nil {
+		return false, err
+	}
+	if !capacity.Valid || role != models.RoleStudent {
+		return true, nil
+	}
+
+	var enrolled int64
+	if err := tx.QueryRowContext(ctx, enrolledStudents, courseID, termID).Scan(&enrolled); err != nil {
+		return false, fmt.Errorf("failed to count students in course %d: %w", courseID, classify(err))
+	}
+	return enrolled < capacity.Int64, nil
+}
+
+// promoteWaitlist locks the course and enrolls people from the head of each
+// of its term waitlists into any free seats in that term. It is called
+// whenever a seat may have been freed. People the course would no longer
+// suit are passed over but keep their place, so they can still be promoted
+// once it does: those already enrolled in it, those whose other courses in
+// the term clash with it, and students who lack its prerequisites or whom it
+// would put over their credit limit.
+func promoteWaitlist(ctx context.Context, tx *sql.Tx, courseID int) error {
+	if _, err := lockCourse(ctx, tx, courseID); err != nil {
+		return err
+	}
+	// free is the number of free seats in the entry's term. For unlimited
+	// courses it is NULL, which promotes everyone who fits their limit.
+	_, err := tx.ExecContext(ctx, `
+	WITH queue AS (
+		SELECT w.id,
+			ROW_NUMBER() OVER (PARTITION BY w.term_id ORDER BY w.id) AS position,
+			(
+				SELECT c.capacity - LEAST(COUNT(*), c.capacity)
+				FROM person_course pc
+				WHERE pc.course_id = w.course_id AND pc.term_id IS NOT DISTINCT FROM w.term_id AND pc.role = 'student'
+			) AS free
+		FROM course_waitlist w
+		JOIN course c ON c.id = w.course_id
+		JOIN person p ON p.id = w.person_id
+		WHERE w.course_id = $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM person_course e
+			WHERE e.person_id = w.person_id AND e.course_id = w.course_id AND e.term_id IS NOT DISTINCT FROM w.term_id
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM person_course pc
+			JOIN course_meeting m ON m.course_id = pc.course_id
+			JOIN course_meeting n ON n.course_id = w.course_id
+			WHERE pc.person_id = w.person_id
+			AND pc.course_id <> w.course_id
+			AND `+inTerm("pc.term_id", "w.term_id")+`
+			AND `+meetingsOverlap("n", "m")+`
+		)
+		AND (p.type <> 'student' OR (
+			`+creditLoad("p.id", "w.term_id")+` + c.credits <= COALESCE(p.max_credits, $2)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM course_prerequisite cp
+				WHERE cp.course_id = w.course_id
+				AND NOT `+prerequisiteMet("w.person_id", "cp.prerequisite_id", "w.term_id")+`
+			)
+		))
+	), promoted AS (
+		DELETE FROM course_waitlist
+		WHERE id IN (SELECT id FROM queue WHERE free IS NULL OR position <= free)
+		RETURNING person_id, course_id, term_id
+	)
+	INSERT INTO person_course (person_id, course_id, term_id)
+	SELECT person_id, course_id, term_id FROM promoted
+	ON CONFLICT DO NOTHING
+	`, courseID, DefaultMaxCredits)
+	if err != nil {
+		return fmt.Errorf("failed to promote waitlist of course %d: %w", courseID, classify(err))
+	}
+	return nil
+}
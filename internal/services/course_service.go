@@ -3,9 +3,12 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
 )
 
 type CourseService struct {
@@ -18,79 +21,372 @@ func NewCourseService(db *sql.DB) *CourseService {
 	}
 }
 
-func (c CourseService) GetCourses(ctx context.Context) ([]models.Course, error) {
-	rows, err := c.Database.QueryContext(ctx, `SELECT * FROM "course"`)
+// courseColumns selects a course row aliased c in the order scanCourse
+// reads it.
+const courseColumns = `c.id, c.code, c.name, c.department_id, c.capacity, c.credits`
+
+func scanCourse(scan func(dest ...any) error, prefix ...any) (models.Course, error) {
+	var course models.Course
+	dest := append(prefix, &course.ID, &course.Code, &course.Name, &course.DepartmentID, &course.Capacity, &course.Credits)
+	err := scan(dest...)
+	return course, err
+}
+
+// CourseFilter narrows the courses returned by GetCourses. Zero values mean
+// the field is not filtered on.
+type CourseFilter struct {
+	DepartmentID int
+	// Term keeps courses with enrollments in the given terms.
+	Term TermScope
+}
+
+// GetCourses returns one page of the courses matching filter ordered by ID.
+func (c CourseService) GetCourses(ctx context.Context, filter CourseFilter, page Page) ([]models.Course, PageInfo, error) {
+	query := `SELECT ` + courseColumns + ` FROM course c`
+	var whereClauses []string
+	var args []interface{}
+	if filter.DepartmentID != 0 {
+		args = append(args, filter.DepartmentID)
+		whereClauses = append(whereClauses, fmt.Sprintf("c.department_id = $%d", len(args)))
+	}
+	if cond, termArgs := filter.Term.condition("term_id", args); cond != "" {
+		whereClauses = append(whereClauses, "c.id IN (SELECT course_id FROM person_course WHERE "+cond+")")
+		args = termArgs
+	}
+
+	// The count only depends on the filters, so build it before the keyset
+	// condition is added.
+	countQuery := `SELECT COUNT(*) FROM course c`
+	if len(whereClauses) > 0 {
+		countQuery += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	countArgs := args
+
+	cond, order, keysetArgs, err := page.keyset(nil, "c.id", len(args)+1)
+	if err != nil {
+		return []models.Course{}, PageInfo{}, fmt.Errorf("[in services.GetCourses] invalid page: %w", err)
+	}
+	if cond != "" {
+		whereClauses = append(whereClauses, cond)
+		args = append(args, keysetArgs...)
+	}
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s LIMIT %d", order, page.limit()+1)
+
+	rows, err := c.Database.QueryContext(ctx, query, args...)
 	if err != nil {
-		return []models.Course{}, fmt.Errorf("[in services.GetCourses] failed to get courses: %w", err)
+		return []models.Course{}, PageInfo{}, fmt.Errorf("[in services.GetCourses] failed to get courses: %w", classify(err))
 	}
 	defer rows.Close()
 
 	var courses []models.Course
 
 	for rows.Next() {
-		var c models.Course
-		err = rows.Scan(&c.ID, &c.Name)
+		c, err := scanCourse(rows.Scan)
 		if err != nil {
-			return []models.Course{}, fmt.Errorf("[in services.GetCourses] failed to scan courses from row: %w", err)
+			return []models.Course{}, PageInfo{}, fmt.Errorf("[in services.GetCourses] failed to scan courses from row: %w", classify(err))
 		}
 		courses = append(courses, c)
 	}
 	if err := rows.Err(); err != nil {
-		return []models.Course{}, fmt.Errorf("[in services.GetCourses] failed to scan courses: %w", err)
+		return []models.Course{}, PageInfo{}, fmt.Errorf("[in services.GetCourses] failed to scan courses: %w", classify(err))
 	}
-	return courses, nil
+
+	courses, info := paginate(courses, page, func(c models.Course) int { return c.ID }, nil)
+
+	if err := c.addDetails(ctx, courses); err != nil {
+		return []models.Course{}, PageInfo{}, fmt.Errorf("[in services.GetCourses] %w", err)
+	}
+
+	if page.WithTotal {
+		var total int
+		if err := c.Database.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+			return []models.Course{}, PageInfo{}, fmt.Errorf("[in services.GetCourses] failed to count courses: %w", classify(err))
+		}
+		info.Total = &total
+	}
+	return courses, info, nil
 }
 
 func (c CourseService) GetCourse(ctx context.Context, id int) (models.Course, error) {
 	row := c.Database.QueryRowContext(ctx, `
-	SELECT * FROM 
-	"course" 
-	WHERE "id" = $1
+	SELECT `+courseColumns+` FROM 
+	course c 
+	WHERE c.id = $1
 	`, id)
-	course := models.Course{}
-	if err := row.Scan(&course.ID, &course.Name); err != nil {
+	course, err := scanCourse(row.Scan)
+	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.Course{}, fmt.Errorf("[in services.GetCourse] course not found: %w", err)
+			return models.Course{}, fmt.Errorf("[in services.GetCourse] course not found: %w", classify(err))
 		}
-		return models.Course{}, fmt.Errorf("[in services.GetCourse] failed to scan course: %w", err)
+		return models.Course{}, fmt.Errorf("[in services.GetCourse] failed to scan course: %w", classify(err))
 	}
-	return course, nil
+
+	courses := []models.Course{course}
+	if err := c.addDetails(ctx, courses); err != nil {
+		return models.Course{}, fmt.Errorf("[in services.GetCourse] %w", err)
+	}
+	return courses[0], nil
 }
 
-func (c CourseService) CreateCourse(ctx context.Context, course models.Course) (models.Course, error) {
-	err := c.Database.QueryRowContext(ctx, `
-	INSERT INTO "course" 
-	(name) 
-	VALUES ($1) 
-	RETURNING "id"
-	`, course.Name).Scan(&course.ID)
+// GetCourseByCode returns the course with the given code, such as CS-101.
+func (c CourseService) GetCourseByCode(ctx context.Context, code string) (models.Course, error) {
+	row := c.Database.QueryRowContext(ctx, `
+	SELECT `+courseColumns+` FROM 
+	course c 
+	WHERE c.code = $1
+	`, code)
+	course, err := scanCourse(row.Scan)
 	if err != nil {
-		return models.Course{}, fmt.Errorf("[in services.CreateCourse] failed to create course: %w", err)
+		if err == sql.ErrNoRows {
+			return models.Course{}, fmt.Errorf("[in services.GetCourseByCode] course %s not found: %w", code, classify(err))
+		}
+		return models.Course{}, fmt.Errorf("[in services.GetCourseByCode] failed to scan course: %w", classify(err))
 	}
-	return course, nil
+
+	courses := []models.Course{course}
+	if err := c.addDetails(ctx, courses); err != nil {
+		return models.Course{}, fmt.Errorf("[in services.GetCourseByCode] %w", err)
+	}
+	return courses[0], nil
 }
 
-func (c CourseService) UpdateCourse(ctx context.Context, id int, course models.Course) (models.Course, error) {
-	// Check if the course exists
+// addDetails fills in the meetings, instructors and room warnings of the
+// courses. Room warnings are only looked up for courses booked into rooms.
+func (c CourseService) addDetails(ctx context.Context, courses []models.Course) error {
+	ids := make([]int, len(courses))
+	for i, course := range courses {
+		ids[i] = course.ID
+	}
+	meetings, err := c.getMeetings(ctx, ids)
+	if err != nil {
+		return err
+	}
+	instructors, err := c.getInstructors(ctx, ids)
+	if err != nil {
+		return err
+	}
+	var booked []int
+	for _, id := range ids {
+		if bookedMeetings(meetings[id]) {
+			booked = append(booked, id)
+		}
+	}
+	var warnings map[int][]models.RoomWarning
+	if len(booked) > 0 {
+		if warnings, err = getRoomWarnings(ctx, c.Database, booked); err != nil {
+			return err
+		}
+	}
+	for i := range courses {
+		courses[i].Meetings = meetings[courses[i].ID]
+		courses[i].Instructors = instructors[courses[i].ID]
+		courses[i].RoomWarnings = warnings[courses[i].ID]
+	}
+	return nil
+}
+
+// GetPeopleByCourse returns the people enrolled in each of the given
+// courses, keyed by course ID, using a single query. Courses nobody is
+// enrolled in map to an empty slice.
+func (c CourseService) GetPeopleByCourse(ctx context.Context, courseIDs []int) (map[int][]models.Person, error) {
+	rows, err := c.Database.QueryContext(ctx, `
+	SELECT DISTINCT pc.course_id, p.id, p.first_name, p.last_name, p.type, p.age,
+		ARRAY(SELECT DISTINCT course_id FROM person_course WHERE person_id = p.id ORDER BY course_id) AS courses,
+		p.max_credits, `+personCredits+` AS credits
+		FROM person_course pc
+		JOIN person p ON p.id = pc.person_id
+		WHERE pc.course_id = ANY($1)
+		ORDER BY pc.course_id, p.id;
+	`, pq.Array(courseIDs))
+	if err != nil {
+		return nil, fmt.Errorf("[in services.GetPeopleByCourse] failed to get people: %w", classify(err))
+	}
+	defer rows.Close()
+
+	people := make(map[int][]models.Person, len(courseIDs))
+	for _, id := range courseIDs {
+		people[id] = []models.Person{}
+	}
+	for rows.Next() {
+		var courseID int
+		var person models.Person
+		if err := rows.Scan(&courseID, &person.ID, &person.FirstName, &person.LastName, &person.Type, &person.Age, pq.Array(&person.Courses), &person.MaxCredits, &person.Credits); err != nil {
+			return nil, fmt.Errorf("[in services.GetPeopleByCourse] failed to scan person: %w", classify(err))
+		}
+		people[courseID] = append(people[courseID], person)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[in services.GetPeopleByCourse] failed to scan people: %w", classify(err))
+	}
+	return people, nil
+}
+
+// RosterFilter narrows and orders a course roster. Type "professor" keeps
+// the teaching staff and "student" the students, by the role they hold in
+// the course; an empty Type includes both. The zero Term includes every
+// term; Sort uses the fields accepted by ParsePersonSort and defaults to ID
+// order.
+type RosterFilter struct {
+	Type string
+	Term TermScope
+	Sort []SortKey
+}
+
+// GetCourseRoster returns the people enrolled in the course with the given
+// ID and their roles in it, split into teaching staff and students. Someone
+// who held different roles in different terms is listed once per role.
+func (c CourseService) GetCourseRoster(ctx context.Context, courseID int, filter RosterFilter) (models.Roster, error) {
 	var exists bool
 	err := c.Database.QueryRowContext(ctx, `
         SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
-    `, id).Scan(&exists)
+    `, courseID).Scan(&exists)
 	if err != nil {
-		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] failed to check course existence: %w", err)
+		return models.Roster{}, fmt.Errorf("[in services.GetCourseRoster] failed to check course existence: %w", classify(err))
 	}
 	if !exists {
-		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] course with ID %d does not exist", id)
+		return models.Roster{}, fmt.Errorf("[in services.GetCourseRoster] course with ID %d does not exist: %w", courseID, ErrNotFound)
+	}
+
+	query := `
+	SELECT DISTINCT p.id, p.first_name, p.last_name, p.type, p.age,
+		ARRAY(SELECT DISTINCT course_id FROM person_course WHERE person_id = p.id ORDER BY course_id) AS courses,
+		p.max_credits, ` + personCredits + ` AS credits, pc.role
+		FROM person_course pc
+		JOIN person p ON p.id = pc.person_id
+		WHERE pc.course_id = $1`
+	cond, args := filter.Term.condition("pc.term_id", []interface{}{courseID})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	switch filter.Type {
+	case "student":
+		query += " AND pc.role = 'student'"
+	case "professor":
+		query += " AND pc.role <> 'student'"
+	}
+	order := make([]string, 0, len(filter.Sort)+1)
+	for _, key := range filter.Sort {
+		column, ok := personSortColumns[key.Column]
+		if !ok {
+			return models.Roster{}, fmt.Errorf("[in services.GetCourseRoster] cannot sort by %q: %w", key.Column, ErrConstraintViolation)
+		}
+		if key.Desc {
+			column += " DESC"
+		}
+		order = append(order, column)
+	}
+	order = append(order, "p.id")
+	query += " ORDER BY " + strings.Join(order, ", ")
+
+	rows, err := c.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.Roster{}, fmt.Errorf("[in services.GetCourseRoster] failed to get roster: %w", classify(err))
+	}
+	defer rows.Close()
+
+	roster := models.Roster{CourseID: courseID, Professors: []models.RosterEntry{}, Students: []models.RosterEntry{}}
+	for rows.Next() {
+		var entry models.RosterEntry
+		if err := rows.Scan(&entry.ID, &entry.FirstName, &entry.LastName, &entry.Type, &entry.Age, pq.Array(&entry.Courses), &entry.MaxCredits, &entry.Credits, &entry.Role); err != nil {
+			return models.Roster{}, fmt.Errorf("[in services.GetCourseRoster] failed to scan person: %w", classify(err))
+		}
+		if entry.Role == models.RoleStudent {
+			roster.Students = append(roster.Students, entry)
+		} else {
+			roster.Professors = append(roster.Professors, entry)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return models.Roster{}, fmt.Errorf("[in services.GetCourseRoster] failed to scan people: %w", classify(err))
+	}
+	roster.ProfessorCount = len(roster.Professors)
+	roster.StudentCount = len(roster.Students)
+	return roster, nil
+}
+
+func (c CourseService) CreateCourse(ctx context.Context, course models.Course) (models.Course, error) {
+	tx, err := c.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Course{}, fmt.Errorf("[in services.CreateCourse] failed to start transaction: %w", classify(err))
 	}
 
-	// Update the course if it exists
-	_, err = c.Database.ExecContext(ctx, `
+	err = tx.QueryRowContext(ctx, `
+	INSERT INTO "course" 
+	(code, name, department_id, capacity, credits) 
+	VALUES ($1, $2, $3, $4, $5) 
+	RETURNING "id"
+	`, course.Code, course.Name, course.DepartmentID, course.Capacity, course.Credits).Scan(&course.ID)
+	if err != nil {
+		tx.Rollback()
+		return models.Course{}, fmt.Errorf("[in services.CreateCourse] failed to create course: %w", classify(err))
+	}
+
+	if err := setMeetings(ctx, tx, course.ID, course.Meetings); err != nil {
+		tx.Rollback()
+		return models.Course{}, fmt.Errorf("[in services.CreateCourse] %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Course{}, fmt.Errorf("[in services.CreateCourse] failed to commit transaction: %w", classify(err))
+	}
+	return course, nil
+}
+
+// UpdateCourse updates the course with the given ID and replaces its
+// meetings. Seats added by raising its capacity go to its waitlist straight
+// away. Changing the meetings does not recheck the timetables of the people
+// already enrolled. Booking rooms too small for the students already
+// enrolled succeeds, with the returned course's room warnings listing them.
+func (c CourseService) UpdateCourse(ctx context.Context, id int, course models.Course) (models.Course, error) {
+	tx, err := c.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] failed to start transaction: %w", classify(err))
+	}
+
+	result, err := tx.ExecContext(ctx, `
         UPDATE "course" 
-        SET "name" = $1 
-        WHERE "id" = $2
-    `, course.Name, id)
+        SET "code" = $1, "name" = $2, "department_id" = $3, "capacity" = $4, "credits" = $5
+        WHERE "id" = $6
+    `, course.Code, course.Name, course.DepartmentID, course.Capacity, course.Credits, id)
 	if err != nil {
-		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] failed to update course: %w", err)
+		tx.Rollback()
+		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] failed to update course: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] course with ID %d does not exist: %w", id, ErrNotFound)
+	}
+
+	if err := setMeetings(ctx, tx, id, course.Meetings); err != nil {
+		tx.Rollback()
+		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] %w", err)
+	}
+
+	if err := promoteWaitlist(ctx, tx, id); err != nil {
+		tx.Rollback()
+		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] %w", err)
+	}
+
+	course.RoomWarnings = nil
+	if bookedMeetings(course.Meetings) {
+		warnings, err := getRoomWarnings(ctx, tx, []int{id})
+		if err != nil {
+			tx.Rollback()
+			return models.Course{}, fmt.Errorf("[in services.UpdateCourse] %w", err)
+		}
+		course.RoomWarnings = warnings[id]
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Course{}, fmt.Errorf("[in services.UpdateCourse] failed to commit transaction: %w", classify(err))
 	}
 
 	course.ID = id
@@ -103,10 +399,10 @@ func (c CourseService) DeleteCourse(ctx context.Context, id int) error {
         SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
     `, id).Scan(&exists)
 	if err != nil {
-		return fmt.Errorf("[in services.DeleteCourse] failed to check course existence: %w", err)
+		return fmt.Errorf("[in services.DeleteCourse] failed to check course existence: %w", classify(err))
 	}
 	if !exists {
-		return fmt.Errorf("[in services.DeleteCourses] course with ID %d does not exist", id)
+		return fmt.Errorf("[in services.DeleteCourse] course with ID %d does not exist: %w", id, ErrNotFound)
 	}
 
 	_, err = c.Database.Exec(`
@@ -115,7 +411,14 @@ func (c CourseService) DeleteCourse(ctx context.Context, id int) error {
 	WHERE "id" = $1
 	`, id)
 	if err != nil {
-		return fmt.Errorf("[in services.UpdateCourse] failed to delete course: %w", err)
+		err = classify(err)
+		// A foreign key failure here means people are still enrolled in the
+		// course, which is a conflict with existing data rather than a bad
+		// reference supplied by the caller.
+		if errors.Is(err, ErrInvalidReference) {
+			err = withKind(ErrConflict, err)
+		}
+		return fmt.Errorf("[in services.DeleteCourse] failed to delete course: %w", err)
 	}
 	return nil
 }
@@ -3,12 +3,14 @@ package services_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,22 +28,103 @@ func TestGetCourses(t *testing.T) {
 	defer service.Database.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name"}).
-			AddRow(1, "Course 1").
-			AddRow(2, "Course 2")
-		mock.ExpectQuery(`SELECT \* FROM "course"`).WillReturnRows(rows)
-
-		courses, err := service.GetCourses(context.Background())
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).
+			AddRow(1, "C-1", "Course 1", nil, nil, 3).
+			AddRow(2, "C-2", "Course 2", nil, 30, 4)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c ORDER BY c.id ASC LIMIT 21`).WillReturnRows(rows)
+		expectMeetings(mock, []int{1, 2})
+		expectInstructors(mock, []int{1, 2})
+
+		courses, info, err := service.GetCourses(context.Background(), services.CourseFilter{}, services.Page{})
 		require.NoError(t, err)
 		require.Len(t, courses, 2)
 		require.Equal(t, courses[0].Name, "Course 1")
 		require.Equal(t, courses[1].Name, "Course 2")
+		require.Nil(t, courses[0].Capacity)
+		require.Equal(t, 30, *courses[1].Capacity)
+		require.Equal(t, 4, courses[1].Credits)
+		require.Empty(t, info.NextCursor)
+		require.Empty(t, info.PrevCursor)
+		require.Nil(t, info.Total)
+	})
+
+	t.Run("NextPage", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).
+			AddRow(3, "C-3", "Course 3", nil, nil, 3).
+			AddRow(4, "C-4", "Course 4", nil, nil, 3).
+			AddRow(5, "C-5", "Course 5", nil, nil, 3)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.id > \$1 ORDER BY c.id ASC LIMIT 3`).
+			WithArgs(2).
+			WillReturnRows(rows)
+		expectMeetings(mock, []int{3, 4})
+		expectInstructors(mock, []int{3, 4})
+
+		courses, info, err := service.GetCourses(context.Background(), services.CourseFilter{}, services.Page{Limit: 2, Cursor: &services.Cursor{ID: 2}})
+		require.NoError(t, err)
+		require.Len(t, courses, 2)
+		require.Equal(t, services.EncodeCursor(services.Cursor{ID: 4}), info.NextCursor)
+		require.Equal(t, services.EncodeCursor(services.Cursor{ID: 3, Backward: true}), info.PrevCursor)
+	})
+
+	t.Run("PreviousPage", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).
+			AddRow(2, "C-2", "Course 2", nil, nil, 3).
+			AddRow(1, "C-1", "Course 1", nil, nil, 3)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.id < \$1 ORDER BY c.id DESC LIMIT 3`).
+			WithArgs(3).
+			WillReturnRows(rows)
+		expectMeetings(mock, []int{1, 2})
+		expectInstructors(mock, []int{1, 2})
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM course c`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+		courses, info, err := service.GetCourses(context.Background(), services.CourseFilter{}, services.Page{Limit: 2, Cursor: &services.Cursor{ID: 3, Backward: true}, WithTotal: true})
+		require.NoError(t, err)
+		require.Equal(t, []models.Course{{ID: 1, Code: "C-1", Name: "Course 1", Credits: 3}, {ID: 2, Code: "C-2", Name: "Course 2", Credits: 3}}, courses)
+		require.Equal(t, services.EncodeCursor(services.Cursor{ID: 2}), info.NextCursor)
+		require.Empty(t, info.PrevCursor)
+		require.Equal(t, 5, *info.Total)
+	})
+
+	t.Run("Department", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).
+			AddRow(3, "DES-110", "UI Design", 2, nil, 3)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.department_id = \$1 AND c.id > \$2 ORDER BY c.id ASC LIMIT 3`).
+			WithArgs(2, 1).
+			WillReturnRows(rows)
+		expectMeetings(mock, []int{3})
+		expectInstructors(mock, []int{3})
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM course c WHERE c.department_id = \$1`).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		courses, info, err := service.GetCourses(context.Background(), services.CourseFilter{DepartmentID: 2}, services.Page{Limit: 2, Cursor: &services.Cursor{ID: 1}, WithTotal: true})
+		require.NoError(t, err)
+		departmentID := 2
+		require.Equal(t, []models.Course{{ID: 3, Code: "DES-110", Name: "UI Design", DepartmentID: &departmentID, Credits: 3}}, courses)
+		require.Equal(t, 1, *info.Total)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ByTerm", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).
+			AddRow(1, "CS-101", "Programming", 1, 30, 4)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.department_id = \$1 AND c.id IN \(SELECT course_id FROM person_course WHERE term_id = \$2\) ORDER BY c.id ASC LIMIT 21`).
+			WithArgs(1, 2).
+			WillReturnRows(rows)
+		expectMeetings(mock, []int{1})
+		expectInstructors(mock, []int{1})
+
+		courses, _, err := service.GetCourses(context.Background(), services.CourseFilter{DepartmentID: 1, Term: services.TermScope{ID: 2}}, services.Page{})
+		require.NoError(t, err)
+		require.Len(t, courses, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("QueryError", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "course"`).WillReturnError(errors.New("query error"))
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c`).WillReturnError(errors.New("query error"))
 
-		_, err := service.GetCourses(context.Background())
+		_, _, err := service.GetCourses(context.Background(), services.CourseFilter{}, services.Page{})
 		require.Error(t, err)
 	})
 }
@@ -51,51 +134,127 @@ func TestGetCourse(t *testing.T) {
 	defer service.Database.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Course 1")
-		mock.ExpectQuery(`SELECT \* FROM "course" WHERE "id" = \$1`).WithArgs(1).WillReturnRows(rows)
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).AddRow(1, "C-1", "Course 1", nil, 2, 4)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.id = \$1`).WithArgs(1).WillReturnRows(rows)
+		expectMeetings(mock, []int{1}, 1, pq.Array([]string{"mon", "wed"}), "09:00", "10:30", "Room 101", nil)
+		expectInstructors(mock, []int{1}, 1, 1, "Steve", "Jobs", "instructor", 1, 3, "Larry", "Page", "teaching_assistant")
 
 		course, err := service.GetCourse(context.Background(), 1)
 		require.NoError(t, err)
 		require.Equal(t, course.Name, "Course 1")
+		require.Equal(t, 2, *course.Capacity)
+		require.Equal(t, 4, course.Credits)
+		require.Equal(t, []models.Instructor{
+			{PersonID: 1, FirstName: "Steve", LastName: "Jobs", Role: models.RoleInstructor},
+			{PersonID: 3, FirstName: "Larry", LastName: "Page", Role: models.RoleTeachingAssistant},
+		}, course.Instructors)
+		require.Equal(t, []models.Meeting{{Days: []string{"mon", "wed"}, StartTime: "09:00", EndTime: "10:30", Location: "Room 101"}}, course.Meetings)
+	})
+
+	t.Run("Room Warnings", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).AddRow(3, "DES-110", "UI Design", nil, nil, 3)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.id = \$1`).WithArgs(3).WillReturnRows(rows)
+		expectMeetings(mock, []int{3}, 3, pq.Array([]string{"mon", "wed"}), "11:00", "12:30", "Studio 3", 4)
+		expectInstructors(mock, []int{3})
+		mock.ExpectQuery(`SELECT course_id, room_id, seats, enrolled FROM \(.*\) w WHERE enrolled > seats`).
+			WithArgs(pq.Array([]int{3})).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id", "room_id", "seats", "enrolled"}).AddRow(3, 4, 2, 3))
+
+		course, err := service.GetCourse(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, []models.RoomWarning{{RoomID: 4, Seats: 2, Enrolled: 3}}, course.RoomWarnings)
+		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "course" WHERE "id" = \$1`).WithArgs(1).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.id = \$1`).WithArgs(1).WillReturnError(sql.ErrNoRows)
 
 		_, err := service.GetCourse(context.Background(), 1)
 		require.Error(t, err)
+		require.ErrorIs(t, err, services.ErrNotFound)
 	})
 
 	t.Run("QueryError", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "course" WHERE "id" = \$1`).WithArgs(1).WillReturnError(errors.New("query error"))
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.id = \$1`).WithArgs(1).WillReturnError(errors.New("query error"))
 
 		_, err := service.GetCourse(context.Background(), 1)
 		require.Error(t, err)
 	})
 }
 
+func TestGetCourseByCode(t *testing.T) {
+	service, mock := newMockCourseService(t)
+	defer service.Database.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).AddRow(1, "CS-101", "Programming", 1, nil, 4)
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course c WHERE c.code = \$1`).WithArgs("CS-101").WillReturnRows(rows)
+		expectMeetings(mock, []int{1})
+		expectInstructors(mock, []int{1})
+
+		course, err := service.GetCourseByCode(context.Background(), "CS-101")
+		require.NoError(t, err)
+		require.Equal(t, 1, course.ID)
+		require.Equal(t, "Programming", course.Name)
+		require.Equal(t, 1, *course.DepartmentID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery(`FROM course c WHERE c.code = \$1`).WithArgs("CS-999").WillReturnError(sql.ErrNoRows)
+
+		_, err := service.GetCourseByCode(context.Background(), "CS-999")
+		require.ErrorIs(t, err, services.ErrNotFound)
+	})
+}
+
 func TestCreateCourse(t *testing.T) {
 	service, mock := newMockCourseService(t)
 	defer service.Database.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectQuery(`INSERT INTO "course" \(name\) VALUES \(\$1\) RETURNING "id"`).
-			WithArgs("Course 1").
+		capacity, departmentID := 25, 1
+		meeting := models.Meeting{Days: []string{"tue", "thu"}, StartTime: "13:00", EndTime: "14:15"}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "course" \(code, name, department_id, capacity, credits\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING "id"`).
+			WithArgs("CS-101", "Course 1", 1, 25, 3).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec(`DELETE FROM course_meeting WHERE course_id = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO course_meeting \(course_id, days, start_time, end_time, location, room_id\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)`).
+			WithArgs(1, pq.Array(meeting.Days), "13:00", "14:15", "", nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
-		course, err := service.CreateCourse(context.Background(), models.Course{Name: "Course 1"})
+		course, err := service.CreateCourse(context.Background(), models.Course{Code: "CS-101", Name: "Course 1", DepartmentID: &departmentID, Capacity: &capacity, Credits: 3, Meetings: []models.Meeting{meeting}})
 		require.NoError(t, err)
 		require.Equal(t, course.ID, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("InsertError", func(t *testing.T) {
-		mock.ExpectQuery(`INSERT INTO "course" \(name\) VALUES \(\$1\) RETURNING "id"`).
-			WithArgs("Course 1").
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "course" \(code, name, department_id, capacity, credits\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING "id"`).
+			WithArgs("CS-101", "Course 1", nil, nil, 0).
 			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
 
-		_, err := service.CreateCourse(context.Background(), models.Course{Name: "Course 1"})
+		_, err := service.CreateCourse(context.Background(), models.Course{Code: "CS-101", Name: "Course 1"})
 		require.Error(t, err)
 	})
+
+	t.Run("Duplicate Code", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "course"`).
+			WithArgs("CS-101", "Course 1", nil, nil, 0).
+			WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectRollback()
+
+		_, err := service.CreateCourse(context.Background(), models.Course{Code: "CS-101", Name: "Course 1"})
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestUpdateCourse(t *testing.T) {
@@ -103,39 +262,45 @@ func TestUpdateCourse(t *testing.T) {
 	defer service.Database.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
-			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
-
-		mock.ExpectExec(`UPDATE "course" SET "name" = \$1 WHERE "id" = \$2`).
-			WithArgs("Updated Course", 1).
+		capacity := 40
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "course" SET "code" = \$1, "name" = \$2, "department_id" = \$3, "capacity" = \$4, "credits" = \$5 WHERE "id" = \$6`).
+			WithArgs("CS-102", "Updated Course", nil, 40, 4, 1).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`DELETE FROM course_meeting WHERE course_id = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		expectPromotion(mock, 1, 40)
+		mock.ExpectCommit()
 
-		course, err := service.UpdateCourse(context.Background(), 1, models.Course{Name: "Updated Course"})
+		course, err := service.UpdateCourse(context.Background(), 1, models.Course{Code: "CS-102", Name: "Updated Course", Capacity: &capacity, Credits: 4})
 		require.NoError(t, err)
 		require.Equal(t, course.ID, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("CourseNotFound", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
-			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "course"`).
+			WithArgs("CS-102", "Updated Course", nil, nil, 0, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
 
-		_, err := service.UpdateCourse(context.Background(), 1, models.Course{Name: "Updated Course"})
+		_, err := service.UpdateCourse(context.Background(), 1, models.Course{Code: "CS-102", Name: "Updated Course"})
 		require.Error(t, err)
+		require.ErrorIs(t, err, services.ErrNotFound)
 	})
 
 	t.Run("UpdateError", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
-			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
-
-		mock.ExpectExec(`UPDATE "course" SET "name" = \$1 WHERE "id" = \$2`).
-			WithArgs("Updated Course", 1).
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "course"`).
+			WithArgs("CS-102", "Updated Course", nil, nil, 0, 1).
 			WillReturnError(errors.New("update error"))
+		mock.ExpectRollback()
 
-		_, err := service.UpdateCourse(context.Background(), 1, models.Course{Name: "Updated Course"})
+		_, err := service.UpdateCourse(context.Background(), 1, models.Course{Code: "CS-102", Name: "Updated Course"})
 		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
 	})
 }
 
@@ -162,6 +327,20 @@ func TestDeleteCourse(t *testing.T) {
 
 		err := service.DeleteCourse(context.Background(), 1)
 		require.Error(t, err)
+		require.ErrorIs(t, err, services.ErrNotFound)
+	})
+
+	t.Run("StillReferenced", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		mock.ExpectExec(`DELETE FROM "course" WHERE "id" = \$1`).
+			WithArgs(1).
+			WillReturnError(&pq.Error{Code: "23503"})
+
+		err := service.DeleteCourse(context.Background(), 1)
+		require.ErrorIs(t, err, services.ErrConflict)
 	})
 
 	t.Run("DeleteError", func(t *testing.T) {
@@ -178,6 +357,156 @@ func TestDeleteCourse(t *testing.T) {
 	})
 }
 
+func TestGetPeopleByCourse(t *testing.T) {
+	service, mock := newMockCourseService(t)
+	defer service.Database.Close()
+
+	rows := sqlmock.NewRows([]string{"course_id", "id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+		AddRow(1, 3, "John", "Doe", "student", 20, pq.Array([]int64{1, 2}), nil, 0).
+		AddRow(1, 4, "Jane", "Roe", "professor", 45, pq.Array([]int64{1}), nil, 0)
+	mock.ExpectQuery(`FROM person_course pc JOIN person p ON p.id = pc.person_id WHERE pc.course_id = ANY\(\$1\) ORDER BY pc.course_id, p.id;`).
+		WithArgs(pq.Array([]int{1, 2})).
+		WillReturnRows(rows)
+
+	people, err := service.GetPeopleByCourse(context.Background(), []int{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, map[int][]models.Person{
+		1: {
+			{ID: 3, FirstName: "John", LastName: "Doe", Type: "student", Age: 20, Courses: []int64{1, 2}},
+			{ID: 4, FirstName: "Jane", LastName: "Roe", Type: "professor", Age: 45, Courses: []int64{1}},
+		},
+		2: {},
+	}, people)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCourseRoster(t *testing.T) {
+	columns := []string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits", "role"}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`FROM person_course pc JOIN person p ON p.id = pc.person_id WHERE pc.course_id = \$1 ORDER BY p.last_name, p.id$`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(4, "Bill", "Gates", "student", 67, pq.Array([]int64{1}), nil, 0, "student").
+				AddRow(1, "Steve", "Jobs", "professor", 56, pq.Array([]int64{1, 2}), nil, 0, "instructor").
+				AddRow(5, "Elon", "Musk", "student", 52, pq.Array([]int64{1}), nil, 0, "teaching_assistant"))
+
+		sort, err := services.ParsePersonSort("last_name")
+		require.NoError(t, err)
+		roster, err := service.GetCourseRoster(context.Background(), 1, services.RosterFilter{Sort: sort})
+		require.NoError(t, err)
+		// A student assisting in the course is listed with the teaching staff.
+		require.Equal(t, models.Roster{
+			CourseID: 1,
+			Professors: []models.RosterEntry{
+				{Person: models.Person{ID: 1, FirstName: "Steve", LastName: "Jobs", Type: "professor", Age: 56, Courses: []int64{1, 2}}, Role: models.RoleInstructor},
+				{Person: models.Person{ID: 5, FirstName: "Elon", LastName: "Musk", Type: "student", Age: 52, Courses: []int64{1}}, Role: models.RoleTeachingAssistant},
+			},
+			Students: []models.RosterEntry{
+				{Person: models.Person{ID: 4, FirstName: "Bill", LastName: "Gates", Type: "student", Age: 67, Courses: []int64{1}}, Role: models.RoleStudent},
+			},
+			ProfessorCount: 2,
+			StudentCount:   1,
+		}, roster)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Filtered By Type", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`WHERE pc.course_id = \$1 AND pc.role <> 'student' ORDER BY p.last_name DESC, p.id$`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		roster, err := service.GetCourseRoster(context.Background(), 1, services.RosterFilter{
+			Type: "professor",
+			Sort: []services.SortKey{{Column: "last_name", Desc: true}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, models.Roster{CourseID: 1, Professors: []models.RosterEntry{}, Students: []models.RosterEntry{}}, roster)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Filtered By Term", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`WHERE pc.course_id = \$1 AND pc.term_id = \$2 AND pc.role = 'student' ORDER BY p.id$`).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := service.GetCourseRoster(context.Background(), 1, services.RosterFilter{Type: "student", Term: services.TermScope{ID: 2}})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.GetCourseRoster(context.Background(), 9, services.RosterFilter{})
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := services.Cursor{ID: 42, Backward: true}
+
+	decoded, err := services.DecodeCursor(services.EncodeCursor(cursor))
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+
+	_, err = services.DecodeCursor("not a cursor!")
+	require.Error(t, err)
+
+	// {"id":1,"v":[{"age":1}]}
+	_, err = services.DecodeCursor("eyJpZCI6MSwidiI6W3siYWdlIjoxfV19")
+	require.Error(t, err)
+}
+
+// expectMeetings expects the meetings of the given courses to be loaded.
+// Each meeting is a course ID followed by its days, start and end times,
+// location and room ID.
+func expectMeetings(mock sqlmock.Sqlmock, courseIDs []int, meetings ...driver.Value) {
+	rows := sqlmock.NewRows([]string{"course_id", "days", "start_time", "end_time", "location", "room_id"})
+	for i := 0; i+6 <= len(meetings); i += 6 {
+		rows.AddRow(meetings[i : i+6]...)
+	}
+	mock.ExpectQuery(`FROM course_meeting m WHERE m.course_id = ANY\(\$1\) ORDER BY m.course_id, m.id`).
+		WithArgs(pq.Array(courseIDs)).
+		WillReturnRows(rows)
+}
+
+// expectInstructors expects the teaching staff of the courses to be read,
+// returning the given (course_id, id, first_name, last_name, role) values.
+func expectInstructors(mock sqlmock.Sqlmock, courseIDs []int, instructors ...driver.Value) {
+	rows := sqlmock.NewRows([]string{"course_id", "id", "first_name", "last_name", "role"})
+	for i := 0; i+5 <= len(instructors); i += 5 {
+		rows.AddRow(instructors[i : i+5]...)
+	}
+	mock.ExpectQuery(`FROM person_course pc JOIN person p ON p.id = pc.person_id WHERE pc.course_id = ANY\(\$1\) AND pc.role <> 'student'`).
+		WithArgs(pq.Array(courseIDs), pq.Array(models.Roles)).
+		WillReturnRows(rows)
+}
+
 func newMockCourseService(t *testing.T) (services.CourseService, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DefaultMaxCredits is the credit load limit of students without a limit of
+// their own.
+const DefaultMaxCredits = 18
+
+// personCredits computes the current credit load of person p: the credits of
+// the courses they take as a student in the current term and of those not
+// tied to a term.
+var personCredits = `(
+	SELECT COALESCE(SUM(lc.credits), 0)
+		FROM person_course l
+		JOIN course lc ON lc.id = l.course_id
+		WHERE l.person_id = p.id AND l.role = 'student'
+		AND (l.term_id IS NULL OR ` + currentTermCondition("l.term_id") + `)
+	)`
+
+func currentTermCondition(column string) string {
+	cond, _ := TermScope{When: TermCurrent}.condition(column, nil)
+	return cond
+}
+
+// inTerm is the condition that the enrollment term in column falls in the
+// term given by the SQL expression term, which is NULL for the current term.
+// Enrollments not tied to a term fall in every term.
+func inTerm(column, term string) string {
+	return `(` + column + ` IS NULL OR ` + column + ` = ` + term + ` OR (` + term + ` IS NULL AND ` + currentTermCondition(column) + `))`
+}
+
+// creditLoad computes the credit load of the person whose ID is the SQL
+// expression person in the term given by the expression term, which is NULL
+// for the current term. Only courses taken as a student count, and courses
+// not tied to a term always do.
+func creditLoad(person, term string) string {
+	return `(
+		SELECT COALESCE(SUM(lc.credits), 0)
+			FROM person_course l
+			JOIN course lc ON lc.id = l.course_id
+			WHERE l.person_id = ` + person + ` AND l.role = 'student'
+			AND ` + inTerm("l.term_id", term) + `
+		)`
+}
+
+// checkCreditLimit fails with a CreditLimitError when the student's credit
+// load in the given term, counting courses not tied to a term, is over their
+// limit. A nil term stands for the current term. It runs after the
+// enrollments have been written, so the load includes them. Professors have
+// no limit, and courses a student assists in do not count.
+func checkCreditLimit(ctx context.Context, tx *sql.Tx, personID int, termID *int) error {
+	var personType string
+	var limit, credits int
+	err := tx.QueryRowContext(ctx, `
+	SELECT p.type, COALESCE(p.max_credits, $3), `+creditLoad("p.id", "$2")+`
+		FROM person p
+		WHERE p.id = $1
+	`, personID, termID, DefaultMaxCredits).Scan(&personType, &limit, &credits)
+	if err != nil {
+		return fmt.Errorf("failed to get credit load of person %d: %w", personID, classify(err))
+	}
+	if personType == "student" && credits > limit {
+		return fmt.Errorf("credit limit exceeded: %w", &CreditLimitError{PersonID: personID, Limit: limit, Credits: credits})
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrollCreditLimit(t *testing.T) {
+	service, mock := newMockEnrollmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectBegin()
+	expectSeat(mock, 3, 1, nil, 0)
+	expectNotEnrolled(mock, 3, 1)
+	expectPrerequisitesMet(mock, 3, 1, nil)
+	expectNoScheduleConflicts(mock, 3, 1, nil)
+	mock.ExpectQuery(`INSERT INTO person_course`).
+		WithArgs(3, 1, nil, "student").
+		WillReturnRows(sqlmock.NewRows([]string{"enrolled_at"}).AddRow(time.Now()))
+	expectCreditLoad(mock, 3, nil, "student", 12, 14)
+	mock.ExpectRollback()
+
+	_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+	require.ErrorIs(t, err, services.ErrConflict)
+	var limit *services.CreditLimitError
+	require.True(t, errors.As(err, &limit))
+	require.Equal(t, services.CreditLimitError{PersonID: 3, Limit: 12, Credits: 14}, *limit)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePersonCoursesCreditLimit(t *testing.T) {
+	t.Run("Over Limit", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectDropCourses(mock, 3, []int64{1, 2})
+		expectKeptCourses(mock, 3, 1)
+		expectSeat(mock, 3, 2, nil, 0)
+		expectPrerequisitesMet(mock, 3, 2, nil)
+		expectNoScheduleConflicts(mock, 3, 2, nil)
+		mock.ExpectExec(`INSERT INTO person_course`).WithArgs(3, 2, models.RoleStudent).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCreditLoad(mock, 3, nil, "student", 18, 20)
+		mock.ExpectRollback()
+
+		err := service.UpdatePersonCourses(context.Background(), 3, []int64{1, 2})
+		var limit *services.CreditLimitError
+		require.True(t, errors.As(err, &limit))
+		require.Equal(t, 20, limit.Credits)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Dropping Courses Skips The Check", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectDropCourses(mock, 3, []int64{1}, 2)
+		expectPromotion(mock, 2, nil)
+		expectKeptCourses(mock, 3, 1)
+		mock.ExpectCommit()
+
+		require.NoError(t, service.UpdatePersonCourses(context.Background(), 3, []int64{1}))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPromoteWaitlistCreditLimit(t *testing.T) {
+	service, mock := newMockEnrollmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectBegin()
+	expectCourseLock(mock, 1, 30)
+	mock.ExpectExec(`DELETE FROM person_course`).
+		WithArgs(3, 1, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectCourseLock(mock, 1, 30)
+	// Students are passed over when the course's credits would put them over
+	// their own limit or the default one.
+	mock.ExpectExec(`AND \(p.type <> 'student' OR \( \( SELECT COALESCE\(SUM\(lc.credits\), 0\) .* l.term_id = w.term_id .* \) \+ c.credits <= COALESCE\(p.max_credits, \$2\) AND NOT EXISTS`).
+		WithArgs(1, services.DefaultMaxCredits).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, service.Unenroll(context.Background(), 3, 1, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
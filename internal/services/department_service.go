@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+type DepartmentService struct {
+	Database *sql.DB
+}
+
+func NewDepartmentService(db *sql.DB) *DepartmentService {
+	return &DepartmentService{
+		Database: db,
+	}
+}
+
+// GetDepartments returns every department ordered by code. There are only
+// ever a handful of departments, so the list is not paginated.
+func (d DepartmentService) GetDepartments(ctx context.Context) ([]models.Department, error) {
+	rows, err := d.Database.QueryContext(ctx, `
+	SELECT "id", "code", "name"
+		FROM "department"
+		ORDER BY "code"
+	`)
+	if err != nil {
+		return []models.Department{}, fmt.Errorf("[in services.GetDepartments] failed to get departments: %w", classify(err))
+	}
+	defer rows.Close()
+
+	departments := []models.Department{}
+	for rows.Next() {
+		var department models.Department
+		if err := rows.Scan(&department.ID, &department.Code, &department.Name); err != nil {
+			return []models.Department{}, fmt.Errorf("[in services.GetDepartments] failed to scan department: %w", classify(err))
+		}
+		departments = append(departments, department)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Department{}, fmt.Errorf("[in services.GetDepartments] failed to scan departments: %w", classify(err))
+	}
+	return departments, nil
+}
+
+func (d DepartmentService) GetDepartment(ctx context.Context, id int) (models.Department, error) {
+	var department models.Department
+	err := d.Database.QueryRowContext(ctx, `
+	SELECT "id", "code", "name"
+		FROM "department"
+		WHERE "id" = $1
+	`, id).Scan(&department.ID, &department.Code, &department.Name)
+	if err != nil {
+		return models.Department{}, fmt.Errorf("[in services.GetDepartment] failed to get department: %w", classify(err))
+	}
+	return department, nil
+}
+
+// CreateDepartment creates a department. Reusing the code of another
+// department is a conflict.
+func (d DepartmentService) CreateDepartment(ctx context.Context, department models.Department) (models.Department, error) {
+	err := d.Database.QueryRowContext(ctx, `
+	INSERT INTO "department"
+	(code, name)
+	VALUES ($1, $2)
+	RETURNING "id"
+	`, department.Code, department.Name).Scan(&department.ID)
+	if err != nil {
+		return models.Department{}, fmt.Errorf("[in services.CreateDepartment] failed to create department: %w", classify(err))
+	}
+	return department, nil
+}
+
+func (d DepartmentService) UpdateDepartment(ctx context.Context, id int, department models.Department) (models.Department, error) {
+	result, err := d.Database.ExecContext(ctx, `
+	UPDATE "department"
+	SET "code" = $1, "name" = $2
+	WHERE "id" = $3
+	`, department.Code, department.Name, id)
+	if err != nil {
+		return models.Department{}, fmt.Errorf("[in services.UpdateDepartment] failed to update department: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.Department{}, fmt.Errorf("[in services.UpdateDepartment] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return models.Department{}, fmt.Errorf("[in services.UpdateDepartment] department with ID %d does not exist: %w", id, ErrNotFound)
+	}
+
+	department.ID = id
+	return department, nil
+}
+
+// DeleteDepartment deletes the department with the given ID. Departments
+// that still offer courses cannot be deleted.
+func (d DepartmentService) DeleteDepartment(ctx context.Context, id int) error {
+	result, err := d.Database.ExecContext(ctx, `
+	DELETE FROM "department"
+	WHERE "id" = $1
+	`, id)
+	if err != nil {
+		err = classify(err)
+		if errors.Is(err, ErrInvalidReference) {
+			err = withKind(ErrConflict, err)
+		}
+		return fmt.Errorf("[in services.DeleteDepartment] failed to delete department: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteDepartment] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.DeleteDepartment] department with ID %d does not exist: %w", id, ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var computerScience = models.Department{Code: "CS", Name: "Computer Science"}
+
+func TestNewDepartmentService(t *testing.T) {
+	var mockDB *sql.DB
+
+	departmentService := services.NewDepartmentService(mockDB)
+
+	require.NotNil(t, departmentService)
+	require.Equal(t, mockDB, departmentService.Database)
+}
+
+func TestGetDepartments(t *testing.T) {
+	service, mock := newMockDepartmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT "id", "code", "name" FROM "department" ORDER BY "code"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name"}).
+			AddRow(1, "CS", "Computer Science").
+			AddRow(2, "DES", "Design"))
+
+	departments, err := service.GetDepartments(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []models.Department{
+		{ID: 1, Code: "CS", Name: "Computer Science"},
+		{ID: 2, Code: "DES", Name: "Design"},
+	}, departments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDepartment(t *testing.T) {
+	service, mock := newMockDepartmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`FROM "department" WHERE "id" = \$1`).
+		WithArgs(9).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := service.GetDepartment(context.Background(), 9)
+	require.ErrorIs(t, err, services.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateDepartment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockDepartmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`INSERT INTO "department" \(code, name\) VALUES \(\$1, \$2\) RETURNING "id"`).
+			WithArgs("CS", "Computer Science").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		department, err := service.CreateDepartment(context.Background(), computerScience)
+		require.NoError(t, err)
+		require.Equal(t, 1, department.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate Code", func(t *testing.T) {
+		service, mock := newMockDepartmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`INSERT INTO "department"`).
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		_, err := service.CreateDepartment(context.Background(), computerScience)
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateDepartment(t *testing.T) {
+	service, mock := newMockDepartmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectExec(`UPDATE "department" SET "code" = \$1, "name" = \$2 WHERE "id" = \$3`).
+		WithArgs("CS", "Computer Science", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	department, err := service.UpdateDepartment(context.Background(), 1, computerScience)
+	require.NoError(t, err)
+	require.Equal(t, 1, department.ID)
+
+	mock.ExpectExec(`UPDATE "department"`).
+		WithArgs("CS", "Computer Science", 9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = service.UpdateDepartment(context.Background(), 9, computerScience)
+	require.ErrorIs(t, err, services.ErrNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDepartment(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      sql.Result
+		dbError     error
+		expectedErr error
+	}{
+		{name: "Success", result: sqlmock.NewResult(0, 1)},
+		{name: "Not Found", result: sqlmock.NewResult(0, 0), expectedErr: services.ErrNotFound},
+		{name: "Has Courses", dbError: &pq.Error{Code: "23503"}, expectedErr: services.ErrConflict},
+		{name: "Database Error", dbError: errors.New("database error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockDepartmentService(t)
+			defer service.Database.Close()
+
+			exec := mock.ExpectExec(`DELETE FROM "department" WHERE "id" = \$1`).WithArgs(1)
+			if tt.dbError != nil {
+				exec.WillReturnError(tt.dbError)
+			} else {
+				exec.WillReturnResult(tt.result)
+			}
+
+			err := service.DeleteDepartment(context.Background(), 1)
+			switch {
+			case tt.expectedErr != nil:
+				require.ErrorIs(t, err, tt.expectedErr)
+			case tt.dbError != nil:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockDepartmentService(t *testing.T) (services.DepartmentService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.DepartmentService{Database: db}
+
+	return service, mock
+}
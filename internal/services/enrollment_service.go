@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+type EnrollmentService struct {
+	Database *sql.DB
+}
+
+func NewEnrollmentService(db *sql.DB) *EnrollmentService {
+	return &EnrollmentService{
+		Database: db,
+	}
+}
+
+// GetCourseEnrollments returns the enrollments of the course with the given
+// ID in the terms selected by term, oldest first.
+func (e EnrollmentService) GetCourseEnrollments(ctx context.Context, courseID int, term TermScope) ([]models.Enrollment, error) {
+	if err := e.ensureExists(ctx, `SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)`, courseID); err != nil {
+		return []models.Enrollment{}, fmt.Errorf("[in services.GetCourseEnrollments] course with ID %d: %w", courseID, err)
+	}
+	query := `
+	SELECT person_id, course_id, term_id, role, enrolled_at
+		FROM person_course
+		WHERE course_id = $1`
+	cond, args := term.condition("term_id", []interface{}{courseID})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	query += " ORDER BY enrolled_at, person_id"
+
+	enrollments, err := e.queryEnrollments(ctx, query, args...)
+	if err != nil {
+		return []models.Enrollment{}, fmt.Errorf("[in services.GetCourseEnrollments] %w", err)
+	}
+	return enrollments, nil
+}
+
+// GetPersonEnrollments returns the enrollments of the person with the given
+// ID in the terms selected by term, oldest first.
+func (e EnrollmentService) GetPersonEnrollments(ctx context.Context, personID int, term TermScope) ([]models.Enrollment, error) {
+	if err := e.ensureExists(ctx, `SELECT EXISTS(SELECT 1 FROM "person" WHERE "id" = $1)`, personID); err != nil {
+		return []models.Enrollment{}, fmt.Errorf("[in services.GetPersonEnrollments] person with ID %d: %w", personID, err)
+	}
+	query := `
+	SELECT person_id, course_id, term_id, role, enrolled_at
+		FROM person_course
+		WHERE person_id = $1`
+	cond, args := term.condition("term_id", []interface{}{personID})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	query += " ORDER BY enrolled_at, course_id"
+
+	enrollments, err := e.queryEnrollments(ctx, query, args...)
+	if err != nil {
+		return []models.Enrollment{}, fmt.Errorf("[in services.GetPersonEnrollments] %w", err)
+	}
+	return enrollments, nil
+}
+
+// GetCourseWaitlist returns the people waiting for a seat in the course with
+// the given ID in the terms selected by term, in the order they will be
+// enrolled. Each term has a waitlist of its own.
+func (e EnrollmentService) GetCourseWaitlist(ctx context.Context, courseID int, term TermScope) ([]models.Enrollment, error) {
+	if err := e.ensureExists(ctx, `SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)`, courseID); err != nil {
+		return []models.Enrollment{}, fmt.Errorf("[in services.GetCourseWaitlist] course with ID %d: %w", courseID, err)
+	}
+	query := `
+	SELECT person_id, course_id, term_id, waitlisted_at, ROW_NUMBER() OVER (PARTITION BY term_id ORDER BY id)
+		FROM course_waitlist
+		WHERE course_id = $1`
+	cond, args := term.condition("term_id", []interface{}{courseID})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	query += " ORDER BY term_id NULLS FIRST, id"
+
+	rows, err := e.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []models.Enrollment{}, fmt.Errorf("[in services.GetCourseWaitlist] failed to get waitlist: %w", classify(err))
+	}
+	defer rows.Close()
+
+	waitlist := []models.Enrollment{}
+	for rows.Next() {
+		entry := models.Enrollment{Role: models.RoleStudent, Status: models.EnrollmentStatusWaitlisted}
+		if err := rows.Scan(&entry.PersonID, &entry.CourseID, &entry.TermID, &entry.EnrolledAt, &entry.WaitlistPosition); err != nil {
+			return []models.Enrollment{}, fmt.Errorf("[in services.GetCourseWaitlist] failed to scan waitlist entry: %w", classify(err))
+		}
+		waitlist = append(waitlist, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Enrollment{}, fmt.Errorf("[in services.GetCourseWaitlist] failed to scan waitlist: %w", classify(err))
+	}
+	return waitlist, nil
+}
+
+// EnrollOptions changes what Enroll does with an enrollment it cannot make
+// as asked. Waitlist puts people on the waitlist of a full course instead of
+// failing, and OverrideConflicts, meant for administrators, enrolls people
+// in courses that clash with their timetable.
+type EnrollOptions struct {
+	Waitlist          bool
+	OverrideConflicts bool
+}
+
+// Enroll adds the enrollment's person to its course in its term, in the
+// enrollment's role or the default role for their type when it has none. A
+// role the person cannot hold is a constraint violation. When the course is
+// full for that term a student is placed at the end of the term's waitlist
+// if opts.Waitlist is set, and the call fails with ErrCourseFull otherwise;
+// the teaching staff always get a seat. Students missing a prerequisite of
+// the course get a MissingPrerequisitesError, and a course meeting at the
+// same time as one of the person's courses in the term gives a
+// ScheduleConflictError unless opts.OverrideConflicts is set. Taking a seat
+// that puts a student over their credit limit gives a CreditLimitError;
+// waitlisting does not count towards the limit, but waitlisted students are
+// only promoted into seats that keep them within it. Enrolling someone twice
+// in the same term is a conflict, and enrolling an unknown person or in an
+// unknown course fails with ErrNotFound.
+func (e EnrollmentService) Enroll(ctx context.Context, enrollment models.Enrollment, opts EnrollOptions) (models.Enrollment, error) {
+	personID, courseID, termID := enrollment.PersonID, enrollment.CourseID, enrollment.TermID
+
+	tx, err := e.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] failed to start transaction: %w", classify(err))
+	}
+
+	role, err := enrollmentRole(ctx, tx, personID, enrollment.Role)
+	if err != nil {
+		tx.Rollback()
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] %w", err)
+	}
+	seat, err := reserveSeat(ctx, tx, courseID, termID, role)
+	if err != nil {
+		tx.Rollback()
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] %w", err)
+	}
+
+	var enrolled bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT EXISTS(
+		SELECT 1 FROM person_course
+		WHERE person_id = $1 AND course_id = $2 AND term_id IS NOT DISTINCT FROM $3
+	)
+	`, personID, courseID, termID).Scan(&enrolled)
+	if err != nil {
+		tx.Rollback()
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] failed to check enrollment: %w", classify(err))
+	}
+	if enrolled {
+		tx.Rollback()
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] person %d is already enrolled in course %d: %w", personID, courseID, ErrConflict)
+	}
+	if role == models.RoleStudent {
+		if err := checkPrerequisites(ctx, tx, personID, courseID, termID); err != nil {
+			tx.Rollback()
+			return models.Enrollment{}, fmt.Errorf("[in services.Enroll] %w", err)
+		}
+	}
+	if !opts.OverrideConflicts {
+		if err := checkScheduleConflicts(ctx, tx, personID, courseID, termID); err != nil {
+			tx.Rollback()
+			return models.Enrollment{}, fmt.Errorf("[in services.Enroll] %w", err)
+		}
+	}
+
+	enrollment = models.Enrollment{PersonID: personID, CourseID: courseID, TermID: termID, Role: role}
+	switch {
+	case seat:
+		enrollment.Status = models.EnrollmentStatusEnrolled
+		err = tx.QueryRowContext(ctx, `
+		INSERT INTO person_course (person_id, course_id, term_id, role)
+		VALUES ($1, $2, $3, $4)
+		RETURNING enrolled_at
+		`, personID, courseID, termID, role).Scan(&enrollment.EnrolledAt)
+	case opts.Waitlist:
+		// The course is locked, so nobody can join the waitlist between the
+		// count and the insert.
+		enrollment.Status = models.EnrollmentStatusWaitlisted
+		err = tx.QueryRowContext(ctx, `
+		INSERT INTO course_waitlist (person_id, course_id, term_id)
+		VALUES ($1, $2, $3)
+		RETURNING waitlisted_at, (
+			SELECT COUNT(*) + 1 FROM course_waitlist
+			WHERE course_id = $2 AND term_id IS NOT DISTINCT FROM $3
+		)
+		`, personID, courseID, termID).Scan(&enrollment.EnrolledAt, &enrollment.WaitlistPosition)
+	default:
+		tx.Rollback()
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] course %d has no free seats: %w", courseID, ErrCourseFull)
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] failed to enroll person %d in course %d: %w", personID, courseID, classify(err))
+	}
+	if seat && role == models.RoleStudent {
+		if err := checkCreditLimit(ctx, tx, personID, termID); err != nil {
+			tx.Rollback()
+			return models.Enrollment{}, fmt.Errorf("[in services.Enroll] %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Enrollment{}, fmt.Errorf("[in services.Enroll] failed to commit transaction: %w", classify(err))
+	}
+	return enrollment, nil
+}
+
+// Unenroll removes the person from the course, or from its waitlist, in the
+// given term and gives any seat that frees up to the head of the waitlist.
+func (e EnrollmentService) Unenroll(ctx context.Context, personID, courseID int, termID *int) error {
+	tx, err := e.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[in services.Unenroll] failed to start transaction: %w", classify(err))
+	}
+
+	if _, err := lockCourse(ctx, tx, courseID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("[in services.Unenroll] %w", err)
+	}
+
+	for _, query := range []string{
+		`DELETE FROM person_course WHERE person_id = $1 AND course_id = $2 AND term_id IS NOT DISTINCT FROM $3`,
+		`DELETE FROM course_waitlist WHERE person_id = $1 AND course_id = $2 AND term_id IS NOT DISTINCT FROM $3`,
+	} {
+		result, err := tx.ExecContext(ctx, query, personID, courseID, termID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("[in services.Unenroll] failed to delete enrollment: %w", classify(err))
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("[in services.Unenroll] failed to get affected rows: %w", classify(err))
+		}
+		if rowsAffected == 0 {
+			continue
+		}
+
+		if err := promoteWaitlist(ctx, tx, courseID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("[in services.Unenroll] %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("[in services.Unenroll] failed to commit transaction: %w", classify(err))
+		}
+		return nil
+	}
+
+	tx.Rollback()
+	return fmt.Errorf("[in services.Unenroll] person %d is not enrolled in course %d: %w", personID, courseID, ErrNotFound)
+}
+
+// ensureExists runs an EXISTS query for id and fails with ErrNotFound when
+// it is false.
+func (e EnrollmentService) ensureExists(ctx context.Context, query string, id int) error {
+	var exists bool
+	if err := e.Database.QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check existence: %w", classify(err))
+	}
+	if !exists {
+		return fmt.Errorf("does not exist: %w", ErrNotFound)
+	}
+	return nil
+}
+
+func (e EnrollmentService) queryEnrollments(ctx context.Context, query string, args ...interface{}) ([]models.Enrollment, error) {
+	rows, err := e.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []models.Enrollment{}, fmt.Errorf("failed to get enrollments: %w", classify(err))
+	}
+	defer rows.Close()
+
+	enrollments := []models.Enrollment{}
+	for rows.Next() {
+		enrollment := models.Enrollment{Status: models.EnrollmentStatusEnrolled}
+		if err := rows.Scan(&enrollment.PersonID, &enrollment.CourseID, &enrollment.TermID, &enrollment.Role, &enrollment.EnrolledAt); err != nil {
+			return []models.Enrollment{}, fmt.Errorf("failed to scan enrollment: %w", classify(err))
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Enrollment{}, fmt.Errorf("failed to scan enrollments: %w", classify(err))
+	}
+	return enrollments, nil
+}
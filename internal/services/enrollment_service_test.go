@@ -0,0 +1,435 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnrollmentService(t *testing.T) {
+	var mockDB *sql.DB
+
+	enrollmentService := services.NewEnrollmentService(mockDB)
+
+	require.NotNil(t, enrollmentService)
+	require.Equal(t, mockDB, enrollmentService.Database)
+}
+
+func TestGetCourseEnrollments(t *testing.T) {
+	enrolledAt := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`SELECT person_id, course_id, term_id, role, enrolled_at FROM person_course WHERE course_id = \$1 ORDER BY enrolled_at, person_id`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"person_id", "course_id", "term_id", "role", "enrolled_at"}).AddRow(3, 1, nil, "student", enrolledAt))
+
+		enrollments, err := service.GetCourseEnrollments(context.Background(), 1, services.TermScope{})
+		require.NoError(t, err)
+		require.Equal(t, []models.Enrollment{{PersonID: 3, CourseID: 1, Role: models.RoleStudent, Status: models.EnrollmentStatusEnrolled, EnrolledAt: enrolledAt}}, enrollments)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.GetCourseEnrollments(context.Background(), 9, services.TermScope{})
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetPersonEnrollments(t *testing.T) {
+	enrolledAt := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	termID := 2
+
+	service, mock := newMockEnrollmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "person" WHERE "id" = \$1\)`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`FROM person_course WHERE person_id = \$1 AND term_id IN \(SELECT id FROM term WHERE start_date <= CURRENT_DATE AND end_date >= CURRENT_DATE\) ORDER BY enrolled_at, course_id`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"person_id", "course_id", "term_id", "role", "enrolled_at"}).AddRow(3, 1, 2, "teaching_assistant", enrolledAt))
+
+	enrollments, err := service.GetPersonEnrollments(context.Background(), 3, services.TermScope{When: services.TermCurrent})
+	require.NoError(t, err)
+	require.Equal(t, []models.Enrollment{{PersonID: 3, CourseID: 1, TermID: &termID, Role: models.RoleTeachingAssistant, Status: models.EnrollmentStatusEnrolled, EnrolledAt: enrolledAt}}, enrollments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCourseWaitlist(t *testing.T) {
+	waitlistedAt := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	termID := 2
+
+	service, mock := newMockEnrollmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`ROW_NUMBER\(\) OVER \(PARTITION BY term_id ORDER BY id\) FROM course_waitlist WHERE course_id = \$1 AND term_id = \$2 ORDER BY term_id NULLS FIRST, id`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"person_id", "course_id", "term_id", "waitlisted_at", "row_number"}).
+			AddRow(4, 1, 2, waitlistedAt, 1).
+			AddRow(5, 1, 2, waitlistedAt, 2))
+
+	waitlist, err := service.GetCourseWaitlist(context.Background(), 1, services.TermScope{ID: 2})
+	require.NoError(t, err)
+	require.Equal(t, []models.Enrollment{
+		{PersonID: 4, CourseID: 1, TermID: &termID, Role: models.RoleStudent, Status: models.EnrollmentStatusWaitlisted, EnrolledAt: waitlistedAt, WaitlistPosition: 1},
+		{PersonID: 5, CourseID: 1, TermID: &termID, Role: models.RoleStudent, Status: models.EnrollmentStatusWaitlisted, EnrolledAt: waitlistedAt, WaitlistPosition: 2},
+	}, waitlist)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnroll(t *testing.T) {
+	enrolledAt := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectSeat(mock, 3, 1, 30, 29)
+		expectNotEnrolled(mock, 3, 1)
+		expectPrerequisitesMet(mock, 3, 1, nil)
+		expectNoScheduleConflicts(mock, 3, 1, nil)
+		mock.ExpectQuery(`INSERT INTO person_course \(person_id, course_id, term_id, role\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING enrolled_at`).
+			WithArgs(3, 1, nil, "student").
+			WillReturnRows(sqlmock.NewRows([]string{"enrolled_at"}).AddRow(enrolledAt))
+		expectCreditLoad(mock, 3, nil, "student", 18, 12)
+		mock.ExpectCommit()
+
+		enrollment, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+		require.NoError(t, err)
+		require.Equal(t, models.Enrollment{PersonID: 3, CourseID: 1, Role: models.RoleStudent, Status: models.EnrollmentStatusEnrolled, EnrolledAt: enrolledAt}, enrollment)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("In Term", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+		termID := 2
+
+		mock.ExpectBegin()
+		expectPersonType(mock, 3, "student")
+		expectCourseLock(mock, 1, 30)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM person_course pc WHERE pc.course_id = \$1 AND pc.term_id IS NOT DISTINCT FROM \$2 AND pc.role = 'student'`).
+			WithArgs(1, &termID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(3, 1, &termID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		expectPrerequisitesMet(mock, 3, 1, &termID)
+		expectNoScheduleConflicts(mock, 3, 1, &termID)
+		mock.ExpectQuery(`INSERT INTO person_course`).
+			WithArgs(3, 1, &termID, "student").
+			WillReturnRows(sqlmock.NewRows([]string{"enrolled_at"}).AddRow(enrolledAt))
+		expectCreditLoad(mock, 3, &termID, "student", 18, 15)
+		mock.ExpectCommit()
+
+		enrollment, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1, TermID: &termID}, services.EnrollOptions{})
+		require.NoError(t, err)
+		require.Equal(t, &termID, enrollment.TermID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Course Full", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectSeat(mock, 3, 1, 30, 30)
+		expectNotEnrolled(mock, 3, 1)
+		expectPrerequisitesMet(mock, 3, 1, nil)
+		expectNoScheduleConflicts(mock, 3, 1, nil)
+		mock.ExpectRollback()
+
+		_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+		require.ErrorIs(t, err, services.ErrCourseFull)
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Waitlisted", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectSeat(mock, 3, 1, 30, 30)
+		expectNotEnrolled(mock, 3, 1)
+		expectPrerequisitesMet(mock, 3, 1, nil)
+		expectNoScheduleConflicts(mock, 3, 1, nil)
+		mock.ExpectQuery(`INSERT INTO course_waitlist \(person_id, course_id, term_id\) VALUES \(\$1, \$2, \$3\) RETURNING waitlisted_at`).
+			WithArgs(3, 1, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"waitlisted_at", "position"}).AddRow(enrolledAt, 2))
+		mock.ExpectCommit()
+
+		enrollment, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{Waitlist: true})
+		require.NoError(t, err)
+		require.Equal(t, models.Enrollment{PersonID: 3, CourseID: 1, Role: models.RoleStudent, Status: models.EnrollmentStatusWaitlisted, EnrolledAt: enrolledAt, WaitlistPosition: 2}, enrollment)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Professor Ignores Capacity", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectPersonType(mock, 3, "professor")
+		expectCourseLock(mock, 1, 30)
+		expectNotEnrolled(mock, 3, 1)
+		expectNoScheduleConflicts(mock, 3, 1, nil)
+		mock.ExpectQuery(`INSERT INTO person_course`).
+			WithArgs(3, 1, nil, models.RoleInstructor).
+			WillReturnRows(sqlmock.NewRows([]string{"enrolled_at"}).AddRow(enrolledAt))
+		mock.ExpectCommit()
+
+		enrollment, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+		require.NoError(t, err)
+		require.Equal(t, models.RoleInstructor, enrollment.Role)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Student Teaching Assistant", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectPersonType(mock, 3, "student")
+		expectCourseLock(mock, 1, 30)
+		expectNotEnrolled(mock, 3, 1)
+		expectNoScheduleConflicts(mock, 3, 1, nil)
+		mock.ExpectQuery(`INSERT INTO person_course`).
+			WithArgs(3, 1, nil, models.RoleTeachingAssistant).
+			WillReturnRows(sqlmock.NewRows([]string{"enrolled_at"}).AddRow(enrolledAt))
+		mock.ExpectCommit()
+
+		enrollment, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1, Role: models.RoleTeachingAssistant}, services.EnrollOptions{})
+		require.NoError(t, err)
+		require.Equal(t, models.RoleTeachingAssistant, enrollment.Role)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Student Instructor", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectPersonType(mock, 3, "student")
+		mock.ExpectRollback()
+
+		_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1, Role: models.RoleCoInstructor}, services.EnrollOptions{})
+		require.ErrorIs(t, err, services.ErrConstraintViolation)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Enrolled", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectSeat(mock, 3, 1, nil, 0)
+		mock.ExpectQuery(`SELECT EXISTS\( SELECT 1 FROM person_course`).
+			WithArgs(3, 1, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectPersonType(mock, 3, "student")
+		mock.ExpectQuery(`SELECT capacity FROM course WHERE id = \$1 FOR UPDATE`).
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Insert Error", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectSeat(mock, 3, 1, nil, 0)
+		expectNotEnrolled(mock, 3, 1)
+		expectPrerequisitesMet(mock, 3, 1, nil)
+		expectNoScheduleConflicts(mock, 3, 1, nil)
+		mock.ExpectQuery(`INSERT INTO person_course`).
+			WithArgs(3, 1, nil, "student").
+			WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
+
+		_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+		require.ErrorIs(t, err, services.ErrInvalidReference)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUnenroll(t *testing.T) {
+	t.Run("Enrolled", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectCourseLock(mock, 1, 30)
+		mock.ExpectExec(`DELETE FROM person_course WHERE person_id = \$1 AND course_id = \$2 AND term_id IS NOT DISTINCT FROM \$3`).
+			WithArgs(3, 1, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPromotion(mock, 1, 30)
+		mock.ExpectCommit()
+
+		require.NoError(t, service.Unenroll(context.Background(), 3, 1, nil))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Waitlisted", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectCourseLock(mock, 1, 30)
+		mock.ExpectExec(`DELETE FROM person_course`).
+			WithArgs(3, 1, nil).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM course_waitlist WHERE person_id = \$1 AND course_id = \$2 AND term_id IS NOT DISTINCT FROM \$3`).
+			WithArgs(3, 1, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPromotion(mock, 1, 30)
+		mock.ExpectCommit()
+
+		require.NoError(t, service.Unenroll(context.Background(), 3, 1, nil))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Enrolled", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectCourseLock(mock, 1, 30)
+		mock.ExpectExec(`DELETE FROM person_course`).
+			WithArgs(3, 1, nil).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM course_waitlist`).
+			WithArgs(3, 1, nil).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		require.ErrorIs(t, service.Unenroll(context.Background(), 3, 1, nil), services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// expectCourseLock expects the course row to be locked. A nil capacity is
+// an unlimited course.
+func expectCourseLock(mock sqlmock.Sqlmock, courseID int, capacity any) {
+	mock.ExpectQuery(`SELECT capacity FROM course WHERE id = \$1 FOR UPDATE`).
+		WithArgs(courseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(capacity))
+}
+
+// expectPersonType expects the person's type to be read to work out their
+// role in a course.
+func expectPersonType(mock sqlmock.Sqlmock, personID int, personType string) {
+	mock.ExpectQuery(`SELECT type FROM person WHERE id = \$1`).
+		WithArgs(personID).
+		WillReturnRows(sqlmock.NewRows([]string{"type"}).AddRow(personType))
+}
+
+// expectSeat expects a student to be checked against the course's capacity
+// while enrolled students already hold seats.
+func expectSeat(mock sqlmock.Sqlmock, personID, courseID int, capacity any, enrolled int) {
+	expectPersonType(mock, personID, "student")
+	expectCourseSeat(mock, courseID, capacity, enrolled)
+}
+
+// expectCourseSeat is expectSeat for a student whose type has already been
+// read.
+func expectCourseSeat(mock sqlmock.Sqlmock, courseID int, capacity any, enrolled int) {
+	expectCourseLock(mock, courseID, capacity)
+	if capacity != nil {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM person_course pc`).
+			WithArgs(courseID, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(enrolled))
+	}
+}
+
+func expectNotEnrolled(mock sqlmock.Sqlmock, personID, courseID int) {
+	mock.ExpectQuery(`SELECT EXISTS\( SELECT 1 FROM person_course WHERE person_id = \$1 AND course_id = \$2 AND term_id IS NOT DISTINCT FROM \$3 \)`).
+		WithArgs(personID, courseID, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+}
+
+// expectPrerequisitesMet expects the person's prerequisites for the course
+// to be checked and none to be missing.
+func expectPrerequisitesMet(mock sqlmock.Sqlmock, personID, courseID int, termID any) {
+	mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course_prerequisite cp .* AND NOT EXISTS`).
+		WithArgs(personID, courseID, termID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}))
+}
+
+// expectNoScheduleConflicts expects the course to be checked against the
+// person's timetable and to clash with nothing.
+func expectNoScheduleConflicts(mock sqlmock.Sqlmock, personID, courseID int, termID any) {
+	mock.ExpectQuery(`FROM person_course pc JOIN course c ON c.id = pc.course_id JOIN course_meeting m`).
+		WithArgs(personID, courseID, termID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "days", "start_time", "end_time", "location", "room_id"}))
+}
+
+// expectCreditLoad expects the person's credit load in the term to be
+// checked against their limit.
+func expectCreditLoad(mock sqlmock.Sqlmock, personID int, termID any, personType string, limit, credits int) {
+	mock.ExpectQuery(`SELECT p.type, COALESCE\(p.max_credits, \$3\), \( SELECT COALESCE\(SUM\(lc.credits\), 0\)`).
+		WithArgs(personID, termID, services.DefaultMaxCredits).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "limit", "credits"}).AddRow(personType, limit, credits))
+}
+
+// expectPromotion expects the head of the course's waitlist to be moved
+// into any free seats.
+func expectPromotion(mock sqlmock.Sqlmock, courseID int, capacity any) {
+	expectCourseLock(mock, courseID, capacity)
+	mock.ExpectExec(`WITH queue AS \(.*\), promoted AS \( DELETE FROM course_waitlist`).
+		WithArgs(courseID, services.DefaultMaxCredits).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func newMockEnrollmentService(t *testing.T) (services.EnrollmentService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.EnrollmentService{Database: db}
+
+	return service, mock
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+// Error kinds returned by the services. Callers should test for them with
+// errors.Is rather than inspecting error strings.
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrInvalidReference    = errors.New("invalid reference")
+	ErrConstraintViolation = errors.New("constraint violation")
+	ErrUnavailable         = errors.New("unavailable")
+	ErrForbidden           = errors.New("forbidden")
+)
+
+// ErrCourseFull is returned when a student asks for a seat in a course that
+// has none left. It is a conflict.
+var ErrCourseFull = fmt.Errorf("course is full: %w", ErrConflict)
+
+// Error pairs an underlying failure with the kind it was classified as.
+// Its message is the message of the underlying failure so that wrapping
+// with Error does not change what gets logged.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// withKind tags err with the given kind.
+func withKind(kind, err error) error {
+	return &Error{Kind: kind, Err: err}
+}
+
+// classify translates database level failures (sql.ErrNoRows, pq error
+// codes, dropped connections) into one of the service error kinds. Errors
+// that do not match a known case are returned unchanged.
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return withKind(ErrNotFound, err)
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone), errors.Is(err, context.DeadlineExceeded):
+		return withKind(ErrUnavailable, err)
+	case errors.As(err, &pqErr):
+		switch pqErr.Code {
+		case "23505", "40001", "40P01": // unique_violation, serialization_failure, deadlock_detected
+			return withKind(ErrConflict, err)
+		case "23503": // foreign_key_violation
+			return withKind(ErrInvalidReference, err)
+		case "23502", "23514", "22001", "22003": // not_null, check, string too long, numeric out of range
+			return withKind(ErrConstraintViolation, err)
+		case "22P02", "22007", "22008": // invalid text representation, invalid datetime format, datetime out of range
+			// Client supplied values, such as those in a tampered cursor,
+			// that do not parse as the column's type.
+			return withKind(ErrConstraintViolation, err)
+		case "57P03": // cannot_connect_now
+			return withKind(ErrUnavailable, err)
+		}
+		switch pqErr.Code.Class() {
+		case "08", "53": // connection exception, insufficient resources
+			return withKind(ErrUnavailable, err)
+		case "23":
+			return withKind(ErrConstraintViolation, err)
+		}
+	}
+	return err
+}
+
+// AmbiguousError is returned when a lookup by a non-unique key, such as a
+// person's first name, matches more than one row. It is a conflict and
+// carries the matching rows so callers can offer them to the client.
+type AmbiguousError struct {
+	Candidates []models.Person
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%d people match the given name", len(e.Candidates))
+}
+
+func (e *AmbiguousError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// PrerequisiteCycleError is returned when adding a prerequisite would make a
+// course require itself. Cycle lists the course IDs around the cycle,
+// starting and ending with the course that was being edited. It is a
+// conflict.
+type PrerequisiteCycleError struct {
+	Cycle []int
+}
+
+func (e *PrerequisiteCycleError) Error() string {
+	steps := make([]string, len(e.Cycle))
+	for i, id := range e.Cycle {
+		steps[i] = strconv.Itoa(id)
+	}
+	return "prerequisite cycle: " + strings.Join(steps, " -> ")
+}
+
+func (e *PrerequisiteCycleError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// MissingPrerequisitesError is returned when a student is enrolled in a
+// course without having completed all of its prerequisites. It is a
+// conflict and carries the prerequisites that are missing.
+type MissingPrerequisitesError struct {
+	CourseID int
+	Missing  []models.Course
+}
+
+func (e *MissingPrerequisitesError) Error() string {
+	return fmt.Sprintf("%d prerequisites of course %d are missing", len(e.Missing), e.CourseID)
+}
+
+func (e *MissingPrerequisitesError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ScheduleConflictError is returned when a person is enrolled in a course
+// that meets at the same time as courses they already take. It is a conflict
+// and carries the clashing meetings.
+type ScheduleConflictError struct {
+	CourseID  int
+	Conflicts []models.ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("course %d clashes with %d meetings of the person's other courses", e.CourseID, len(e.Conflicts))
+}
+
+func (e *ScheduleConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// CreditLimitError is returned when an enrollment would take a student over
+// their credit load limit. It is a conflict and carries the limit and the
+// load the student would have had.
+type CreditLimitError struct {
+	PersonID int
+	Limit    int
+	Credits  int
+}
+
+func (e *CreditLimitError) Error() string {
+	return fmt.Sprintf("person %d would take %d credits, over their limit of %d", e.PersonID, e.Credits, e.Limit)
+}
+
+func (e *CreditLimitError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// AdviseesError is returned when a professor who still advises students is
+// deleted without naming a professor to take the students over. It is a
+// conflict and lists the IDs of the advisees.
+type AdviseesError struct {
+	ProfessorID int
+	Advisees    []int
+}
+
+func (e *AdviseesError) Error() string {
+	return fmt.Sprintf("professor %d still advises %d students", e.ProfessorID, len(e.Advisees))
+}
+
+func (e *AdviseesError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// RoomConflictError is returned when a course is booked into a room that
+// another course has at the same time. It is a conflict and carries the
+// meetings of the other courses holding the room.
+type RoomConflictError struct {
+	CourseID  int
+	Conflicts []models.ScheduleConflict
+}
+
+func (e *RoomConflictError) Error() string {
+	return fmt.Sprintf("course %d is booked into rooms taken by %d meetings of other courses", e.CourseID, len(e.Conflicts))
+}
+
+func (e *RoomConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
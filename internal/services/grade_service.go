@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+type GradeService struct {
+	Database *sql.DB
+}
+
+func NewGradeService(db *sql.DB) *GradeService {
+	return &GradeService{
+		Database: db,
+	}
+}
+
+// GetGradeScale returns the grading scale, highest grade points first and
+// letters that do not count towards a GPA last.
+func (g GradeService) GetGradeScale(ctx context.Context) ([]models.GradeScaleEntry, error) {
+	rows, err := g.Database.QueryContext(ctx, `
+	SELECT letter, points
+		FROM grade_scale
+		ORDER BY points DESC NULLS LAST, letter
+	`)
+	if err != nil {
+		return []models.GradeScaleEntry{}, fmt.Errorf("[in services.GetGradeScale] failed to get grade scale: %w", classify(err))
+	}
+	defer rows.Close()
+
+	scale := []models.GradeScaleEntry{}
+	for rows.Next() {
+		var entry models.GradeScaleEntry
+		if err := rows.Scan(&entry.Letter, &entry.Points); err != nil {
+			return []models.GradeScaleEntry{}, fmt.Errorf("[in services.GetGradeScale] failed to scan grade: %w", classify(err))
+		}
+		scale = append(scale, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.GradeScaleEntry{}, fmt.Errorf("[in services.GetGradeScale] failed to scan grades: %w", classify(err))
+	}
+	return scale, nil
+}
+
+// SetGradeScaleEntry adds the letter to the grading scale or changes the
+// grade points it is worth. Grades already posted keep their points.
+func (g GradeService) SetGradeScaleEntry(ctx context.Context, entry models.GradeScaleEntry) (models.GradeScaleEntry, error) {
+	_, err := g.Database.ExecContext(ctx, `
+	INSERT INTO grade_scale (letter, points)
+	VALUES ($1, $2)
+	ON CONFLICT (letter) DO UPDATE SET points = EXCLUDED.points
+	`, entry.Letter, entry.Points)
+	if err != nil {
+		return models.GradeScaleEntry{}, fmt.Errorf("[in services.SetGradeScaleEntry] failed to set grade: %w", classify(err))
+	}
+	return entry, nil
+}
+
+// DeleteGradeScaleEntry removes the letter from the grading scale. Letters
+// that have been posted as grades cannot be removed.
+func (g GradeService) DeleteGradeScaleEntry(ctx context.Context, letter string) error {
+	result, err := g.Database.ExecContext(ctx, `DELETE FROM grade_scale WHERE letter = $1`, letter)
+	if err != nil {
+		err = classify(err)
+		if errors.Is(err, ErrInvalidReference) {
+			err = withKind(ErrConflict, err)
+		}
+		return fmt.Errorf("[in services.DeleteGradeScaleEntry] failed to delete grade: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteGradeScaleEntry] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.DeleteGradeScaleEntry] grade %q is not on the grading scale: %w", letter, ErrNotFound)
+	}
+	return nil
+}
+
+// GetCourseGrades returns the grades of the students of the course,
+// including students who have not been graded yet, ordered by term and
+// person.
+func (g GradeService) GetCourseGrades(ctx context.Context, courseID int, term TermScope) ([]models.Grade, error) {
+	var exists bool
+	err := g.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
+    `, courseID).Scan(&exists)
+	if err != nil {
+		return []models.Grade{}, fmt.Errorf("[in services.GetCourseGrades] failed to check course existence: %w", classify(err))
+	}
+	if !exists {
+		return []models.Grade{}, fmt.Errorf("[in services.GetCourseGrades] course with ID %d does not exist: %w", courseID, ErrNotFound)
+	}
+
+	query := `
+	SELECT pc.person_id, pc.course_id, pc.term_id, pc.grade, pc.grade_points
+		FROM person_course pc
+		WHERE pc.course_id = $1
+		AND pc.role = 'student'`
+	cond, args := term.condition("pc.term_id", []interface{}{courseID})
+	if cond != "" {
+		query += " AND " + cond
+	}
+	query += " ORDER BY pc.term_id NULLS FIRST, pc.person_id"
+
+	rows, err := g.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []models.Grade{}, fmt.Errorf("[in services.GetCourseGrades] failed to get grades: %w", classify(err))
+	}
+	defer rows.Close()
+
+	grades := []models.Grade{}
+	for rows.Next() {
+		var grade models.Grade
+		if err := rows.Scan(&grade.PersonID, &grade.CourseID, &grade.TermID, &grade.Grade, &grade.GradePoints); err != nil {
+			return []models.Grade{}, fmt.Errorf("[in services.GetCourseGrades] failed to scan grade: %w", classify(err))
+		}
+		grades = append(grades, grade)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Grade{}, fmt.Errorf("[in services.GetCourseGrades] failed to scan grades: %w", classify(err))
+	}
+	return grades, nil
+}
+
+// PostGrades stores the grades of the submission for the course in the
+// submission's term, replacing any grades posted before. Only an instructor
+// or co-instructor of the course in that term may post grades; anyone else
+// gets ErrForbidden. Every graded person must be a student of the course in
+// the term and every letter must be on the grading scale, otherwise nothing
+// is stored and the call fails with ErrInvalidReference.
+func (g GradeService) PostGrades(ctx context.Context, courseID int, submission models.GradeSubmission) ([]models.Grade, error) {
+	termID := submission.TermID
+
+	tx, err := g.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return []models.Grade{}, fmt.Errorf("[in services.PostGrades] failed to start transaction: %w", classify(err))
+	}
+
+	if _, err := lockCourse(ctx, tx, courseID); err != nil {
+		tx.Rollback()
+		return []models.Grade{}, fmt.Errorf("[in services.PostGrades] %w", err)
+	}
+
+	var teaches bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT EXISTS(
+		SELECT 1 FROM person_course pc
+		WHERE pc.person_id = $1 AND pc.course_id = $2 AND pc.term_id IS NOT DISTINCT FROM $3
+		AND pc.role IN ('instructor', 'co_instructor')
+	)
+	`, submission.ProfessorID, courseID, termID).Scan(&teaches)
+	if err != nil {
+		tx.Rollback()
+		return []models.Grade{}, fmt.Errorf("[in services.PostGrades] failed to check professor: %w", classify(err))
+	}
+	if !teaches {
+		tx.Rollback()
+		return []models.Grade{}, fmt.Errorf("[in services.PostGrades] person %d does not teach course %d in the term: %w", submission.ProfessorID, courseID, ErrForbidden)
+	}
+
+	scale, err := gradeScale(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return []models.Grade{}, fmt.Errorf("[in services.PostGrades] %w", err)
+	}
+
+	grades := make([]models.Grade, 0, len(submission.Grades))
+	for _, entry := range submission.Grades {
+		points, ok := scale[entry.Grade]
+		if !ok {
+			tx.Rollback()
+			return []models.Grade{}, fmt.Errorf("[in services.PostGrades] grade %q is not on the grading scale: %w", entry.Grade, ErrInvalidReference)
+		}
+
+		result, err := tx.ExecContext(ctx, `
+		UPDATE person_course pc
+		SET grade = $4, grade_points = $5
+		WHERE pc.role = 'student'
+		AND pc.person_id = $1 AND pc.course_id = $2 AND pc.term_id IS NOT DISTINCT FROM $3
+		`, entry.PersonID, courseID, termID, entry.Grade, points)
+		if err != nil {
+			tx.Rollback()
+			return []models.Grade{}, fmt.Errorf("[in services.PostGrades] failed to post grade: %w", classify(err))
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return []models.Grade{}, fmt.Errorf("[in services.PostGrades] failed to get affected rows: %w", classify(err))
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return []models.Grade{}, fmt.Errorf("[in services.PostGrades] person %d is not a student of course %d in the term: %w", entry.PersonID, courseID, ErrInvalidReference)
+		}
+
+		letter := entry.Grade
+		grades = append(grades, models.Grade{PersonID: entry.PersonID, CourseID: courseID, TermID: termID, Grade: &letter, GradePoints: points})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return []models.Grade{}, fmt.Errorf("[in services.PostGrades] failed to commit transaction: %w", classify(err))
+	}
+	return grades, nil
+}
+
+// gradeScale returns the grade points of each letter of the grading scale.
+func gradeScale(ctx context.Context, tx *sql.Tx) (map[string]*float64, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT letter, points FROM grade_scale`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grade scale: %w", classify(err))
+	}
+	defer rows.Close()
+
+	scale := make(map[string]*float64)
+	for rows.Next() {
+		var letter string
+		var points *float64
+		if err := rows.Scan(&letter, &points); err != nil {
+			return nil, fmt.Errorf("failed to scan grade: %w", classify(err))
+		}
+		scale[letter] = points
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan grades: %w", classify(err))
+	}
+	return scale, nil
+}
+
+// GetTranscript returns the transcript of the person with the given ID,
+// listing only the courses they took as a student.
+func (g GradeService) GetTranscript(ctx context.Context, personID int) (models.Transcript, error) {
+	var exists bool
+	err := g.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "person" WHERE "id" = $1)
+    `, personID).Scan(&exists)
+	if err != nil {
+		return models.Transcript{}, fmt.Errorf("[in services.GetTranscript] failed to check person existence: %w", classify(err))
+	}
+	if !exists {
+		return models.Transcript{}, fmt.Errorf("[in services.GetTranscript] person with ID %d does not exist: %w", personID, ErrNotFound)
+	}
+
+	rows, err := g.Database.QueryContext(ctx, `
+	SELECT pc.term_id, COALESCE(t.name, ''), c.id, c.name, c.credits, pc.grade, pc.grade_points
+		FROM person_course pc
+		JOIN course c ON c.id = pc.course_id
+		LEFT JOIN term t ON t.id = pc.term_id
+		WHERE pc.person_id = $1 AND pc.role = 'student'
+		ORDER BY t.start_date NULLS FIRST, pc.term_id, c.id
+	`, personID)
+	if err != nil {
+		return models.Transcript{}, fmt.Errorf("[in services.GetTranscript] failed to get courses: %w", classify(err))
+	}
+	defer rows.Close()
+
+	transcript := models.Transcript{PersonID: personID, Terms: []models.TranscriptTerm{}}
+	var all, term gpa
+	for rows.Next() {
+		var termID *int
+		var termName string
+		var course models.TranscriptCourse
+		if err := rows.Scan(&termID, &termName, &course.CourseID, &course.CourseName, &course.Credits, &course.Grade, &course.GradePoints); err != nil {
+			return models.Transcript{}, fmt.Errorf("[in services.GetTranscript] failed to scan course: %w", classify(err))
+		}
+
+		last := len(transcript.Terms) - 1
+		if last < 0 || !sameTerm(transcript.Terms[last].TermID, termID) {
+			if last >= 0 {
+				transcript.Terms[last].GPA = term.value()
+			}
+			transcript.Terms = append(transcript.Terms, models.TranscriptTerm{TermID: termID, TermName: termName})
+			term = gpa{}
+			last++
+		}
+		transcript.Terms[last].Courses = append(transcript.Terms[last].Courses, course)
+		term.add(course)
+		all.add(course)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Transcript{}, fmt.Errorf("[in services.GetTranscript] failed to scan courses: %w", classify(err))
+	}
+	if last := len(transcript.Terms) - 1; last >= 0 {
+		transcript.Terms[last].GPA = term.value()
+	}
+	transcript.CumulativeGPA = all.value()
+	return transcript, nil
+}
+
+func sameTerm(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// completedCourse is the condition under which the person_course row
+// aliased enrollment counts as a completed course: it was taken as a
+// student and passed with a grade worth more than zero grade points.
+func completedCourse(enrollment string) string {
+	return enrollment + ".role = 'student' AND " + enrollment + ".grade_points > 0"
+}
+
+// gpa accumulates the grade points of courses weighted by their credits.
+type gpa struct {
+	points  float64
+	credits int
+}
+
+// add counts the course towards the GPA if it has grade points.
+func (g *gpa) add(course models.TranscriptCourse) {
+	if course.GradePoints == nil {
+		return
+	}
+	g.points += *course.GradePoints * float64(course.Credits)
+	g.credits += course.Credits
+}
+
+// value returns the GPA rounded to two decimal places, or nil if no credits
+// count towards it.
+func (g gpa) value() *float64 {
+	if g.credits == 0 {
+		return nil
+	}
+	avg := math.Round(g.points/float64(g.credits)*100) / 100
+	return &avg
+}
@@ -0,0 +1,265 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGradeService(t *testing.T) {
+	var mockDB *sql.DB
+
+	gradeService := services.NewGradeService(mockDB)
+
+	require.NotNil(t, gradeService)
+	require.Equal(t, mockDB, gradeService.Database)
+}
+
+func TestGetGradeScale(t *testing.T) {
+	service, mock := newMockGradeService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT letter, points FROM grade_scale ORDER BY points DESC NULLS LAST, letter`).
+		WillReturnRows(sqlmock.NewRows([]string{"letter", "points"}).AddRow("A", 4.0).AddRow("W", nil))
+
+	scale, err := service.GetGradeScale(context.Background())
+	require.NoError(t, err)
+	four := 4.0
+	require.Equal(t, []models.GradeScaleEntry{{Letter: "A", Points: &four}, {Letter: "W"}}, scale)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetGradeScaleEntry(t *testing.T) {
+	service, mock := newMockGradeService(t)
+	defer service.Database.Close()
+	points := 4.3
+
+	mock.ExpectExec(`INSERT INTO grade_scale \(letter, points\) VALUES \(\$1, \$2\) ON CONFLICT \(letter\) DO UPDATE SET points = EXCLUDED.points`).
+		WithArgs("A+", &points).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	entry, err := service.SetGradeScaleEntry(context.Background(), models.GradeScaleEntry{Letter: "A+", Points: &points})
+	require.NoError(t, err)
+	require.Equal(t, "A+", entry.Letter)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteGradeScaleEntry(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM grade_scale WHERE letter = \$1`).WithArgs("W").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Not On Scale",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM grade_scale`).WithArgs("W").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: services.ErrNotFound,
+		},
+		{
+			name: "Posted As A Grade",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM grade_scale`).WithArgs("W").WillReturnError(&pq.Error{Code: "23503"})
+			},
+			expectedErr: services.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockGradeService(t)
+			defer service.Database.Close()
+			tt.setup(mock)
+
+			err := service.DeleteGradeScaleEntry(context.Background(), "W")
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.expectedErr)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetCourseGrades(t *testing.T) {
+	service, mock := newMockGradeService(t)
+	defer service.Database.Close()
+	termID := 2
+	grade := "A"
+	points := 4.0
+
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT pc.person_id, pc.course_id, pc.term_id, pc.grade, pc.grade_points FROM person_course pc WHERE pc.course_id = \$1 AND pc.role = 'student' AND pc.term_id = \$2 ORDER BY pc.term_id NULLS FIRST, pc.person_id`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"person_id", "course_id", "term_id", "grade", "grade_points"}).
+			AddRow(3, 1, 2, "A", 4.0).
+			AddRow(4, 1, 2, nil, nil))
+
+	grades, err := service.GetCourseGrades(context.Background(), 1, services.TermScope{ID: 2})
+	require.NoError(t, err)
+	require.Equal(t, []models.Grade{
+		{PersonID: 3, CourseID: 1, TermID: &termID, Grade: &grade, GradePoints: &points},
+		{PersonID: 4, CourseID: 1, TermID: &termID},
+	}, grades)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostGrades(t *testing.T) {
+	termID := 2
+	submission := models.GradeSubmission{ProfessorID: 1, TermID: &termID, Grades: []models.GradeEntry{{PersonID: 3, Grade: "A-"}, {PersonID: 4, Grade: "W"}}}
+	teachesQuery := `SELECT EXISTS\( SELECT 1 FROM person_course pc WHERE pc.person_id = \$1 AND pc.course_id = \$2 AND pc.term_id IS NOT DISTINCT FROM \$3 AND pc.role IN \('instructor', 'co_instructor'\) \)`
+	updateQuery := `UPDATE person_course pc SET grade = \$4, grade_points = \$5 WHERE pc.role = 'student' AND pc.person_id = \$1 AND pc.course_id = \$2 AND pc.term_id IS NOT DISTINCT FROM \$3`
+	expectScale := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT letter, points FROM grade_scale`).
+			WillReturnRows(sqlmock.NewRows([]string{"letter", "points"}).AddRow("A-", 3.7).AddRow("W", nil))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockGradeService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectCourseLock(mock, 1, nil)
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, &termID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		expectScale(mock)
+		mock.ExpectExec(updateQuery).WithArgs(3, 1, &termID, "A-", 3.7).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(updateQuery).WithArgs(4, 1, &termID, "W", nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		grades, err := service.PostGrades(context.Background(), 1, submission)
+		require.NoError(t, err)
+		require.Len(t, grades, 2)
+		require.Equal(t, "A-", *grades[0].Grade)
+		require.Equal(t, 3.7, *grades[0].GradePoints)
+		require.Nil(t, grades[1].GradePoints)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not The Course Professor", func(t *testing.T) {
+		service, mock := newMockGradeService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectCourseLock(mock, 1, nil)
+		mock.ExpectQuery(teachesQuery).WithArgs(1, 1, &termID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		_, err := service.PostGrades(context.Background(), 1, submission)
+		require.ErrorIs(t, err, services.ErrForbidden)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Letter", func(t *testing.T) {
+		service, mock := newMockGradeService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectCourseLock(mock, 1, nil)
+		mock.ExpectQuery(teachesQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		expectScale(mock)
+		mock.ExpectRollback()
+
+		_, err := service.PostGrades(context.Background(), 1, models.GradeSubmission{ProfessorID: 1, TermID: &termID, Grades: []models.GradeEntry{{PersonID: 3, Grade: "Z"}}})
+		require.ErrorIs(t, err, services.ErrInvalidReference)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not On Roster", func(t *testing.T) {
+		service, mock := newMockGradeService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectCourseLock(mock, 1, nil)
+		mock.ExpectQuery(teachesQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		expectScale(mock)
+		mock.ExpectExec(updateQuery).WithArgs(3, 1, &termID, "A-", 3.7).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := service.PostGrades(context.Background(), 1, submission)
+		require.ErrorIs(t, err, services.ErrInvalidReference)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTranscript(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockGradeService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "person" WHERE "id" = \$1\)`).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`SELECT pc.term_id, COALESCE\(t.name, ''\), c.id, c.name, c.credits, pc.grade, pc.grade_points FROM person_course pc JOIN course c ON c.id = pc.course_id LEFT JOIN term t ON t.id = pc.term_id WHERE pc.person_id = \$1 AND pc.role = 'student' ORDER BY t.start_date NULLS FIRST, pc.term_id, c.id`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"term_id", "name", "id", "name", "credits", "grade", "grade_points"}).
+				AddRow(1, "Spring 2026", 1, "Programming", 4, "A", 4.0).
+				AddRow(1, "Spring 2026", 3, "UI Design", 3, "B+", 3.3).
+				AddRow(1, "Spring 2026", 4, "Statistics", 3, "W", nil).
+				AddRow(2, "Fall 2026", 2, "Databases", 3, "B", 3.0).
+				AddRow(2, "Fall 2026", 5, "Networks", 3, nil, nil))
+
+		transcript, err := service.GetTranscript(context.Background(), 3)
+		require.NoError(t, err)
+		require.Len(t, transcript.Terms, 2)
+		require.Equal(t, "Spring 2026", transcript.Terms[0].TermName)
+		require.Len(t, transcript.Terms[0].Courses, 3)
+		// Grade points are weighted by credits: (4*4.0 + 3*3.3) / 7.
+		require.Equal(t, 3.7, *transcript.Terms[0].GPA)
+		require.Equal(t, 3.0, *transcript.Terms[1].GPA)
+		require.Equal(t, 3.49, *transcript.CumulativeGPA)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Grades", func(t *testing.T) {
+		service, mock := newMockGradeService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS`).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`FROM person_course pc`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"term_id", "name", "id", "name", "credits", "grade", "grade_points"}).
+				AddRow(nil, "", 1, "Programming", 4, nil, nil))
+
+		transcript, err := service.GetTranscript(context.Background(), 3)
+		require.NoError(t, err)
+		require.Len(t, transcript.Terms, 1)
+		require.Nil(t, transcript.Terms[0].TermID)
+		require.Nil(t, transcript.Terms[0].GPA)
+		require.Nil(t, transcript.CumulativeGPA)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Person", func(t *testing.T) {
+		service, mock := newMockGradeService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS`).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.GetTranscript(context.Background(), 9)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func newMockGradeService(t *testing.T) (services.GradeService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.GradeService{Database: db}
+
+	return service, mock
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+// getInstructors returns the teaching staff of each of the given courses in
+// the current term, counting enrollments not tied to a term, keyed by course
+// ID and using a single query. Instructors come first, then co-instructors
+// and teaching assistants. Courses without staff are missing from the map.
+func (c CourseService) getInstructors(ctx context.Context, courseIDs []int) (map[int][]models.Instructor, error) {
+	rows, err := c.Database.QueryContext(ctx, `
+	SELECT pc.course_id, p.id, p.first_name, p.last_name, pc.role
+		FROM person_course pc
+		JOIN person p ON p.id = pc.person_id
+		WHERE pc.course_id = ANY($1) AND pc.role <> 'student'
+		AND (pc.term_id IS NULL OR `+currentTermCondition("pc.term_id")+`)
+		GROUP BY pc.course_id, p.id, pc.role
+		ORDER BY pc.course_id, array_position($2, pc.role), p.id
+	`, pq.Array(courseIDs), pq.Array(models.Roles))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instructors: %w", classify(err))
+	}
+	defer rows.Close()
+
+	instructors := make(map[int][]models.Instructor)
+	for rows.Next() {
+		var courseID int
+		var instructor models.Instructor
+		if err := rows.Scan(&courseID, &instructor.PersonID, &instructor.FirstName, &instructor.LastName, &instructor.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan instructor: %w", classify(err))
+		}
+		instructors[courseID] = append(instructors[courseID], instructor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan instructors: %w", classify(err))
+	}
+	return instructors, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+// meetingColumns selects a course_meeting row aliased m in the order
+// scanMeeting reads it.
+const meetingColumns = `m.days, to_char(m.start_time, 'HH24:MI'), to_char(m.end_time, 'HH24:MI'), m.location, m.room_id`
+
+func scanMeeting(scan func(dest ...any) error, prefix ...any) (models.Meeting, error) {
+	var meeting models.Meeting
+	dest := append(prefix, pq.Array(&meeting.Days), &meeting.StartTime, &meeting.EndTime, &meeting.Location, &meeting.RoomID)
+	err := scan(dest...)
+	return meeting, err
+}
+
+// getMeetings returns the meetings of each of the given courses, keyed by
+// course ID, using a single query. Courses without meetings are missing from
+// the map.
+func (c CourseService) getMeetings(ctx context.Context, courseIDs []int) (map[int][]models.Meeting, error) {
+	rows, err := c.Database.QueryContext(ctx, `
+	SELECT m.course_id, `+meetingColumns+`
+		FROM course_meeting m
+		WHERE m.course_id = ANY($1)
+		ORDER BY m.course_id, m.id
+	`, pq.Array(courseIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meetings: %w", classify(err))
+	}
+	defer rows.Close()
+
+	meetings := make(map[int][]models.Meeting)
+	for rows.Next() {
+		var courseID int
+		meeting, err := scanMeeting(rows.Scan, &courseID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan meeting: %w", classify(err))
+		}
+		meetings[courseID] = append(meetings[courseID], meeting)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan meetings: %w", classify(err))
+	}
+	return meetings, nil
+}
+
+// setMeetings replaces the meetings of the course. Meetings booked into a
+// room fail with a RoomConflictError when another course has the room at
+// the same time.
+func setMeetings(ctx context.Context, tx *sql.Tx, courseID int, meetings []models.Meeting) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM course_meeting WHERE course_id = $1`, courseID); err != nil {
+		return fmt.Errorf("failed to remove meetings: %w", classify(err))
+	}
+	var rooms []int
+	for _, meeting := range meetings {
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO course_meeting (course_id, days, start_time, end_time, location, room_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		`, courseID, pq.Array(meeting.Days), meeting.StartTime, meeting.EndTime, meeting.Location, meeting.RoomID)
+		if err != nil {
+			return fmt.Errorf("failed to add meeting: %w", classify(err))
+		}
+		if meeting.RoomID != nil {
+			rooms = append(rooms, *meeting.RoomID)
+		}
+	}
+	if len(rooms) > 0 {
+		return checkRoomConflicts(ctx, tx, courseID, distinctIDs(rooms))
+	}
+	return nil
+}
+
+// meetingsOverlap is the condition that the course_meeting rows aliased a
+// and b overlap: they share a day and their times intersect.
+func meetingsOverlap(a, b string) string {
+	return a + `.days && ` + b + `.days AND ` + a + `.start_time < ` + b + `.end_time AND ` + b + `.start_time < ` + a + `.end_time`
+}
+
+// checkScheduleConflicts fails with a ScheduleConflictError if a meeting of
+// the course overlaps a meeting of another course the person takes in the
+// given term, which is nil for the current term. Courses not tied to a term
+// are checked against every term, as they count towards the credit load of
+// every term.
+func checkScheduleConflicts(ctx context.Context, tx *sql.Tx, personID, courseID int, termID *int) error {
+	rows, err := tx.QueryContext(ctx, `
+	SELECT c.id, c.name, `+meetingColumns+`
+		FROM person_course pc
+		JOIN course c ON c.id = pc.course_id
+		JOIN course_meeting m ON m.course_id = pc.course_id
+		WHERE pc.person_id = $1
+		AND pc.course_id <> $2
+		AND `+inTerm("pc.term_id", "$3")+`
+		AND EXISTS (
+			SELECT 1
+			FROM course_meeting n
+			WHERE n.course_id = $2
+			AND `+meetingsOverlap("n", "m")+`
+		)
+		ORDER BY c.id, m.id
+	`, personID, courseID, termID)
+	if err != nil {
+		return fmt.Errorf("failed to check schedule of course %d: %w", courseID, classify(err))
+	}
+	defer rows.Close()
+
+	var conflicts []models.ScheduleConflict
+	for rows.Next() {
+		var conflict models.ScheduleConflict
+		conflict.Meeting, err = scanMeeting(rows.Scan, &conflict.CourseID, &conflict.CourseName)
+		if err != nil {
+			return fmt.Errorf("failed to scan schedule conflict: %w", classify(err))
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to scan schedule conflicts: %w", classify(err))
+	}
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{CourseID: courseID, Conflicts: conflicts}
+	}
+	return nil
+}
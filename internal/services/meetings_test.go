@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrollScheduleConflicts(t *testing.T) {
+	t.Run("Conflict", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectSeat(mock, 3, 1, nil, 0)
+		expectNotEnrolled(mock, 3, 1)
+		expectPrerequisitesMet(mock, 3, 1, nil)
+		mock.ExpectQuery(`SELECT c.id, c.name, m.days, to_char\(m.start_time, 'HH24:MI'\), to_char\(m.end_time, 'HH24:MI'\), m.location, m.room_id FROM person_course pc .* AND pc.course_id <> \$2 AND \(pc.term_id IS NULL OR pc.term_id = \$3 OR \(\$3 IS NULL AND pc.term_id IN \(SELECT id FROM term WHERE start_date <= CURRENT_DATE AND end_date >= CURRENT_DATE\)\)\) AND EXISTS .* AND n.days && m.days AND n.start_time < m.end_time AND m.start_time < n.end_time`).
+			WithArgs(3, 1, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "days", "start_time", "end_time", "location", "room_id"}).
+				AddRow(2, "Databases", pq.Array([]string{"mon", "wed"}), "09:30", "11:00", "Room 2", nil))
+		mock.ExpectRollback()
+
+		_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{})
+		require.ErrorIs(t, err, services.ErrConflict)
+		var clash *services.ScheduleConflictError
+		require.True(t, errors.As(err, &clash))
+		require.Equal(t, []models.ScheduleConflict{{
+			CourseID:   2,
+			CourseName: "Databases",
+			Meeting:    models.Meeting{Days: []string{"mon", "wed"}, StartTime: "09:30", EndTime: "11:00", Location: "Room 2"},
+		}}, clash.Conflicts)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		service, mock := newMockEnrollmentService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectSeat(mock, 3, 1, nil, 0)
+		expectNotEnrolled(mock, 3, 1)
+		expectPrerequisitesMet(mock, 3, 1, nil)
+		mock.ExpectQuery(`INSERT INTO person_course`).
+			WithArgs(3, 1, nil, "student").
+			WillReturnRows(sqlmock.NewRows([]string{"enrolled_at"}).AddRow(termStart))
+		expectCreditLoad(mock, 3, nil, "student", 18, 3)
+		mock.ExpectCommit()
+
+		enrollment, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 1}, services.EnrollOptions{OverrideConflicts: true})
+		require.NoError(t, err)
+		require.Equal(t, models.EnrollmentStatusEnrolled, enrollment.Status)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPromoteWaitlistPassesOver(t *testing.T) {
+	service, mock := newMockEnrollmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectBegin()
+	expectCourseLock(mock, 1, 30)
+	mock.ExpectExec(`DELETE FROM person_course`).
+		WithArgs(3, 1, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectCourseLock(mock, 1, 30)
+	// People already enrolled in the term and people whose timetable now
+	// clashes with the course are passed over, as are students who lack its
+	// prerequisites.
+	mock.ExpectExec(`WHERE w.course_id = \$1 `+
+		`AND NOT EXISTS \( SELECT 1 FROM person_course e WHERE e.person_id = w.person_id AND e.course_id = w.course_id AND e.term_id IS NOT DISTINCT FROM w.term_id \) `+
+		`AND NOT EXISTS \( SELECT 1 FROM person_course pc JOIN course_meeting m ON m.course_id = pc.course_id JOIN course_meeting n ON n.course_id = w.course_id `+
+		`WHERE pc.person_id = w.person_id AND pc.course_id <> w.course_id AND \(pc.term_id IS NULL OR pc.term_id = w.term_id .*\) `+
+		`AND n.days && m.days AND n.start_time < m.end_time AND m.start_time < n.end_time \) `+
+		`.* AND NOT EXISTS \( SELECT 1 FROM course_prerequisite cp WHERE cp.course_id = w.course_id AND NOT EXISTS \( .* WHERE pc.person_id = w.person_id AND pc.course_id = cp.prerequisite_id`).
+		WithArgs(1, services.DefaultMaxCredits).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, service.Unenroll(context.Background(), 3, 1, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
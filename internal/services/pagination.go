@@ -0,0 +1,175 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Cursor marks a position in a listing. Values holds the row's values for
+// the listing's sort keys, in order; ID breaks ties between equal values.
+// Clients only ever see the cursor in its encoded, opaque form.
+type Cursor struct {
+	ID       int           `json:"id"`
+	Values   []interface{} `json:"v,omitempty"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// EncodeCursor returns the opaque string form of c.
+func EncodeCursor(c Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor previously produced by EncodeCursor.
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", err)
+	}
+	var c Cursor
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&c); err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", err)
+	}
+	// Numbers come back as json.Number; hand integers to the database as
+	// integers so they compare correctly with integer columns. Sort keys are
+	// only ever strings or numbers, so anything else was not produced by
+	// EncodeCursor.
+	for i, v := range c.Values {
+		switch v := v.(type) {
+		case json.Number:
+			if integer, err := v.Int64(); err == nil {
+				c.Values[i] = integer
+				continue
+			}
+			c.Values[i] = v.String()
+		case string:
+		default:
+			return Cursor{}, fmt.Errorf("malformed cursor: unexpected value %v", v)
+		}
+	}
+	return c, nil
+}
+
+// Page selects a slice of a listing. A nil Cursor starts at the beginning.
+type Page struct {
+	Limit     int
+	Cursor    *Cursor
+	WithTotal bool
+}
+
+// PageInfo describes where a returned page sits in the listing. Cursors are
+// empty when there is no page in that direction, and Total is only set when
+// it was requested.
+type PageInfo struct {
+	NextCursor string
+	PrevCursor string
+	Total      *int
+}
+
+func (p Page) limit() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	return min(p.Limit, MaxPageLimit)
+}
+
+// SortKey orders a listing by a column.
+type SortKey struct {
+	Column string
+	Desc   bool
+}
+
+// keyset returns the condition restricting a listing ordered by keys, then
+// idColumn, to the rows after (or before) the cursor, along with the ORDER
+// BY clause for the page. Placeholders are numbered from $n. The condition is
+// empty when the page has no cursor.
+func (p Page) keyset(keys []SortKey, idColumn string, n int) (cond string, order string, args []interface{}, err error) {
+	all := append(append([]SortKey{}, keys...), SortKey{Column: idColumn})
+	backward := p.Cursor != nil && p.Cursor.Backward
+
+	orders := make([]string, 0, len(all))
+	for _, k := range all {
+		dir := "ASC"
+		if k.Desc != backward {
+			dir = "DESC"
+		}
+		orders = append(orders, k.Column+" "+dir)
+	}
+	order = strings.Join(orders, ", ")
+
+	if p.Cursor == nil {
+		return "", order, nil, nil
+	}
+	if len(p.Cursor.Values) != len(keys) {
+		return "", "", nil, fmt.Errorf("cursor does not match the sort order: %w", ErrConstraintViolation)
+	}
+	values := append(append([]interface{}{}, p.Cursor.Values...), p.Cursor.ID)
+
+	// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... with the comparison flipped
+	// for descending keys and for backward pages.
+	var ors []string
+	for i, k := range all {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, fmt.Sprintf("%s = $%d", all[j].Column, n+j))
+		}
+		op := ">"
+		if k.Desc != backward {
+			op = "<"
+		}
+		ands = append(ands, fmt.Sprintf("%s %s $%d", k.Column, op, n+i))
+		ors = append(ors, strings.Join(ands, " AND "))
+	}
+	if len(ors) == 1 {
+		return ors[0], order, values, nil
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", order, values, nil
+}
+
+// paginate trims the extra row fetched to detect further pages, restores
+// the requested order for backward pages and builds the cursors around items.
+// values returns an item's values for the listing's sort keys and may be nil
+// for listings ordered by ID alone.
+func paginate[T any](items []T, page Page, id func(T) int, values func(T) []interface{}) ([]T, PageInfo) {
+	limit := page.limit()
+	more := len(items) > limit
+	if more {
+		items = items[:limit]
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	var info PageInfo
+	if len(items) == 0 {
+		return items, info
+	}
+	cursor := func(item T, backward bool) string {
+		c := Cursor{ID: id(item), Backward: backward}
+		if values != nil {
+			c.Values = values(item)
+		}
+		return EncodeCursor(c)
+	}
+	if (backward && more) || (!backward && page.Cursor != nil) {
+		info.PrevCursor = cursor(items[0], true)
+	}
+	if (!backward && more) || backward {
+		info.NextCursor = cursor(items[len(items)-1], false)
+	}
+	return items, info
+}
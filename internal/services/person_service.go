@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
@@ -14,47 +15,188 @@ type PersonService struct {
 	Database *sql.DB
 }
 
+// personSelect selects people together with the IDs of the courses they are
+// associated with in any term and their current credit load. People without
+// courses get an empty list rather than a list containing NULL. Callers
+// append WHERE and GROUP BY clauses.
+var personSelect = `SELECT p.id, p.first_name, p.last_name, p.type, p.age,
+	COALESCE(ARRAY_AGG(DISTINCT pc.course_id) FILTER (WHERE pc.course_id IS NOT NULL), '{}') AS courses,
+	p.max_credits, ` + personCredits + ` AS credits
+		FROM person p
+		LEFT JOIN person_course pc ON p.id = pc.person_id
+	`
+
 func NewPersonService(db *sql.DB) *PersonService {
 	return &PersonService{
 		Database: db,
 	}
 }
 
-func (p PersonService) GetPeople(ctx context.Context, firstName, lastName, age, personType string) ([]models.Person, error) {
-	query := `SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG(pc.course_id) AS courses
-		FROM person p
-		LEFT JOIN person_course pc ON p.id = pc.person_id
-		`
+// PersonFilter narrows and orders the people returned by GetPeople. Zero
+// values mean the field is not filtered on.
+type PersonFilter struct {
+	Type      string
+	FirstName string
+	LastName  string
+	// Name is a case-insensitive prefix of the first name, the last name or
+	// the full name.
+	Name   string
+	Age    int
+	AgeMin int
+	AgeMax int
+	// Courses keeps people associated with any of the given courses.
+	Courses []int64
+	// Term keeps people with enrollments in the given terms and lists only
+	// the courses they take in those terms.
+	Term TermScope
+	// Sort orders the results; people with equal sort keys are ordered by ID.
+	Sort []SortKey
+}
+
+// personSortColumns whitelists the fields people can be sorted by, mapped to
+// the columns they sort on.
+var personSortColumns = map[string]string{
+	"id":         "p.id",
+	"first_name": "p.first_name",
+	"last_name":  "p.last_name",
+	"type":       "p.type",
+	"age":        "p.age",
+}
+
+// ParsePersonSort parses a comma separated list of person fields, each
+// optionally prefixed with "-" for descending order, such as
+// "last_name,-age".
+func ParsePersonSort(s string) ([]SortKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var keys []SortKey
+	for _, field := range strings.Split(s, ",") {
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		if _, ok := personSortColumns[field]; !ok {
+			return nil, fmt.Errorf("cannot sort people by %q: %w", field, ErrConstraintViolation)
+		}
+		keys = append(keys, SortKey{Column: field, Desc: desc})
+	}
+	return keys, nil
+}
+
+// personSortValue returns the value of person for a sort field.
+func personSortValue(person models.Person, field string) interface{} {
+	switch field {
+	case "first_name":
+		return person.FirstName
+	case "last_name":
+		return person.LastName
+	case "type":
+		return person.Type
+	case "age":
+		return person.Age
+	default:
+		return person.ID
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// GetPeople returns one page of the people matching filter.
+func (p PersonService) GetPeople(ctx context.Context, filter PersonFilter, page Page) ([]models.Person, PageInfo, error) {
+	query := personSelect
 	var whereClauses []string
 	var args []interface{}
 
-	whereClauses = append(whereClauses, "type = $"+fmt.Sprint(len(args)+1))
-	args = append(args, personType)
+	// Sort fields are user supplied, so only whitelisted columns ever reach
+	// the query text.
+	sortKeys := make([]SortKey, 0, len(filter.Sort))
+	for _, key := range filter.Sort {
+		column, ok := personSortColumns[key.Column]
+		if !ok {
+			return []models.Person{}, PageInfo{}, fmt.Errorf("[in services.GetPeople] cannot sort people by %q: %w", key.Column, ErrConstraintViolation)
+		}
+		sortKeys = append(sortKeys, SortKey{Column: column, Desc: key.Desc})
+	}
+
+	// The term comes first so that the join and the WHERE clause can share
+	// its placeholder.
+	termCond, args := filter.Term.condition("term_id", args)
+	if termCond != "" {
+		joinCond, _ := filter.Term.condition("pc.term_id", nil)
+		query += " AND " + joinCond
+	}
 
-	if firstName != "" {
+	if filter.Type != "" {
+		whereClauses = append(whereClauses, "type = $"+fmt.Sprint(len(args)+1))
+		args = append(args, filter.Type)
+	}
+	if filter.FirstName != "" {
 		whereClauses = append(whereClauses, "first_name = $"+fmt.Sprint(len(args)+1))
-		args = append(args, firstName)
+		args = append(args, filter.FirstName)
 	}
-	if lastName != "" {
+	if filter.LastName != "" {
 		whereClauses = append(whereClauses, "last_name = $"+fmt.Sprint(len(args)+1))
-		args = append(args, lastName)
+		args = append(args, filter.LastName)
 	}
-	if age != "" {
+	if filter.Name != "" {
+		n := "$" + fmt.Sprint(len(args)+1)
+		whereClauses = append(whereClauses, "(first_name ILIKE "+n+" OR last_name ILIKE "+n+" OR first_name || ' ' || last_name ILIKE "+n+")")
+		args = append(args, escapeLike(filter.Name)+"%")
+	}
+	if filter.Age != 0 {
 		whereClauses = append(whereClauses, "age = $"+fmt.Sprint(len(args)+1))
-		args = append(args, age)
+		args = append(args, filter.Age)
+	}
+	if filter.AgeMin != 0 {
+		whereClauses = append(whereClauses, "age >= $"+fmt.Sprint(len(args)+1))
+		args = append(args, filter.AgeMin)
+	}
+	if filter.AgeMax != 0 {
+		whereClauses = append(whereClauses, "age <= $"+fmt.Sprint(len(args)+1))
+		args = append(args, filter.AgeMax)
+	}
+	if len(filter.Courses) > 0 {
+		cond := "course_id = ANY($" + fmt.Sprint(len(args)+1) + ")"
+		if termCond != "" {
+			cond += " AND " + termCond
+		}
+		whereClauses = append(whereClauses, "p.id IN (SELECT person_id FROM person_course WHERE "+cond+")")
+		args = append(args, pq.Array(filter.Courses))
+	} else if termCond != "" {
+		whereClauses = append(whereClauses, "p.id IN (SELECT person_id FROM person_course WHERE "+termCond+")")
+	}
+
+	// The count only depends on the filters, so build it before the keyset
+	// condition is added.
+	countQuery := `SELECT COUNT(*) FROM person p`
+	if len(whereClauses) > 0 {
+		countQuery += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	countArgs := args
+
+	cond, order, keysetArgs, err := page.keyset(sortKeys, "p.id", len(args)+1)
+	if err != nil {
+		return []models.Person{}, PageInfo{}, fmt.Errorf("[in services.GetPeople] invalid page: %w", err)
+	}
+	if cond != "" {
+		whereClauses = append(whereClauses, cond)
+		args = append(args, keysetArgs...)
 	}
 
 	if len(whereClauses) > 0 {
 		query += " WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
-	query += `
-	GROUP BY id, first_name, last_name, type, age;
-	`
+	query += fmt.Sprintf(`
+	GROUP BY id, first_name, last_name, type, age
+	ORDER BY %s LIMIT %d;
+	`, order, page.limit()+1)
 
 	rows, err := p.Database.QueryContext(ctx, query, args...)
 	if err != nil {
-		return []models.Person{}, fmt.Errorf("[in services.GetPeople] failed to get people: %w", err)
+		return []models.Person{}, PageInfo{}, fmt.Errorf("[in services.GetPeople] failed to get people: %w", classify(err))
 	}
 	defer rows.Close()
 
@@ -62,64 +204,158 @@ func (p PersonService) GetPeople(ctx context.Context, firstName, lastName, age,
 
 	for rows.Next() {
 		var p models.Person
-		err = rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Type, &p.Age, pq.Array(&p.Courses))
+		err = rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Type, &p.Age, pq.Array(&p.Courses), &p.MaxCredits, &p.Credits)
 		if err != nil {
-			return []models.Person{}, fmt.Errorf("[in services.GetPeople] failed to scan people from row: %w", err)
+			return []models.Person{}, PageInfo{}, fmt.Errorf("[in services.GetPeople] failed to scan people from row: %w", classify(err))
 		}
 		people = append(people, p)
 	}
 	if err := rows.Err(); err != nil {
-		return []models.Person{}, fmt.Errorf("[in services.GetPeople] failed to scan people: %w", err)
+		return []models.Person{}, PageInfo{}, fmt.Errorf("[in services.GetPeople] failed to scan people: %w", classify(err))
+	}
+
+	people, info := paginate(people, page, func(p models.Person) int { return p.ID }, func(p models.Person) []interface{} {
+		values := make([]interface{}, 0, len(filter.Sort))
+		for _, key := range filter.Sort {
+			values = append(values, personSortValue(p, key.Column))
+		}
+		return values
+	})
+
+	if page.WithTotal {
+		var total int
+		if err := p.Database.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+			return []models.Person{}, PageInfo{}, fmt.Errorf("[in services.GetPeople] failed to count people: %w", classify(err))
+		}
+		info.Total = &total
 	}
-	return people, nil
+	return people, info, nil
 }
 
+// GetPerson returns the single person with the given first name. An empty
+// personType matches people of any type.
 func (p PersonService) GetPerson(ctx context.Context, firstName, personType string) (models.Person, error) {
-	row := p.Database.QueryRowContext(ctx, `
-	SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG(pc.course_id) AS courses
-		FROM person p
-		LEFT JOIN person_course pc ON p.id = pc.person_id
-	WHERE "first_name" = $1 
+	query := personSelect + `
+	WHERE "first_name" = $1 `
+	args := []interface{}{firstName}
+	if personType != "" {
+		query += `
 	AND 
-	"type" = $2
+	"type" = $2`
+		args = append(args, personType)
+	}
+	query += `
 	GROUP BY id, first_name, last_name, type, age;
-	`, firstName, personType)
+	`
+
+	rows, err := p.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.Person{}, fmt.Errorf("[in services.GetPerson] failed to get person: %w", classify(err))
+	}
+	defer rows.Close()
+
+	var people []models.Person
+	for rows.Next() {
+		var person models.Person
+		if err := rows.Scan(&person.ID, &person.FirstName, &person.LastName, &person.Type, &person.Age, pq.Array(&person.Courses), &person.MaxCredits, &person.Credits); err != nil {
+			return models.Person{}, fmt.Errorf("[in services.GetPerson] failed to scan person: %w", classify(err))
+		}
+		people = append(people, person)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Person{}, fmt.Errorf("[in services.GetPerson] failed to scan person: %w", classify(err))
+	}
+
+	// First names are not unique, so refuse to guess which person was meant.
+	switch len(people) {
+	case 0:
+		return models.Person{}, fmt.Errorf("[in services.GetPerson] failed to get person: %w", classify(sql.ErrNoRows))
+	case 1:
+		return people[0], nil
+	default:
+		return models.Person{}, fmt.Errorf("[in services.GetPerson] failed to get person: %w", &AmbiguousError{Candidates: people})
+	}
+}
+
+func (p PersonService) GetPersonByID(ctx context.Context, id int) (models.Person, error) {
+	row := p.Database.QueryRowContext(ctx, personSelect+`
+	WHERE p.id = $1
+	GROUP BY id, first_name, last_name, type, age;
+	`, id)
 	person := models.Person{}
-	if err := row.Scan(&person.ID, &person.FirstName, &person.LastName, &person.Type, &person.Age, pq.Array(&person.Courses)); err != nil {
+	if err := row.Scan(&person.ID, &person.FirstName, &person.LastName, &person.Type, &person.Age, pq.Array(&person.Courses), &person.MaxCredits, &person.Credits); err != nil {
 		if err == sql.ErrNoRows {
-			return models.Person{}, fmt.Errorf("[in services.GetPerson] failed to get person: %w", err)
+			return models.Person{}, fmt.Errorf("[in services.GetPersonByID] person with ID %d not found: %w", id, classify(err))
 		}
-		return models.Person{}, fmt.Errorf("[in services.GetCourse] failed to scan person: %w", err)
+		return models.Person{}, fmt.Errorf("[in services.GetPersonByID] failed to scan person: %w", classify(err))
 	}
 	return person, nil
 }
 
+// GetCoursesByPerson returns the courses of each of the given people, keyed
+// by person ID, using a single query. People without courses map to an
+// empty slice.
+func (p PersonService) GetCoursesByPerson(ctx context.Context, personIDs []int) (map[int][]models.Course, error) {
+	rows, err := p.Database.QueryContext(ctx, `
+	SELECT DISTINCT pc.person_id, `+courseColumns+`
+		FROM person_course pc
+		JOIN course c ON c.id = pc.course_id
+		WHERE pc.person_id = ANY($1)
+		ORDER BY pc.person_id, c.id;
+	`, pq.Array(personIDs))
+	if err != nil {
+		return nil, fmt.Errorf("[in services.GetCoursesByPerson] failed to get courses: %w", classify(err))
+	}
+	defer rows.Close()
+
+	courses := make(map[int][]models.Course, len(personIDs))
+	for _, id := range personIDs {
+		courses[id] = []models.Course{}
+	}
+	for rows.Next() {
+		var personID int
+		course, err := scanCourse(rows.Scan, &personID)
+		if err != nil {
+			return nil, fmt.Errorf("[in services.GetCoursesByPerson] failed to scan course: %w", classify(err))
+		}
+		courses[personID] = append(courses[personID], course)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[in services.GetCoursesByPerson] failed to scan courses: %w", classify(err))
+	}
+	return courses, nil
+}
+
+// UpdatePerson updates the person with the given first name and type. It
+// fails with an AmbiguousError if more than one person matches.
 func (p PersonService) UpdatePerson(ctx context.Context, firstName, personType string, person models.Person) (models.Person, error) {
-	_, err := p.Database.ExecContext(ctx, `
+	existing, err := p.GetPerson(ctx, firstName, personType)
+	if err != nil {
+		return models.Person{}, fmt.Errorf("[in services.UpdatePerson] failed to find person: %w", err)
+	}
+	return p.UpdatePersonByID(ctx, existing.ID, person)
+}
+
+func (p PersonService) UpdatePersonByID(ctx context.Context, id int, person models.Person) (models.Person, error) {
+	err := p.Database.QueryRowContext(ctx, `
 	UPDATE "person" 
      SET "first_name" = $1, 
          "last_name" = $2, 
          "type" = $3, 
-         "age" = $4
-     WHERE "first_name" = $5
-	 AND "type" = $6;
-	 `, person.FirstName, person.LastName, person.Type, person.Age, firstName, personType)
-	if err != nil {
-		return models.Person{}, fmt.Errorf("[in services.UpdatePerson] failed to update person: %w", err)
-	}
-
-	err = p.Database.QueryRowContext(ctx, `
-        SELECT id, first_name, last_name, type, age FROM "person" 
-        WHERE "first_name" = $1 AND "type" = $2;
-    `, person.FirstName, person.Type).Scan(
+         "age" = $4,
+         "max_credits" = $5
+     WHERE "id" = $6
+	 RETURNING id, first_name, last_name, type, age, max_credits;
+	 `, person.FirstName, person.LastName, person.Type, person.Age, person.MaxCredits, id).Scan(
 		&person.ID,
 		&person.FirstName,
 		&person.LastName,
 		&person.Type,
 		&person.Age,
+		&person.MaxCredits,
 	)
 	if err != nil {
-		return models.Person{}, fmt.Errorf("[in services.UpdatePerson] failed to retrieve updated person: %w", err)
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonByID] failed to update person: %w", classify(err))
 	}
 
 	return person, nil
@@ -129,82 +365,308 @@ func (p PersonService) UpdatePersonCourses(ctx context.Context, studentID int, n
 
 	tx, err := p.Database.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("[in services.UpdatePersonCourses] failed to start transaction: %v", err)
+		return fmt.Errorf("[in services.UpdatePersonCourses] failed to start transaction: %w", classify(err))
 	}
 
-	_, err = tx.ExecContext(ctx, `
+	if err := setPersonCourses(ctx, tx, studentID, newCourses); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("[in services.UpdatePersonCourses] %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("[in services.UpdatePersonCourses] failed to commit transaction: %w", classify(err))
+	}
+
+	return nil
+}
+
+// UpdatePersonWithCourses updates the person with the given ID and replaces
+// their enrollments with person.Courses in a single transaction, so either
+// both change or neither does. Changing the type of a person who holds
+// course roles the new type cannot hold, who advises students or who has an
+// advisor is a constraint violation. It
+// returns the person as stored afterwards, including their courses.
+func (p PersonService) UpdatePersonWithCourses(ctx context.Context, id int, person models.Person) (models.Person, error) {
+	tx, err := p.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] failed to start transaction: %w", classify(err))
+	}
+
+	result, err := tx.ExecContext(ctx, `
+	UPDATE "person"
+	SET "first_name" = $1,
+		"last_name" = $2,
+		"type" = $3,
+		"age" = $4,
+		"max_credits" = $5
+	WHERE "id" = $6
+	`, person.FirstName, person.LastName, person.Type, person.Age, person.MaxCredits, id)
+	if err != nil {
+		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] failed to update person: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] person with ID %d does not exist: %w", id, ErrNotFound)
+	}
+
+	// The type may have changed, so the roles and advising the person already
+	// have must still suit it; grading and attendance trust them.
+	if err := checkPersonRoles(ctx, tx, id, person.Type); err != nil {
+		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] %w", err)
+	}
+	if err := checkAdvising(ctx, tx, id, person.Type); err != nil {
+		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] %w", err)
+	}
+
+	if err := setPersonCourses(ctx, tx, id, person.Courses); err != nil {
+		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] %w", err)
+	}
+
+	updated := models.Person{}
+	err = tx.QueryRowContext(ctx, personSelect+`
+	WHERE p.id = $1
+	GROUP BY id, first_name, last_name, type, age;
+	`, id).Scan(&updated.ID, &updated.FirstName, &updated.LastName, &updated.Type, &updated.Age, pq.Array(&updated.Courses), &updated.MaxCredits, &updated.Credits)
+	if err != nil {
+		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] failed to reload person: %w", classify(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Person{}, fmt.Errorf("[in services.UpdatePersonWithCourses] failed to commit transaction: %w", classify(err))
+	}
+	return updated, nil
+}
+
+// setPersonCourses makes courses the complete list of courses the person is
+// enrolled in, within tx. Only ungraded enrollments in the current term or
+// not tied to a term are dropped; graded ones and those of other terms are
+// kept, so transcripts and prerequisite history survive. Added courses are
+// not tied to a term. Seats freed by dropped courses go to
+// their waitlists, joining a full course fails with ErrCourseFull, joining
+// a course without its prerequisites fails with a MissingPrerequisitesError,
+// joining a course that clashes with the person's other courses fails with a
+// ScheduleConflictError and going over a student's credit limit fails with a
+// CreditLimitError.
+func setPersonCourses(ctx context.Context, tx *sql.Tx, personID int, courses []int64) error {
+	// A nil slice would be sent as NULL, against which != ALL never holds.
+	if courses == nil {
+		courses = []int64{}
+	}
+	dropped, err := queryIDs(ctx, tx, `
         DELETE FROM person_course
         WHERE person_id = $1
         AND course_id != ALL($2)
-    `, studentID, pq.Array(newCourses))
+        AND grade IS NULL
+        AND (term_id IS NULL OR `+currentTermCondition("term_id")+`)
+        RETURNING course_id
+    `, personID, pq.Array(courses))
 	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("[in services.UpdatePersonCourses] failed to remove old courses: %v", err)
+		return fmt.Errorf("failed to remove old courses: %w", err)
+	}
+	for _, courseID := range distinctIDs(dropped) {
+		if err := promoteWaitlist(ctx, tx, courseID); err != nil {
+			return err
+		}
+	}
+
+	kept, err := queryIDs(ctx, tx, `SELECT course_id FROM person_course WHERE person_id = $1`, personID)
+	if err != nil {
+		return fmt.Errorf("failed to get current courses: %w", err)
+	}
+
+	// Lock new courses in ID order so that concurrent updates touching the
+	// same courses cannot deadlock.
+	added := make([]int, 0, len(courses))
+	for _, courseID := range courses {
+		if !slices.Contains(kept, int(courseID)) && !slices.Contains(added, int(courseID)) {
+			added = append(added, int(courseID))
+		}
 	}
+	slices.Sort(added)
 
-	// Insert the new courses if not already associated with the student
-	for _, courseID := range newCourses {
+	var role string
+	if len(added) > 0 {
+		if role, err = enrollmentRole(ctx, tx, personID, ""); err != nil {
+			return err
+		}
+	}
+	for _, courseID := range added {
+		seat, err := reserveSeat(ctx, tx, courseID, nil, role)
+		if err != nil {
+			return err
+		}
+		if role == models.RoleStudent {
+			if err := checkPrerequisites(ctx, tx, personID, courseID, nil); err != nil {
+				return err
+			}
+		}
+		if err := checkScheduleConflicts(ctx, tx, personID, courseID, nil); err != nil {
+			return err
+		}
+		if !seat {
+			return fmt.Errorf("course %d has no free seats: %w", courseID, ErrCourseFull)
+		}
 		_, err = tx.ExecContext(ctx, `
-            INSERT INTO person_course (person_id, course_id)
-            VALUES ($1, $2)
-            ON CONFLICT DO NOTHING
-        `, studentID, courseID)
+            INSERT INTO person_course (person_id, course_id, role)
+            VALUES ($1, $2, $3)
+        `, personID, courseID, role)
 		if err != nil {
-			tx.Rollback()
-			return fmt.Errorf("[in services.UpdatePersonCourses] failed to add new courses: %v", err)
+			return fmt.Errorf("failed to add new courses: %w", classify(err))
 		}
 	}
+	// Dropping courses only lowers the load, so students already over a
+	// lowered limit can still shed courses.
+	if role == models.RoleStudent {
+		if err := checkCreditLimit(ctx, tx, personID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	// Commit the transaction
-	err = tx.Commit()
+// distinctIDs sorts ids and removes duplicates, such as a course the person
+// was enrolled in during several terms.
+func distinctIDs(ids []int) []int {
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// queryIDs runs a query returning a single integer column within tx.
+func queryIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("[in services.UpdatePersonCourses] failed to commit transaction: %v", err)
+		return nil, classify(err)
 	}
+	defer rows.Close()
 
-	return nil
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, classify(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, classify(rows.Err())
 }
 
 func (p PersonService) CreatePerson(ctx context.Context, person models.Person) (models.Person, error) {
 	err := p.Database.QueryRowContext(ctx, `
 	INSERT INTO "person" 
-	(first_name, last_name, type, age)
+	(first_name, last_name, type, age, max_credits)
 	VALUES 
-	($1, $2, $3, $4)
+	($1, $2, $3, $4, $5)
 	RETURNING id
-	`, person.FirstName, person.LastName, person.Type, person.Age).Scan(&person.ID)
+	`, person.FirstName, person.LastName, person.Type, person.Age, person.MaxCredits).Scan(&person.ID)
 
 	if err != nil {
-		return models.Person{}, fmt.Errorf("[in services.CreatePerson] failed to create person: %w", err)
+		return models.Person{}, fmt.Errorf("[in services.CreatePerson] failed to create person: %w", classify(err))
 	}
 	return person, nil
 }
 
-func (p PersonService) DeletePerson(ctx context.Context, firstName, personType string) error {
-	// Start a transaction
+// CreatePersonWithCourses creates a person and enrolls them in
+// person.Courses in a single transaction, so an enrollment that fails, for
+// example on a full course or a missing prerequisite, leaves no person
+// behind. It returns the person with their new ID.
+func (p PersonService) CreatePersonWithCourses(ctx context.Context, person models.Person) (models.Person, error) {
 	tx, err := p.Database.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("[in services.DeletePerson] failed to start transaction: %w", err)
+		return models.Person{}, fmt.Errorf("[in services.CreatePersonWithCourses] failed to start transaction: %w", classify(err))
 	}
 
-	// Find the person ID based on the first name and type
-	var personID int
 	err = tx.QueryRowContext(ctx, `
-        SELECT id FROM "person"
-        WHERE "first_name" = $1 AND "type" = $2
-    `, firstName, personType).Scan(&personID)
+	INSERT INTO "person"
+	(first_name, last_name, type, age, max_credits)
+	VALUES
+	($1, $2, $3, $4, $5)
+	RETURNING id
+	`, person.FirstName, person.LastName, person.Type, person.Age, person.MaxCredits).Scan(&person.ID)
 	if err != nil {
 		tx.Rollback()
+		return models.Person{}, fmt.Errorf("[in services.CreatePersonWithCourses] failed to create person: %w", classify(err))
+	}
+
+	if len(person.Courses) > 0 {
+		if err := setPersonCourses(ctx, tx, person.ID, person.Courses); err != nil {
+			tx.Rollback()
+			return models.Person{}, fmt.Errorf("[in services.CreatePersonWithCourses] %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Person{}, fmt.Errorf("[in services.CreatePersonWithCourses] failed to commit transaction: %w", classify(err))
+	}
+	return person, nil
+}
+
+// DeleteOptions changes what DeletePersonByID does with the students a
+// deleted professor advises. ReassignAdviseesTo is the ID of the professor
+// who takes them over; when it is zero, deleting a professor with advisees
+// fails with an AdviseesError.
+type DeleteOptions struct {
+	ReassignAdviseesTo int
+}
+
+// DeletePerson deletes the person with the given first name and type. It
+// fails with an AmbiguousError if more than one person matches.
+func (p PersonService) DeletePerson(ctx context.Context, firstName, personType string, opts DeleteOptions) error {
+	existing, err := p.GetPerson(ctx, firstName, personType)
+	if err != nil {
 		return fmt.Errorf("[in services.DeletePerson] failed to find person: %w", err)
 	}
+	return p.DeletePersonByID(ctx, existing.ID, opts)
+}
+
+func (p PersonService) DeletePersonByID(ctx context.Context, personID int, opts DeleteOptions) error {
+	// Start a transaction
+	tx, err := p.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[in services.DeletePersonByID] failed to start transaction: %w", classify(err))
+	}
 
-	// Delete from person_course first to avoid foreign key constraint violation
+	if err := releaseAdvisees(ctx, tx, personID, opts.ReassignAdviseesTo); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("[in services.DeletePersonByID] %w", err)
+	}
+
+	// Delete from course_waitlist and person_course first to avoid foreign
+	// key constraint violations
 	_, err = tx.ExecContext(ctx, `
+			DELETE FROM "course_waitlist"
+			WHERE "person_id" = $1
+    `, personID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("[in services.DeletePersonByID] failed to delete from course_waitlist: %w", classify(err))
+	}
+
+	dropped, err := queryIDs(ctx, tx, `
 			DELETE FROM "person_course"
 			WHERE "person_id" = $1
+			RETURNING "course_id"
     `, personID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("[in services.DeletePerson] failed to delete from person_course: %w", err)
+		return fmt.Errorf("[in services.DeletePersonByID] failed to delete from person_course: %w", err)
+	}
+	for _, courseID := range distinctIDs(dropped) {
+		if err := promoteWaitlist(ctx, tx, courseID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("[in services.DeletePersonByID] %w", err)
+		}
 	}
 
 	// Delete the person record
@@ -214,24 +676,24 @@ func (p PersonService) DeletePerson(ctx context.Context, firstName, personType s
     `, personID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("[in services.DeletePerson] failed to delete person: %w", err)
+		return fmt.Errorf("[in services.DeletePersonByID] failed to delete person: %w", classify(err))
 	}
 
 	// Check if any rows were affected
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("[in services.DeletePerson] failed to get affected rows: %w", err)
+		return fmt.Errorf("[in services.DeletePersonByID] failed to get affected rows: %w", classify(err))
 	}
 	if rowsAffected == 0 {
 		tx.Rollback()
-		return fmt.Errorf("person with first name %s and type %s does not exist", firstName, personType)
+		return fmt.Errorf("[in services.DeletePersonByID] person with ID %d does not exist: %w", personID, ErrNotFound)
 	}
 
 	// Commit the transaction
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("[in services.DeletePerson] failed to commit transaction: %w", err)
+		return fmt.Errorf("[in services.DeletePersonByID] failed to commit transaction: %w", classify(err))
 	}
 
 	return nil
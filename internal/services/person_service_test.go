@@ -33,15 +33,15 @@ func TestGetPeople(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses"}).
-			AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{101, 102})).
-			AddRow(2, "Jane", "Doe", "student", 22, pq.Array([]int64{103}))
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+			AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{101, 102}), nil, 0).
+			AddRow(2, "Jane", "Doe", "student", 22, pq.Array([]int64{103}), nil, 0)
 
-		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG\(pc.course_id\) AS courses FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 GROUP BY id, first_name, last_name, type, age;`).
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 GROUP BY id, first_name, last_name, type, age ORDER BY p.id ASC LIMIT 21;`).
 			WithArgs("student").
 			WillReturnRows(rows)
 
-		people, err := service.GetPeople(ctx, "", "", "", "student")
+		people, _, err := service.GetPeople(ctx, services.PersonFilter{Type: "student"}, services.Page{})
 		assert.NoError(t, err)
 		assert.Len(t, people, 2)
 		assert.Equal(t, "John", people[0].FirstName)
@@ -56,11 +56,11 @@ func TestGetPeople(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG\(pc.course_id\) AS courses FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 GROUP BY id, first_name, last_name, type, age;`).
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 GROUP BY id, first_name, last_name, type, age ORDER BY p.id ASC LIMIT 21;`).
 			WithArgs("student").
 			WillReturnError(errors.New("Database error"))
 
-		_, err := service.GetPeople(ctx, "", "", "", "student")
+		_, _, err := service.GetPeople(ctx, services.PersonFilter{Type: "student"}, services.Page{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get people")
 
@@ -73,14 +73,14 @@ func TestGetPeople(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses"}).
-			AddRow(1, "John", "Doe", "student", "invalid_age", pq.Array([]int64{101, 102}))
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+			AddRow(1, "John", "Doe", "student", "invalid_age", pq.Array([]int64{101, 102}), nil, 0)
 
-		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG\(pc.course_id\) AS courses FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 GROUP BY id, first_name, last_name, type, age;`).
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 GROUP BY id, first_name, last_name, type, age ORDER BY p.id ASC LIMIT 21;`).
 			WithArgs("student").
 			WillReturnRows(rows)
 
-		_, err := service.GetPeople(ctx, "", "", "", "student")
+		_, _, err := service.GetPeople(ctx, services.PersonFilter{Type: "student"}, services.Page{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to scan people from row")
 
@@ -93,14 +93,14 @@ func TestGetPeople(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses"}).
-			AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{101, 102}))
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+			AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{101, 102}), nil, 0)
 
-		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG\(pc.course_id\) AS courses FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 AND first_name = \$2 AND last_name = \$3 AND age = \$4 GROUP BY id, first_name, last_name, type, age;`).
-			WithArgs("student", "John", "Doe", "20").
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE type = \$1 AND first_name = \$2 AND last_name = \$3 AND age = \$4 GROUP BY id, first_name, last_name, type, age ORDER BY p.id ASC LIMIT 21;`).
+			WithArgs("student", "John", "Doe", 20).
 			WillReturnRows(rows)
 
-		people, err := service.GetPeople(ctx, "John", "Doe", "20", "student")
+		people, _, err := service.GetPeople(ctx, services.PersonFilter{Type: "student", FirstName: "John", LastName: "Doe", Age: 20}, services.Page{})
 		assert.NoError(t, err)
 		assert.Len(t, people, 1)
 		assert.Equal(t, "John", people[0].FirstName)
@@ -111,6 +111,166 @@ func TestGetPeople(t *testing.T) {
 	})
 }
 
+func TestGetPeopleByName(t *testing.T) {
+	ctx := context.Background()
+	service, mock := newMockPersonService(t)
+	defer service.Database.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+		AddRow(1, "Steve", "Jobs", "professor", 56, pq.Array([]int64{1}), nil, 0)
+
+	mock.ExpectQuery(`FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE \(first_name ILIKE \$1 OR last_name ILIKE \$1 OR first_name \|\| ' ' \|\| last_name ILIKE \$1\) GROUP BY id, first_name, last_name, type, age ORDER BY p.id ASC LIMIT 21;`).
+		WithArgs(`steve j\_%`).
+		WillReturnRows(rows)
+
+	people, _, err := service.GetPeople(ctx, services.PersonFilter{Name: "steve j_"}, services.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, people, 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPeopleSortedAndFiltered(t *testing.T) {
+	ctx := context.Background()
+	service, mock := newMockPersonService(t)
+	defer service.Database.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+		AddRow(4, "Bill", "Gates", "student", 67, pq.Array([]int64{1}), nil, 0).
+		AddRow(3, "Larry", "Page", "student", 51, pq.Array([]int64{1}), nil, 0)
+
+	mock.ExpectQuery(`WHERE age >= \$1 AND age <= \$2 AND p.id IN \(SELECT person_id FROM person_course WHERE course_id = ANY\(\$3\)\) `+
+		`AND \(p.last_name > \$4 OR p.last_name = \$4 AND p.age < \$5 OR p.last_name = \$4 AND p.age = \$5 AND p.id > \$6\) `+
+		`GROUP BY id, first_name, last_name, type, age ORDER BY p.last_name ASC, p.age DESC, p.id ASC LIMIT 3;`).
+		WithArgs(50, 70, pq.Array([]int64{1, 2}), "Bezos", int64(60), 2).
+		WillReturnRows(rows)
+
+	sort, err := services.ParsePersonSort("last_name,-age")
+	assert.NoError(t, err)
+
+	filter := services.PersonFilter{AgeMin: 50, AgeMax: 70, Courses: []int64{1, 2}, Sort: sort}
+	page := services.Page{Limit: 2, Cursor: &services.Cursor{ID: 2, Values: []interface{}{"Bezos", int64(60)}}}
+	people, info, err := service.GetPeople(ctx, filter, page)
+	assert.NoError(t, err)
+	assert.Len(t, people, 2)
+	assert.Empty(t, info.NextCursor)
+
+	prev, err := services.DecodeCursor(info.PrevCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, services.Cursor{ID: 4, Values: []interface{}{"Gates", int64(67)}, Backward: true}, prev)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPeopleByTerm(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Term ID", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+			AddRow(3, "Larry", "Page", "student", 51, pq.Array([]int64{1}), nil, 4)
+
+		mock.ExpectQuery(`LEFT JOIN person_course pc ON p.id = pc.person_id AND pc.term_id = \$1 WHERE type = \$2 `+
+			`AND p.id IN \(SELECT person_id FROM person_course WHERE course_id = ANY\(\$3\) AND term_id = \$1\) GROUP BY`).
+			WithArgs(2, "student", pq.Array([]int64{1})).
+			WillReturnRows(rows)
+
+		filter := services.PersonFilter{Type: "student", Courses: []int64{1}, Term: services.TermScope{ID: 2}}
+		people, _, err := service.GetPeople(ctx, filter, services.Page{})
+		assert.NoError(t, err)
+		assert.Len(t, people, 1)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Current Term", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`LEFT JOIN person_course pc ON p.id = pc.person_id AND pc.term_id IN \(SELECT id FROM term WHERE start_date <= CURRENT_DATE AND end_date >= CURRENT_DATE\) ` +
+			`WHERE p.id IN \(SELECT person_id FROM person_course WHERE term_id IN \(SELECT id FROM term WHERE start_date <= CURRENT_DATE AND end_date >= CURRENT_DATE\)\) GROUP BY`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}))
+
+		people, _, err := service.GetPeople(ctx, services.PersonFilter{Term: services.TermScope{When: services.TermCurrent}}, services.Page{})
+		assert.NoError(t, err)
+		assert.Empty(t, people)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestParsePersonSort(t *testing.T) {
+	keys, err := services.ParsePersonSort("last_name,-age")
+	assert.NoError(t, err)
+	assert.Equal(t, []services.SortKey{{Column: "last_name"}, {Column: "age", Desc: true}}, keys)
+
+	_, err = services.ParsePersonSort("password")
+	assert.ErrorIs(t, err, services.ErrConstraintViolation)
+}
+
+func TestGetPeoplePaginated(t *testing.T) {
+	ctx := context.Background()
+	service, mock := newMockPersonService(t)
+	defer service.Database.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+		AddRow(4, "Bill", "Gates", "student", 67, pq.Array([]int64{}), nil, 0).
+		AddRow(5, "Elon", "Musk", "student", 52, pq.Array([]int64{}), nil, 0).
+		AddRow(6, "Bill", "Nye", "student", 40, pq.Array([]int64{}), nil, 0)
+
+	mock.ExpectQuery(`WHERE type = \$1 AND p.id > \$2 GROUP BY id, first_name, last_name, type, age ORDER BY p.id ASC LIMIT 3;`).
+		WithArgs("student", 3).
+		WillReturnRows(rows)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM person p WHERE type = \$1`).
+		WithArgs("student").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	page := services.Page{Limit: 2, Cursor: &services.Cursor{ID: 3}, WithTotal: true}
+	people, info, err := service.GetPeople(ctx, services.PersonFilter{Type: "student"}, page)
+	assert.NoError(t, err)
+	assert.Len(t, people, 2)
+	assert.Equal(t, services.EncodeCursor(services.Cursor{ID: 5}), info.NextCursor)
+	assert.Equal(t, services.EncodeCursor(services.Cursor{ID: 4, Backward: true}), info.PrevCursor)
+	if assert.NotNil(t, info.Total) {
+		assert.Equal(t, 7, *info.Total)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPeopleTamperedCursor(t *testing.T) {
+	ctx := context.Background()
+	service, mock := newMockPersonService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`WHERE \(p.age > \$1 OR p.age = \$1 AND p.id > \$2\)`).
+		WithArgs("sixty", 2).
+		WillReturnError(&pq.Error{Code: "22P02", Message: `invalid input syntax for type integer: "sixty"`})
+
+	sort, err := services.ParsePersonSort("age")
+	assert.NoError(t, err)
+
+	page := services.Page{Cursor: &services.Cursor{ID: 2, Values: []interface{}{"sixty"}}}
+	_, _, err = service.GetPeople(ctx, services.PersonFilter{Sort: sort}, page)
+	assert.ErrorIs(t, err, services.ErrConstraintViolation)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestGetPerson(t *testing.T) {
 	ctx := context.Background()
 
@@ -118,10 +278,10 @@ func TestGetPerson(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses"}).
-			AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{101, 102}))
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+			AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{101, 102}), nil, 0)
 
-		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG\(pc.course_id\) AS courses FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
 			WithArgs("John", "student").
 			WillReturnRows(rows)
 
@@ -141,13 +301,14 @@ func TestGetPerson(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG\(pc.course_id\) AS courses FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
 			WithArgs("NonExistent", "student").
 			WillReturnError(sql.ErrNoRows)
 
 		_, err := service.GetPerson(ctx, "NonExistent", "student")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get person")
+		assert.ErrorIs(t, err, services.ErrNotFound)
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("There were unfulfilled expectations: %s", err)
@@ -158,10 +319,10 @@ func TestGetPerson(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses"}).
-			AddRow(1, "John", "Doe", "student", "invalid_age", pq.Array([]int64{101, 102})) // Age should be int, not string
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+			AddRow(1, "John", "Doe", "student", "invalid_age", pq.Array([]int64{101, 102}), nil, 0) // Age should be int, not string
 
-		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, ARRAY_AGG\(pc.course_id\) AS courses FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
 			WithArgs("John", "student").
 			WillReturnRows(rows)
 
@@ -175,6 +336,74 @@ func TestGetPerson(t *testing.T) {
 	})
 }
 
+func TestGetPersonAmbiguous(t *testing.T) {
+	ctx := context.Background()
+	service, mock := newMockPersonService(t)
+	defer service.Database.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+		AddRow(4, "Bill", "Gates", "student", 67, pq.Array([]int64{1}), nil, 0).
+		AddRow(6, "Bill", "Nye", "student", 40, pq.Array([]int64{}), nil, 0)
+
+	mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+		WithArgs("Bill", "student").
+		WillReturnRows(rows)
+
+	_, err := service.GetPerson(ctx, "Bill", "student")
+	assert.ErrorIs(t, err, services.ErrConflict)
+
+	var ambiguous *services.AmbiguousError
+	if assert.ErrorAs(t, err, &ambiguous) {
+		assert.Len(t, ambiguous.Candidates, 2)
+		assert.Equal(t, 6, ambiguous.Candidates[1].ID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPersonByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Successful Get Person", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+			AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{101, 102}), nil, 0)
+
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE p.id = \$1 GROUP BY id, first_name, last_name, type, age;`).
+			WithArgs(1).
+			WillReturnRows(rows)
+
+		person, err := service.GetPersonByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, person.ID)
+		assert.Equal(t, []int64{101, 102}, person.Courses)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Failed to Get Person - No Rows", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE p.id = \$1 GROUP BY id, first_name, last_name, type, age;`).
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := service.GetPersonByID(ctx, 99)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
 func TestUpdatePersonCourses(t *testing.T) {
 	ctx := context.Background()
 	studentID := 1
@@ -185,16 +414,19 @@ func TestUpdatePersonCourses(t *testing.T) {
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`DELETE FROM person_course WHERE person_id = \$1 AND course_id != ALL\(\$2\)`).
-			WithArgs(studentID, pq.Array(newCourses)).
-			WillReturnResult(sqlmock.NewResult(0, 2)) // Assume 2 courses were deleted
-
-		for _, courseID := range newCourses {
-			mock.ExpectExec(`INSERT INTO person_course \(person_id, course_id\) VALUES \(\$1, \$2\) ON CONFLICT DO NOTHING`).
-				WithArgs(studentID, courseID).
+		expectDropCourses(mock, studentID, newCourses, 104)
+		expectPromotion(mock, 104, 30)
+		expectKeptCourses(mock, studentID, 101)
+		expectPersonType(mock, studentID, "student")
+		for _, courseID := range []int{102, 103} {
+			expectCourseSeat(mock, courseID, 30, 10)
+			expectPrerequisitesMet(mock, studentID, courseID, nil)
+			expectNoScheduleConflicts(mock, studentID, courseID, nil)
+			mock.ExpectExec(`INSERT INTO person_course \(person_id, course_id, role\) VALUES \(\$1, \$2, \$3\)`).
+				WithArgs(studentID, courseID, models.RoleStudent).
 				WillReturnResult(sqlmock.NewResult(0, 1))
 		}
-
+		expectCreditLoad(mock, studentID, nil, "student", 18, 9)
 		mock.ExpectCommit()
 
 		err := service.UpdatePersonCourses(ctx, studentID, newCourses)
@@ -225,7 +457,7 @@ func TestUpdatePersonCourses(t *testing.T) {
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`DELETE FROM person_course WHERE person_id = \$1 AND course_id != ALL\(\$2\)`).
+		mock.ExpectQuery(`DELETE FROM person_course WHERE person_id = \$1 AND course_id != ALL\(\$2\) AND grade IS NULL AND \(term_id IS NULL OR term_id IN \(SELECT id FROM term WHERE start_date <= CURRENT_DATE AND end_date >= CURRENT_DATE\)\) RETURNING course_id`).
 			WithArgs(studentID, pq.Array(newCourses)).
 			WillReturnError(errors.New("deletion error"))
 
@@ -245,12 +477,13 @@ func TestUpdatePersonCourses(t *testing.T) {
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`DELETE FROM person_course WHERE person_id = \$1 AND course_id != ALL\(\$2\)`).
-			WithArgs(studentID, pq.Array(newCourses)).
-			WillReturnResult(sqlmock.NewResult(0, 2))
-
-		mock.ExpectExec(`INSERT INTO person_course \(person_id, course_id\) VALUES \(\$1, \$2\) ON CONFLICT DO NOTHING`).
-			WithArgs(studentID, newCourses[0]).
+		expectDropCourses(mock, studentID, newCourses)
+		expectKeptCourses(mock, studentID)
+		expectSeat(mock, studentID, 101, nil, 0)
+		expectPrerequisitesMet(mock, studentID, 101, nil)
+		expectNoScheduleConflicts(mock, studentID, 101, nil)
+		mock.ExpectExec(`INSERT INTO person_course`).
+			WithArgs(studentID, 101, models.RoleStudent).
 			WillReturnError(errors.New("insertion error"))
 
 		mock.ExpectRollback()
@@ -264,20 +497,76 @@ func TestUpdatePersonCourses(t *testing.T) {
 		}
 	})
 
-	t.Run("Failed to Commit Transaction", func(t *testing.T) {
+	t.Run("Unknown Course", func(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`DELETE FROM person_course WHERE person_id = \$1 AND course_id != ALL\(\$2\)`).
-			WithArgs(studentID, pq.Array(newCourses)).
-			WillReturnResult(sqlmock.NewResult(0, 2))
+		expectDropCourses(mock, studentID, newCourses)
+		expectKeptCourses(mock, studentID)
+		expectPersonType(mock, studentID, "student")
+		mock.ExpectQuery(`SELECT capacity FROM course WHERE id = \$1 FOR UPDATE`).
+			WithArgs(101).
+			WillReturnError(sql.ErrNoRows)
 
-		for _, courseID := range newCourses {
-			mock.ExpectExec(`INSERT INTO person_course \(person_id, course_id\) VALUES \(\$1, \$2\) ON CONFLICT DO NOTHING`).
-				WithArgs(studentID, courseID).
-				WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		err := service.UpdatePersonCourses(ctx, studentID, newCourses)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
 		}
+	})
+
+	t.Run("Course Full", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectDropCourses(mock, studentID, newCourses)
+		expectKeptCourses(mock, studentID, 101, 102)
+		expectSeat(mock, studentID, 103, 20, 20)
+		expectPrerequisitesMet(mock, studentID, 103, nil)
+		expectNoScheduleConflicts(mock, studentID, 103, nil)
+
+		mock.ExpectRollback()
+
+		err := service.UpdatePersonCourses(ctx, studentID, newCourses)
+		assert.ErrorIs(t, err, services.ErrCourseFull)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Professor Teaches", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectDropCourses(mock, studentID, newCourses)
+		expectKeptCourses(mock, studentID, 101, 102)
+		expectPersonType(mock, studentID, "professor")
+		expectCourseLock(mock, 103, 20)
+		expectNoScheduleConflicts(mock, studentID, 103, nil)
+		mock.ExpectExec(`INSERT INTO person_course`).
+			WithArgs(studentID, 103, models.RoleInstructor).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := service.UpdatePersonCourses(ctx, studentID, newCourses)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Failed to Commit Transaction", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		expectDropCourses(mock, studentID, newCourses)
+		expectKeptCourses(mock, studentID, 101, 102, 103)
 
 		mock.ExpectCommit().WillReturnError(errors.New("commit error"))
 
@@ -297,9 +586,8 @@ func TestUpdatePersonCourses(t *testing.T) {
 		emptyNewCourses := []int64{}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`DELETE FROM person_course WHERE person_id = \$1 AND course_id != ALL\(\$2\)`).
-			WithArgs(studentID, pq.Array(emptyNewCourses)).
-			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectDropCourses(mock, studentID, emptyNewCourses)
+		expectKeptCourses(mock, studentID)
 
 		mock.ExpectCommit()
 
@@ -312,83 +600,324 @@ func TestUpdatePersonCourses(t *testing.T) {
 	})
 }
 
+// expectDropCourses expects the person's courses outside of courses to be
+// removed, reporting dropped as the removed course IDs.
+func expectDropCourses(mock sqlmock.Sqlmock, personID int, courses []int64, dropped ...int) {
+	rows := sqlmock.NewRows([]string{"course_id"})
+	for _, id := range dropped {
+		rows.AddRow(id)
+	}
+	mock.ExpectQuery(`DELETE FROM person_course WHERE person_id = \$1 AND course_id != ALL\(\$2\) AND grade IS NULL AND \(term_id IS NULL OR term_id IN \(SELECT id FROM term WHERE start_date <= CURRENT_DATE AND end_date >= CURRENT_DATE\)\) RETURNING course_id`).
+		WithArgs(personID, pq.Array(courses)).
+		WillReturnRows(rows)
+}
+
+func expectKeptCourses(mock sqlmock.Sqlmock, personID int, kept ...int) {
+	rows := sqlmock.NewRows([]string{"course_id"})
+	for _, id := range kept {
+		rows.AddRow(id)
+	}
+	mock.ExpectQuery(`SELECT course_id FROM person_course WHERE person_id = \$1`).
+		WithArgs(personID).
+		WillReturnRows(rows)
+}
+
+// expectPersonRoles expects the roles the person holds to be checked against
+// the type, and held to be those the type cannot hold.
+func expectPersonRoles(mock sqlmock.Sqlmock, personID int, personType string, held ...string) {
+	allowed := map[string][]string{
+		"professor": {models.RoleInstructor, models.RoleCoInstructor, models.RoleTeachingAssistant},
+		"student":   {models.RoleTeachingAssistant, models.RoleStudent},
+	}[personType]
+	mock.ExpectQuery(`SELECT COALESCE\(ARRAY_AGG\(DISTINCT role ORDER BY role\), '\{\}'\) FROM \( SELECT role FROM person_course WHERE person_id = \$1 UNION ALL SELECT 'student' FROM course_waitlist WHERE person_id = \$1 \) held WHERE role <> ALL\(\$2\)`).
+		WithArgs(personID, pq.Array(allowed)).
+		WillReturnRows(sqlmock.NewRows([]string{"roles"}).AddRow(pq.Array(held)))
+}
+
+// expectAdvisees expects the students the person advises to be looked up.
+func expectAdvisees(mock sqlmock.Sqlmock, personID int, advisees ...int) {
+	rows := sqlmock.NewRows([]string{"student_id"})
+	for _, id := range advisees {
+		rows.AddRow(id)
+	}
+	mock.ExpectQuery(`SELECT student_id FROM student_advisor WHERE advisor_id = \$1 ORDER BY student_id`).
+		WithArgs(personID).
+		WillReturnRows(rows)
+}
+
 func TestUpdatePerson(t *testing.T) {
 	ctx := context.Background()
-	oldFirstName := "John"
-	oldPersonType := "Student"
 	updatedPerson := models.Person{
 		FirstName: "Johnny",
 		LastName:  "Doe",
-		Type:      "Graduate",
+		Type:      "student",
 		Age:       25,
 	}
+
 	t.Run("Success", func(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		mock.ExpectExec(`
-		UPDATE "person" 
-		SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4 
-		WHERE "first_name" = \$5 AND "type" = \$6
-		`).
-			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, oldFirstName, oldPersonType).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+			WithArgs("John", "student").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+				AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{}), nil, 0))
 
-		mock.ExpectQuery(`
-		SELECT id, first_name, last_name, type, age 
-		FROM "person" 
-		WHERE "first_name" = \$1 AND "type" = \$2
-		`).
-			WithArgs(updatedPerson.FirstName, updatedPerson.Type).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age"}).
-				AddRow(1, updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age))
+		mock.ExpectQuery(`UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4, "max_credits" = \$5 WHERE "id" = \$6 RETURNING id, first_name, last_name, type, age, max_credits;`).
+			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, nil, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "max_credits"}).
+				AddRow(1, updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, nil))
 
-		result, err := service.UpdatePerson(ctx, oldFirstName, oldPersonType, updatedPerson)
+		result, err := service.UpdatePerson(ctx, "John", "student", updatedPerson)
 
 		assert.NoError(t, err)
 		assert.Equal(t, 1, result.ID)
 		assert.Equal(t, updatedPerson.FirstName, result.FirstName)
-		assert.Equal(t, updatedPerson.LastName, result.LastName)
-		assert.Equal(t, updatedPerson.Type, result.Type)
-		assert.Equal(t, updatedPerson.Age, result.Age)
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("There were unfulfilled expectations: %s", err)
 		}
 	})
-	t.Run("Update Failure", func(t *testing.T) {
+
+	t.Run("Ambiguous Name", func(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		mock.ExpectExec(`UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4 WHERE "first_name" = \$5 AND "type" = \$6`).
-			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, oldFirstName, oldPersonType).
-			WillReturnError(errors.New("update failed"))
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+			WithArgs("John", "student").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+				AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{}), nil, 0).
+				AddRow(2, "John", "Smith", "student", 22, pq.Array([]int64{}), nil, 0))
 
-		_, err := service.UpdatePerson(ctx, oldFirstName, oldPersonType, updatedPerson)
+		_, err := service.UpdatePerson(ctx, "John", "student", updatedPerson)
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to update person")
+		assert.ErrorIs(t, err, services.ErrConflict)
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("There were unfulfilled expectations: %s", err)
 		}
 	})
-	t.Run("Retrieval Failure", func(t *testing.T) {
+}
+
+func TestUpdatePersonWithCourses(t *testing.T) {
+	ctx := context.Background()
+	person := models.Person{FirstName: "Johnny", LastName: "Doe", Type: "student", Age: 25, Courses: []int64{1, 3}}
+	updateQuery := `UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4, "max_credits" = \$5 WHERE "id" = \$6`
+
+	t.Run("Success", func(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		mock.ExpectExec(`UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4 WHERE "first_name" = \$5 AND "type" = \$6`).
-			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, oldFirstName, oldPersonType).
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).
+			WithArgs(person.FirstName, person.LastName, person.Type, person.Age, nil, 1).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPersonRoles(mock, 1, "student")
+		expectAdvisees(mock, 1)
+		expectDropCourses(mock, 1, []int64{1, 3}, 2)
+		expectPromotion(mock, 2, nil)
+		expectKeptCourses(mock, 1, 1)
+		expectSeat(mock, 1, 3, nil, 0)
+		expectPrerequisitesMet(mock, 1, 3, nil)
+		expectNoScheduleConflicts(mock, 1, 3, nil)
+		mock.ExpectExec(`INSERT INTO person_course`).WithArgs(1, 3, models.RoleStudent).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCreditLoad(mock, 1, nil, "student", 18, 6)
+		mock.ExpectQuery(`FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE p.id = \$1`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+				AddRow(1, "Johnny", "Doe", "student", 25, pq.Array([]int64{1, 3}), nil, 0))
+		mock.ExpectCommit()
+
+		result, err := service.UpdatePersonWithCourses(ctx, 1, person)
+		assert.NoError(t, err)
+		expected := person
+		expected.ID = 1
+		assert.Equal(t, expected, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 
-		mock.ExpectQuery(`SELECT id, first_name, last_name, type, age FROM "person" WHERE "first_name" = \$1 AND "type" = \$2`).
-			WithArgs(updatedPerson.FirstName, updatedPerson.Type).
-			WillReturnError(errors.New("retrieval failed"))
+	t.Run("Graded Past Course Survives", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		// Course 2 was taken and graded in a past term. Leaving it out of
+		// the list must not delete it, so it is still among the kept
+		// courses and is not re-enrolled.
+		current := models.Person{FirstName: "Johnny", LastName: "Doe", Type: "student", Age: 25, Courses: []int64{1}}
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPersonRoles(mock, 1, "student")
+		expectAdvisees(mock, 1)
+		expectDropCourses(mock, 1, []int64{1})
+		expectKeptCourses(mock, 1, 1, 2)
+		mock.ExpectQuery(`FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE p.id = \$1`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+				AddRow(1, "Johnny", "Doe", "student", 25, pq.Array([]int64{1, 2}), nil, 4))
+		mock.ExpectCommit()
+
+		result, err := service.UpdatePersonWithCourses(ctx, 1, current)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, result.Courses)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Course Rolls Back", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPersonRoles(mock, 1, "student")
+		expectAdvisees(mock, 1)
+		expectDropCourses(mock, 1, []int64{1, 3})
+		expectKeptCourses(mock, 1)
+		expectSeat(mock, 1, 1, nil, 0)
+		expectPrerequisitesMet(mock, 1, 1, nil)
+		expectNoScheduleConflicts(mock, 1, 1, nil)
+		mock.ExpectExec(`INSERT INTO person_course`).WithArgs(1, 1, models.RoleStudent).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT capacity FROM course WHERE id = \$1 FOR UPDATE`).WithArgs(3).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
 
-		_, err := service.UpdatePerson(ctx, oldFirstName, oldPersonType, updatedPerson)
+		_, err := service.UpdatePersonWithCourses(ctx, 1, person)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := service.UpdatePersonWithCourses(ctx, 99, person)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nil Courses Clears Enrollments", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPersonRoles(mock, 1, "student")
+		expectAdvisees(mock, 1)
+		expectDropCourses(mock, 1, []int64{}, 1, 3)
+		expectPromotion(mock, 1, nil)
+		expectPromotion(mock, 3, 30)
+		expectKeptCourses(mock, 1)
+		mock.ExpectQuery(`FROM person p`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+				AddRow(1, "Johnny", "Doe", "student", 25, pq.Array([]int64{}), nil, 0))
+		mock.ExpectCommit()
+
+		withoutCourses := person
+		withoutCourses.Courses = nil
+		result, err := service.UpdatePersonWithCourses(ctx, 1, withoutCourses)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Courses)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Type Change Keeps Roles", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		professor := person
+		professor.Type = "professor"
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPersonRoles(mock, 1, "professor", models.RoleStudent)
+		mock.ExpectRollback()
+
+		_, err := service.UpdatePersonWithCourses(ctx, 1, professor)
+		assert.ErrorIs(t, err, services.ErrConstraintViolation)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Type Change Keeps Advisees", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPersonRoles(mock, 1, "student")
+		expectAdvisees(mock, 1, 3, 4)
+		mock.ExpectRollback()
+
+		_, err := service.UpdatePersonWithCourses(ctx, 1, person)
+		assert.ErrorIs(t, err, services.ErrConstraintViolation)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Type Change Keeps Advisor", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		professor := person
+		professor.Type = "professor"
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectPersonRoles(mock, 1, "professor")
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM student_advisor WHERE student_id = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		_, err := service.UpdatePersonWithCourses(ctx, 1, professor)
+		assert.ErrorIs(t, err, services.ErrConstraintViolation)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdatePersonByID(t *testing.T) {
+	ctx := context.Background()
+	updatedPerson := models.Person{
+		FirstName: "Johnny",
+		LastName:  "Doe",
+		Type:      "student",
+		Age:       25,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4, "max_credits" = \$5 WHERE "id" = \$6 RETURNING id, first_name, last_name, type, age, max_credits;`).
+			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, nil, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "max_credits"}).
+				AddRow(1, updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, nil))
+
+		result, err := service.UpdatePersonByID(ctx, 1, updatedPerson)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.ID)
+		assert.Equal(t, updatedPerson.FirstName, result.FirstName)
+		assert.Equal(t, updatedPerson.LastName, result.LastName)
+		assert.Equal(t, updatedPerson.Type, result.Type)
+		assert.Equal(t, updatedPerson.Age, result.Age)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Update Failure", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4, "max_credits" = \$5 WHERE "id" = \$6 RETURNING id, first_name, last_name, type, age, max_credits;`).
+			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, nil, 1).
+			WillReturnError(errors.New("update failed"))
+
+		_, err := service.UpdatePersonByID(ctx, 1, updatedPerson)
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to retrieve updated person")
+		assert.Contains(t, err.Error(), "failed to update person")
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("There were unfulfilled expectations: %s", err)
@@ -399,18 +928,13 @@ func TestUpdatePerson(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
 
-		mock.ExpectExec(`UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4 WHERE "first_name" = \$5 AND "type" = \$6`).
-			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, oldFirstName, oldPersonType).
-			WillReturnResult(sqlmock.NewResult(0, 1))
-
-		mock.ExpectQuery(`SELECT id, first_name, last_name, type, age FROM "person" WHERE "first_name" = \$1 AND "type" = \$2`).
-			WithArgs(updatedPerson.FirstName, updatedPerson.Type).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age"}))
+		mock.ExpectQuery(`UPDATE "person" SET "first_name" = \$1, "last_name" = \$2, "type" = \$3, "age" = \$4, "max_credits" = \$5 WHERE "id" = \$6 RETURNING id, first_name, last_name, type, age, max_credits;`).
+			WithArgs(updatedPerson.FirstName, updatedPerson.LastName, updatedPerson.Type, updatedPerson.Age, nil, 99).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "max_credits"}))
 
-		_, err := service.UpdatePerson(ctx, oldFirstName, oldPersonType, updatedPerson)
+		_, err := service.UpdatePersonByID(ctx, 99, updatedPerson)
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "[in services.UpdatePerson] failed to retrieve updated person")
+		assert.ErrorIs(t, err, services.ErrNotFound)
 		assert.Contains(t, err.Error(), "sql: no rows in result set")
 
 		if err := mock.ExpectationsWereMet(); err != nil {
@@ -427,11 +951,11 @@ func TestCreatePerson(t *testing.T) {
 
 		mock.ExpectQuery(`
 		(?i)^INSERT INTO "person" 
-		\(first_name, last_name, type, age\) 
-		VALUES \(\$1, \$2, \$3, \$4\) 
+		\(first_name, last_name, type, age, max_credits\) 
+		VALUES \(\$1, \$2, \$3, \$4, \$5\) 
 		RETURNING id$
 		`).
-			WithArgs("John", "Smith", "student", 22).
+			WithArgs("John", "Smith", "student", 22, nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 		// mock.ExpectCommit()
@@ -455,11 +979,11 @@ func TestCreatePerson(t *testing.T) {
 
 		mock.ExpectQuery(`
 		(?i)^INSERT INTO "person" 
-		\(first_name, last_name, type, age\) 
-		VALUES \(\$1, \$2, \$3, \$4\) 
+		\(first_name, last_name, type, age, max_credits\) 
+		VALUES \(\$1, \$2, \$3, \$4, \$5\) 
 		RETURNING id$
 		`).
-			WithArgs("John", "Smith", "student", 22).
+			WithArgs("John", "Smith", "student", 22, nil).
 			WillReturnError(fmt.Errorf("[in services.CreatePerson] failed to create person"))
 
 		_, err := service.CreatePerson(ctx, models.Person{
@@ -479,28 +1003,70 @@ func TestCreatePerson(t *testing.T) {
 	})
 }
 
-func TestDeletePerson(t *testing.T) {
+func TestCreatePersonWithCourses(t *testing.T) {
 	ctx := context.Background()
+	person := models.Person{FirstName: "John", LastName: "Smith", Type: "student", Age: 22, Courses: []int64{2}}
+
 	t.Run("Success", func(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
+
 		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "person" \(first_name, last_name, type, age, max_credits\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING id`).
+			WithArgs("John", "Smith", "student", 22, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+		expectDropCourses(mock, 8, []int64{2})
+		expectKeptCourses(mock, 8)
+		expectSeat(mock, 8, 2, nil, 0)
+		expectPrerequisitesMet(mock, 8, 2, nil)
+		expectNoScheduleConflicts(mock, 8, 2, nil)
+		mock.ExpectExec(`INSERT INTO person_course`).WithArgs(8, 2, models.RoleStudent).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCreditLoad(mock, 8, nil, "student", 18, 3)
+		mock.ExpectCommit()
 
-		// Mock the person ID query
-		mock.ExpectQuery(`
-		SELECT id FROM "person" 
-		WHERE "first_name" = \$1 AND "type" = \$2
-		`).
-			WithArgs("John", "student").
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		created, err := service.CreatePersonWithCourses(ctx, person)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, created.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Course Full Leaves No Person", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "person"`).
+			WithArgs("John", "Smith", "student", 22, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+		expectDropCourses(mock, 8, []int64{2})
+		expectKeptCourses(mock, 8)
+		expectSeat(mock, 8, 2, 1, 1)
+		expectPrerequisitesMet(mock, 8, 2, nil)
+		expectNoScheduleConflicts(mock, 8, 2, nil)
+		mock.ExpectRollback()
+
+		_, err := service.CreatePersonWithCourses(ctx, person)
+		assert.ErrorIs(t, err, services.ErrCourseFull)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeletePersonByID(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+		mock.ExpectBegin()
+		expectNoAdvisees(mock, 1)
 
 		// Mock the deletion from person_course
-		mock.ExpectExec(`
-		DELETE FROM "person_course" 
-		WHERE "person_id" = \$1
-		`).
+		mock.ExpectExec(`DELETE FROM "course_waitlist" WHERE "person_id" = \$1`).
 			WithArgs(1).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`DELETE FROM "person_course" WHERE "person_id" = \$1 RETURNING "course_id"`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}).AddRow(1))
+		expectPromotion(mock, 1, nil)
 
 		// Mock the deletion from person
 		mock.ExpectExec(`
@@ -513,7 +1079,7 @@ func TestDeletePerson(t *testing.T) {
 		mock.ExpectCommit()
 
 		// Call the method under test
-		err := service.DeletePerson(ctx, "John", "student")
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
 		assert.NoError(t, err)
 
 		// Ensure all expectations were met
@@ -521,34 +1087,6 @@ func TestDeletePerson(t *testing.T) {
 			t.Errorf("unfulfilled expectations: %v", err)
 		}
 	})
-	t.Run("Person not found", func(t *testing.T) {
-		service, mock := newMockPersonService(t)
-		defer service.Database.Close()
-
-		// Mock the transaction begin
-		mock.ExpectBegin()
-
-		// Mock the person ID query to return no rows (person not found)
-		mock.ExpectQuery(`
-		SELECT id FROM "person" 
-		WHERE "first_name" = \$1 AND "type" = \$2
-		`).
-			WithArgs("NonExistent", "student").
-			WillReturnRows(sqlmock.NewRows([]string{"id"}))
-
-		// Mock the transaction rollback since the person is not found
-		mock.ExpectRollback()
-
-		// Call the method under test
-		err := service.DeletePerson(ctx, "NonExistent", "student")
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "[in services.DeletePerson] failed to find person: sql: no rows in result set")
-
-		// Ensure all expectations were met
-		if err := mock.ExpectationsWereMet(); err != nil {
-			t.Errorf("unfulfilled expectations: %v", err)
-		}
-	})
 	t.Run("Failed to Start Transaction", func(t *testing.T) {
 		service, mock := newMockPersonService(t)
 		defer service.Database.Close()
@@ -557,7 +1095,7 @@ func TestDeletePerson(t *testing.T) {
 		mock.ExpectBegin().WillReturnError(fmt.Errorf("failed to start transaction"))
 
 		// Call the method under test
-		err := service.DeletePerson(ctx, "John", "student")
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to start transaction")
 
@@ -571,24 +1109,18 @@ func TestDeletePerson(t *testing.T) {
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
+		expectNoAdvisees(mock, 1)
 
-		mock.ExpectQuery(`
-		SELECT id FROM "person"
-        WHERE "first_name" = \$1 AND "type" = \$2
-		`).
-			WithArgs("John", "student").
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
-
-		mock.ExpectExec(`
-		DELETE FROM "person_course" 
-		WHERE "person_id" = \$1
-		`).
+		mock.ExpectExec(`DELETE FROM "course_waitlist" WHERE "person_id" = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`DELETE FROM "person_course" WHERE "person_id" = \$1 RETURNING "course_id"`).
 			WithArgs(1).
 			WillReturnError(fmt.Errorf("[in services.DeletePerson] failed to delete from person_course"))
 
 		mock.ExpectRollback()
 
-		err := service.DeletePerson(ctx, "John", "student")
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to delete from person_course")
 
@@ -603,19 +1135,15 @@ func TestDeletePerson(t *testing.T) {
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
+		expectNoAdvisees(mock, 1)
 
-		mock.ExpectQuery(`
-		SELECT id FROM "person"
-        WHERE "first_name" = \$1 AND "type" = \$2
-		`).
-			WithArgs("John", "student").
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
-
-		mock.ExpectExec(`
-		DELETE FROM "person_course" WHERE "person_id" = \$1
-		`).
+		mock.ExpectExec(`DELETE FROM "course_waitlist" WHERE "person_id" = \$1`).
 			WithArgs(1).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`DELETE FROM "person_course" WHERE "person_id" = \$1 RETURNING "course_id"`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}).AddRow(1))
+		expectPromotion(mock, 1, nil)
 
 		mock.ExpectExec(`
 		DELETE FROM "person" WHERE "id" = \$1
@@ -625,9 +1153,10 @@ func TestDeletePerson(t *testing.T) {
 
 		mock.ExpectRollback()
 
-		err := service.DeletePerson(ctx, "John", "student")
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "does not exist")
+		assert.ErrorIs(t, err, services.ErrNotFound)
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("unfulfilled expectations: %v", err)
 		}
@@ -638,19 +1167,15 @@ func TestDeletePerson(t *testing.T) {
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
+		expectNoAdvisees(mock, 1)
 
-		mock.ExpectQuery(`
-		SELECT id FROM "person"
-        WHERE "first_name" = \$1 AND "type" = \$2
-		`).
-			WithArgs("John", "student").
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
-
-		mock.ExpectExec(`
-		DELETE FROM "person_course" WHERE "person_id" = \$1
-		`).
+		mock.ExpectExec(`DELETE FROM "course_waitlist" WHERE "person_id" = \$1`).
 			WithArgs(1).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`DELETE FROM "person_course" WHERE "person_id" = \$1 RETURNING "course_id"`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}).AddRow(1))
+		expectPromotion(mock, 1, nil)
 
 		mock.ExpectExec(`
 		DELETE FROM "person" WHERE "id" = \$1
@@ -660,7 +1185,7 @@ func TestDeletePerson(t *testing.T) {
 
 		mock.ExpectRollback()
 
-		err := service.DeletePerson(ctx, "John", "student")
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get affected rows")
 		if err := mock.ExpectationsWereMet(); err != nil {
@@ -673,19 +1198,15 @@ func TestDeletePerson(t *testing.T) {
 		defer service.Database.Close()
 
 		mock.ExpectBegin()
+		expectNoAdvisees(mock, 1)
 
-		mock.ExpectQuery(`
-		SELECT id FROM "person"
-        WHERE "first_name" = \$1 AND "type" = \$2
-		`).
-			WithArgs("John", "student").
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
-
-		mock.ExpectExec(`
-		DELETE FROM "person_course" WHERE "person_id" = \$1
-		`).
+		mock.ExpectExec(`DELETE FROM "course_waitlist" WHERE "person_id" = \$1`).
 			WithArgs(1).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`DELETE FROM "person_course" WHERE "person_id" = \$1 RETURNING "course_id"`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}).AddRow(1))
+		expectPromotion(mock, 1, nil)
 
 		mock.ExpectExec(`
 		DELETE FROM "person" WHERE "id" = \$1
@@ -695,7 +1216,7 @@ func TestDeletePerson(t *testing.T) {
 
 		mock.ExpectCommit().WillReturnError(fmt.Errorf("failed to commit transaction"))
 
-		err := service.DeletePerson(ctx, "John", "student")
+		err := service.DeletePersonByID(ctx, 1, services.DeleteOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to commit transaction")
 		if err := mock.ExpectationsWereMet(); err != nil {
@@ -705,6 +1226,102 @@ func TestDeletePerson(t *testing.T) {
 
 }
 
+func TestDeletePerson(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+			WithArgs("John", "student").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+				AddRow(1, "John", "Doe", "student", 20, pq.Array([]int64{1}), nil, 0))
+
+		mock.ExpectBegin()
+		expectNoAdvisees(mock, 1)
+		mock.ExpectExec(`DELETE FROM "course_waitlist" WHERE "person_id" = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`DELETE FROM "person_course" WHERE "person_id" = \$1 RETURNING "course_id"`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}).AddRow(1))
+		expectPromotion(mock, 1, nil)
+		mock.ExpectExec(`DELETE FROM "person" WHERE "id" = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := service.DeletePerson(ctx, "John", "student", services.DeleteOptions{})
+		assert.NoError(t, err)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("Person not found", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+			WithArgs("NonExistent", "student").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}))
+
+		err := service.DeletePerson(ctx, "NonExistent", "student", services.DeleteOptions{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "[in services.DeletePerson] failed to find person")
+		assert.ErrorIs(t, err, services.ErrNotFound)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("Ambiguous Name", func(t *testing.T) {
+		service, mock := newMockPersonService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT p.id, p.first_name, p.last_name, p.type, p.age, COALESCE\(ARRAY_AGG\(DISTINCT pc.course_id\) FILTER \(WHERE pc.course_id IS NOT NULL\), '\{\}'\) AS courses, p.max_credits, \(.*\) AS credits FROM person p LEFT JOIN person_course pc ON p.id = pc.person_id WHERE "first_name" = \$1 AND "type" = \$2 GROUP BY id, first_name, last_name, type, age;`).
+			WithArgs("Bill", "student").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "type", "age", "courses", "max_credits", "credits"}).
+				AddRow(4, "Bill", "Gates", "student", 67, pq.Array([]int64{}), nil, 0).
+				AddRow(6, "Bill", "Nye", "student", 40, pq.Array([]int64{}), nil, 0))
+
+		err := service.DeletePerson(ctx, "Bill", "student", services.DeleteOptions{})
+		assert.ErrorIs(t, err, services.ErrConflict)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestGetCoursesByPerson(t *testing.T) {
+	service, mock := newMockPersonService(t)
+	defer service.Database.Close()
+
+	rows := sqlmock.NewRows([]string{"person_id", "id", "code", "name", "department_id", "capacity", "credits"}).
+		AddRow(1, 1, "MATH-101", "Math", nil, nil, 3).
+		AddRow(1, 2, "PHYS-101", "Physics", nil, nil, 4)
+	mock.ExpectQuery(`SELECT DISTINCT pc.person_id, c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM person_course pc JOIN course c ON c.id = pc.course_id WHERE pc.person_id = ANY\(\$1\) ORDER BY pc.person_id, c.id;`).
+		WithArgs(pq.Array([]int{1, 2})).
+		WillReturnRows(rows)
+
+	courses, err := service.GetCoursesByPerson(context.Background(), []int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int][]models.Course{
+		1: {{ID: 1, Code: "MATH-101", Name: "Math", Credits: 3}, {ID: 2, Code: "PHYS-101", Name: "Physics", Credits: 4}},
+		2: {},
+	}, courses)
+
+	mock.ExpectQuery(`FROM person_course pc`).WillReturnError(sql.ErrConnDone)
+	_, err = service.GetCoursesByPerson(context.Background(), []int{1})
+	assert.ErrorIs(t, err, services.ErrUnavailable)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func newMockPersonService(t *testing.T) (services.PersonService, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
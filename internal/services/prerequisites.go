@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+// GetPrerequisites returns the courses that must be completed before the
+// course with the given ID can be taken, ordered by ID.
+func (c CourseService) GetPrerequisites(ctx context.Context, courseID int) ([]models.Course, error) {
+	var exists bool
+	err := c.Database.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM "course" WHERE "id" = $1)
+    `, courseID).Scan(&exists)
+	if err != nil {
+		return []models.Course{}, fmt.Errorf("[in services.GetPrerequisites] failed to check course existence: %w", classify(err))
+	}
+	if !exists {
+		return []models.Course{}, fmt.Errorf("[in services.GetPrerequisites] course with ID %d does not exist: %w", courseID, ErrNotFound)
+	}
+
+	rows, err := c.Database.QueryContext(ctx, `
+	SELECT `+courseColumns+`
+		FROM course_prerequisite cp
+		JOIN course c ON c.id = cp.prerequisite_id
+		WHERE cp.course_id = $1
+		ORDER BY c.id
+	`, courseID)
+	if err != nil {
+		return []models.Course{}, fmt.Errorf("[in services.GetPrerequisites] failed to get prerequisites: %w", classify(err))
+	}
+	courses, err := scanCourses(rows)
+	if err != nil {
+		return []models.Course{}, fmt.Errorf("[in services.GetPrerequisites] %w", err)
+	}
+	return courses, nil
+}
+
+// AddPrerequisite makes the course with ID prerequisiteID a prerequisite of
+// the course with ID courseID. Adding an existing prerequisite does nothing.
+// It fails with a PrerequisiteCycleError if the prerequisite already
+// requires the course, directly or through other courses.
+func (c CourseService) AddPrerequisite(ctx context.Context, courseID, prerequisiteID int) error {
+	if courseID == prerequisiteID {
+		return fmt.Errorf("[in services.AddPrerequisite] %w", &PrerequisiteCycleError{Cycle: []int{courseID, courseID}})
+	}
+
+	tx, err := c.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[in services.AddPrerequisite] failed to start transaction: %w", classify(err))
+	}
+
+	// Edits to the graph are serialized, otherwise two concurrent edits
+	// could each pass the cycle check and close a cycle between them.
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE course_prerequisite IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("[in services.AddPrerequisite] failed to lock prerequisites: %w", classify(err))
+	}
+
+	// Walk everything the new prerequisite requires. Reaching the course
+	// means the new edge would close a cycle.
+	var path []int64
+	err = tx.QueryRowContext(ctx, `
+	WITH RECURSIVE requires(id, path) AS (
+		SELECT prerequisite_id, ARRAY[course_id, prerequisite_id]
+			FROM course_prerequisite
+			WHERE course_id = $1
+		UNION ALL
+		SELECT cp.prerequisite_id, r.path || cp.prerequisite_id
+			FROM course_prerequisite cp
+			JOIN requires r ON cp.course_id = r.id
+			WHERE NOT cp.prerequisite_id = ANY(r.path)
+	)
+	SELECT path FROM requires
+		WHERE id = $2
+		ORDER BY array_length(path, 1)
+		LIMIT 1
+	`, prerequisiteID, courseID).Scan(pq.Array(&path))
+	switch {
+	case err == nil:
+		tx.Rollback()
+		cycle := []int{courseID}
+		for _, id := range path {
+			cycle = append(cycle, int(id))
+		}
+		return fmt.Errorf("[in services.AddPrerequisite] %w", &PrerequisiteCycleError{Cycle: cycle})
+	case !errors.Is(err, sql.ErrNoRows):
+		tx.Rollback()
+		return fmt.Errorf("[in services.AddPrerequisite] failed to check for cycles: %w", classify(err))
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO course_prerequisite (course_id, prerequisite_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING
+	`, courseID, prerequisiteID)
+	if err != nil {
+		tx.Rollback()
+		err = classify(err)
+		// Both courses are named in the URL, so a missing one is not found
+		// rather than a bad reference in a payload.
+		if errors.Is(err, ErrInvalidReference) {
+			err = withKind(ErrNotFound, err)
+		}
+		return fmt.Errorf("[in services.AddPrerequisite] failed to add prerequisite: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("[in services.AddPrerequisite] failed to commit transaction: %w", classify(err))
+	}
+	return nil
+}
+
+// RemovePrerequisite stops the course with ID prerequisiteID from being a
+// prerequisite of the course with ID courseID.
+func (c CourseService) RemovePrerequisite(ctx context.Context, courseID, prerequisiteID int) error {
+	result, err := c.Database.ExecContext(ctx, `
+	DELETE FROM course_prerequisite
+	WHERE course_id = $1 AND prerequisite_id = $2
+	`, courseID, prerequisiteID)
+	if err != nil {
+		return fmt.Errorf("[in services.RemovePrerequisite] failed to remove prerequisite: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.RemovePrerequisite] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.RemovePrerequisite] course %d is not a prerequisite of course %d: %w", prerequisiteID, courseID, ErrNotFound)
+	}
+	return nil
+}
+
+// prerequisiteMet is the condition that the person whose ID is the SQL
+// expression person has met the prerequisite whose ID is the expression
+// prerequisite for a course in the term given by the expression term: they
+// completed it, as defined by completedCourse, in a term that ended before
+// that term starts, or before today when term is NULL. Prerequisites taken
+// without a term only need to be completed.
+func prerequisiteMet(person, prerequisite, term string) string {
+	return `EXISTS (
+			SELECT 1
+			FROM person_course pc
+			LEFT JOIN term t ON t.id = pc.term_id
+			WHERE pc.person_id = ` + person + `
+			AND pc.course_id = ` + prerequisite + `
+			AND ` + completedCourse("pc") + `
+			AND (t.id IS NULL OR t.end_date < COALESCE((SELECT start_date FROM term WHERE id = ` + term + `), CURRENT_DATE))
+		)`
+}
+
+// checkPrerequisites fails with a MissingPrerequisitesError if the person
+// is a student who has not met every prerequisite of the course, as defined
+// by prerequisiteMet. Professors do not need prerequisites.
+func checkPrerequisites(ctx context.Context, tx *sql.Tx, personID, courseID int, termID *int) error {
+	rows, err := tx.QueryContext(ctx, `
+	SELECT `+courseColumns+`
+		FROM course_prerequisite cp
+		JOIN course c ON c.id = cp.prerequisite_id
+		WHERE cp.course_id = $2
+		AND EXISTS (SELECT 1 FROM person WHERE id = $1 AND type = 'student')
+		AND NOT `+prerequisiteMet("$1", "cp.prerequisite_id", "$3")+`
+		ORDER BY c.id
+	`, personID, courseID, termID)
+	if err != nil {
+		return fmt.Errorf("failed to check prerequisites of course %d: %w", courseID, classify(err))
+	}
+	missing, err := scanCourses(rows)
+	if err != nil {
+		return fmt.Errorf("failed to check prerequisites of course %d: %w", courseID, err)
+	}
+	if len(missing) > 0 {
+		return &MissingPrerequisitesError{CourseID: courseID, Missing: missing}
+	}
+	return nil
+}
+
+// scanCourses reads courseColumns rows into courses and closes rows.
+func scanCourses(rows *sql.Rows) ([]models.Course, error) {
+	defer rows.Close()
+
+	courses := []models.Course{}
+	for rows.Next() {
+		course, err := scanCourse(rows.Scan)
+		if err != nil {
+			return []models.Course{}, fmt.Errorf("failed to scan course: %w", classify(err))
+		}
+		courses = append(courses, course)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Course{}, fmt.Errorf("failed to scan courses: %w", classify(err))
+	}
+	return courses, nil
+}
@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPrerequisites(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM "course" WHERE "id" = \$1\)`).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.department_id, c.capacity, c.credits FROM course_prerequisite cp JOIN course c ON c.id = cp.prerequisite_id WHERE cp.course_id = \$1 ORDER BY c.id`).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).AddRow(1, "CS-101", "Programming", nil, 30, 4))
+
+		courses, err := service.GetPrerequisites(context.Background(), 2)
+		require.NoError(t, err)
+		capacity := 30
+		require.Equal(t, []models.Course{{ID: 1, Code: "CS-101", Name: "Programming", Capacity: &capacity, Credits: 4}}, courses)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		_, err := service.GetPrerequisites(context.Background(), 9)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAddPrerequisite(t *testing.T) {
+	cycleQuery := `WITH RECURSIVE requires\(id, path\) AS \(.*\) SELECT path FROM requires WHERE id = \$2`
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`LOCK TABLE course_prerequisite IN SHARE ROW EXCLUSIVE MODE`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(cycleQuery).WithArgs(1, 3).WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(`INSERT INTO course_prerequisite \(course_id, prerequisite_id\) VALUES \(\$1, \$2\) ON CONFLICT DO NOTHING`).
+			WithArgs(3, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, service.AddPrerequisite(context.Background(), 3, 1))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`LOCK TABLE`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(cycleQuery).
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"path"}).AddRow(pq.Array([]int64{2, 1})))
+		mock.ExpectRollback()
+
+		err := service.AddPrerequisite(context.Background(), 1, 2)
+		require.ErrorIs(t, err, services.ErrConflict)
+		var cycle *services.PrerequisiteCycleError
+		require.True(t, errors.As(err, &cycle))
+		require.Equal(t, []int{1, 2, 1}, cycle.Cycle)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Own Prerequisite", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		err := service.AddPrerequisite(context.Background(), 1, 1)
+		var cycle *services.PrerequisiteCycleError
+		require.True(t, errors.As(err, &cycle))
+		require.Equal(t, []int{1, 1}, cycle.Cycle)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`LOCK TABLE`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(cycleQuery).WithArgs(9, 1).WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(`INSERT INTO course_prerequisite`).
+			WithArgs(1, 9).
+			WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
+
+		err := service.AddPrerequisite(context.Background(), 1, 9)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRemovePrerequisite(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`DELETE FROM course_prerequisite WHERE course_id = \$1 AND prerequisite_id = \$2`).
+			WithArgs(2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, service.RemovePrerequisite(context.Background(), 2, 1))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not A Prerequisite", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`DELETE FROM course_prerequisite`).
+			WithArgs(2, 3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.ErrorIs(t, service.RemovePrerequisite(context.Background(), 2, 3), services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEnrollMissingPrerequisites(t *testing.T) {
+	service, mock := newMockEnrollmentService(t)
+	defer service.Database.Close()
+
+	mock.ExpectBegin()
+	expectSeat(mock, 3, 2, nil, 0)
+	expectNotEnrolled(mock, 3, 2)
+	mock.ExpectQuery(`FROM course_prerequisite cp .* AND pc.course_id = cp.prerequisite_id AND pc.role = 'student' AND pc.grade_points > 0 AND \(t.id IS NULL OR t.end_date <`).
+		WithArgs(3, 2, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "department_id", "capacity", "credits"}).AddRow(1, "CS-101", "Programming", nil, nil, 4))
+	mock.ExpectRollback()
+
+	_, err := service.Enroll(context.Background(), models.Enrollment{PersonID: 3, CourseID: 2}, services.EnrollOptions{})
+	require.ErrorIs(t, err, services.ErrConflict)
+	var missing *services.MissingPrerequisitesError
+	require.True(t, errors.As(err, &missing))
+	require.Equal(t, []models.Course{{ID: 1, Code: "CS-101", Name: "Programming", Credits: 4}}, missing.Missing)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
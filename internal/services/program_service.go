@@ -0,0 +1,471 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+type ProgramService struct {
+	Database *sql.DB
+}
+
+func NewProgramService(db *sql.DB) *ProgramService {
+	return &ProgramService{
+		Database: db,
+	}
+}
+
+// GetPrograms returns every program with its requirements, ordered by name.
+func (s ProgramService) GetPrograms(ctx context.Context) ([]models.Program, error) {
+	rows, err := s.Database.QueryContext(ctx, `
+	SELECT "id", "name"
+		FROM "program"
+		ORDER BY "name"
+	`)
+	if err != nil {
+		return []models.Program{}, fmt.Errorf("[in services.GetPrograms] failed to get programs: %w", classify(err))
+	}
+	defer rows.Close()
+
+	programs := []models.Program{}
+	for rows.Next() {
+		var program models.Program
+		if err := rows.Scan(&program.ID, &program.Name); err != nil {
+			return []models.Program{}, fmt.Errorf("[in services.GetPrograms] failed to scan program: %w", classify(err))
+		}
+		programs = append(programs, program)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Program{}, fmt.Errorf("[in services.GetPrograms] failed to scan programs: %w", classify(err))
+	}
+
+	if err := getRequirements(ctx, s.Database, programs); err != nil {
+		return []models.Program{}, fmt.Errorf("[in services.GetPrograms] %w", err)
+	}
+	return programs, nil
+}
+
+func (s ProgramService) GetProgram(ctx context.Context, id int) (models.Program, error) {
+	var program models.Program
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT "id", "name"
+		FROM "program"
+		WHERE "id" = $1
+	`, id).Scan(&program.ID, &program.Name)
+	if err != nil {
+		return models.Program{}, fmt.Errorf("[in services.GetProgram] failed to get program: %w", classify(err))
+	}
+
+	programs := []models.Program{program}
+	if err := getRequirements(ctx, s.Database, programs); err != nil {
+		return models.Program{}, fmt.Errorf("[in services.GetProgram] %w", err)
+	}
+	return programs[0], nil
+}
+
+// CreateProgram creates a program with its requirements. Reusing the name
+// of another program is a conflict and requiring unknown courses is an
+// invalid reference.
+func (s ProgramService) CreateProgram(ctx context.Context, program models.Program) (models.Program, error) {
+	tx, err := s.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Program{}, fmt.Errorf("[in services.CreateProgram] failed to start transaction: %w", classify(err))
+	}
+
+	err = tx.QueryRowContext(ctx, `
+	INSERT INTO "program" (name)
+	VALUES ($1)
+	RETURNING "id"
+	`, program.Name).Scan(&program.ID)
+	if err != nil {
+		tx.Rollback()
+		return models.Program{}, fmt.Errorf("[in services.CreateProgram] failed to create program: %w", classify(err))
+	}
+
+	if err := setRequirements(ctx, tx, &program); err != nil {
+		tx.Rollback()
+		return models.Program{}, fmt.Errorf("[in services.CreateProgram] %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Program{}, fmt.Errorf("[in services.CreateProgram] failed to commit transaction: %w", classify(err))
+	}
+	return program, nil
+}
+
+// UpdateProgram renames the program with the given ID and replaces its
+// requirements. The audits of the students who declared it follow the new
+// requirements straight away.
+func (s ProgramService) UpdateProgram(ctx context.Context, id int, program models.Program) (models.Program, error) {
+	tx, err := s.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Program{}, fmt.Errorf("[in services.UpdateProgram] failed to start transaction: %w", classify(err))
+	}
+
+	result, err := tx.ExecContext(ctx, `
+	UPDATE "program"
+	SET "name" = $1
+	WHERE "id" = $2
+	`, program.Name, id)
+	if err != nil {
+		tx.Rollback()
+		return models.Program{}, fmt.Errorf("[in services.UpdateProgram] failed to update program: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return models.Program{}, fmt.Errorf("[in services.UpdateProgram] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return models.Program{}, fmt.Errorf("[in services.UpdateProgram] program with ID %d does not exist: %w", id, ErrNotFound)
+	}
+
+	program.ID = id
+	if err := setRequirements(ctx, tx, &program); err != nil {
+		tx.Rollback()
+		return models.Program{}, fmt.Errorf("[in services.UpdateProgram] %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Program{}, fmt.Errorf("[in services.UpdateProgram] failed to commit transaction: %w", classify(err))
+	}
+	return program, nil
+}
+
+// DeleteProgram deletes the program with the given ID. Programs that
+// students have declared cannot be deleted.
+func (s ProgramService) DeleteProgram(ctx context.Context, id int) error {
+	result, err := s.Database.ExecContext(ctx, `
+	DELETE FROM "program"
+	WHERE "id" = $1
+	`, id)
+	if err != nil {
+		err = classify(err)
+		if errors.Is(err, ErrInvalidReference) {
+			err = withKind(ErrConflict, err)
+		}
+		return fmt.Errorf("[in services.DeleteProgram] failed to delete program: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteProgram] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.DeleteProgram] program with ID %d does not exist: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// getRequirements fills in the required courses and elective pools of the
+// given programs using one query for each.
+func getRequirements(ctx context.Context, q queryer, programs []models.Program) error {
+	ids := make([]int, len(programs))
+	index := make(map[int]int, len(programs))
+	for i := range programs {
+		ids[i] = programs[i].ID
+		index[programs[i].ID] = i
+		programs[i].RequiredCourses = []int{}
+		programs[i].ElectivePools = []models.ElectivePool{}
+	}
+
+	rows, err := q.QueryContext(ctx, `
+	SELECT program_id, course_id
+		FROM program_course
+		WHERE program_id = ANY($1)
+		ORDER BY program_id, course_id
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get required courses: %w", classify(err))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var programID, courseID int
+		if err := rows.Scan(&programID, &courseID); err != nil {
+			return fmt.Errorf("failed to scan required course: %w", classify(err))
+		}
+		program := &programs[index[programID]]
+		program.RequiredCourses = append(program.RequiredCourses, courseID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to scan required courses: %w", classify(err))
+	}
+
+	pools, err := q.QueryContext(ctx, `
+	SELECT p.program_id, p.id, p.name, p.min_credits, pc.course_id
+		FROM elective_pool p
+		LEFT JOIN elective_pool_course pc ON pc.pool_id = p.id
+		WHERE p.program_id = ANY($1)
+		ORDER BY p.program_id, p.id, pc.course_id
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get elective pools: %w", classify(err))
+	}
+	defer pools.Close()
+	for pools.Next() {
+		var programID int
+		var pool models.ElectivePool
+		var courseID *int
+		if err := pools.Scan(&programID, &pool.ID, &pool.Name, &pool.MinCredits, &courseID); err != nil {
+			return fmt.Errorf("failed to scan elective pool: %w", classify(err))
+		}
+		program := &programs[index[programID]]
+		last := len(program.ElectivePools) - 1
+		if last < 0 || program.ElectivePools[last].ID != pool.ID {
+			pool.Courses = []int{}
+			program.ElectivePools = append(program.ElectivePools, pool)
+			last++
+		}
+		if courseID != nil {
+			program.ElectivePools[last].Courses = append(program.ElectivePools[last].Courses, *courseID)
+		}
+	}
+	if err := pools.Err(); err != nil {
+		return fmt.Errorf("failed to scan elective pools: %w", classify(err))
+	}
+	return nil
+}
+
+// setRequirements replaces the required courses and elective pools of the
+// program, setting the IDs of the new pools.
+func setRequirements(ctx context.Context, tx *sql.Tx, program *models.Program) error {
+	if program.RequiredCourses == nil {
+		program.RequiredCourses = []int{}
+	}
+	if program.ElectivePools == nil {
+		program.ElectivePools = []models.ElectivePool{}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM program_course WHERE program_id = $1`, program.ID); err != nil {
+		return fmt.Errorf("failed to remove required courses: %w", classify(err))
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM elective_pool WHERE program_id = $1`, program.ID); err != nil {
+		return fmt.Errorf("failed to remove elective pools: %w", classify(err))
+	}
+
+	if len(program.RequiredCourses) > 0 {
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO program_course (program_id, course_id)
+		SELECT $1, unnest($2::int[])
+		`, program.ID, pq.Array(program.RequiredCourses))
+		if err != nil {
+			return fmt.Errorf("failed to add required courses: %w", classify(err))
+		}
+	}
+
+	for i := range program.ElectivePools {
+		pool := &program.ElectivePools[i]
+		if pool.Courses == nil {
+			pool.Courses = []int{}
+		}
+		err := tx.QueryRowContext(ctx, `
+		INSERT INTO elective_pool (program_id, name, min_credits)
+		VALUES ($1, $2, $3)
+		RETURNING id
+		`, program.ID, pool.Name, pool.MinCredits).Scan(&pool.ID)
+		if err != nil {
+			return fmt.Errorf("failed to add elective pool: %w", classify(err))
+		}
+		_, err = tx.ExecContext(ctx, `
+		INSERT INTO elective_pool_course (pool_id, course_id)
+		SELECT $1, unnest($2::int[])
+		`, pool.ID, pq.Array(pool.Courses))
+		if err != nil {
+			return fmt.Errorf("failed to add elective pool courses: %w", classify(err))
+		}
+	}
+	return nil
+}
+
+// GetDeclaration returns the program the student with the given ID has
+// declared. Students who have not declared one give ErrNotFound, as do
+// unknown people.
+func (s ProgramService) GetDeclaration(ctx context.Context, personID int) (models.ProgramDeclaration, error) {
+	declaration := models.ProgramDeclaration{PersonID: personID}
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT program_id, declared_at FROM student_program WHERE student_id = $1
+	`, personID).Scan(&declaration.ProgramID, &declaration.DeclaredAt)
+	if err != nil {
+		return models.ProgramDeclaration{}, fmt.Errorf("[in services.GetDeclaration] person %d has not declared a program: %w", personID, classify(err))
+	}
+	return declaration, nil
+}
+
+// DeclareProgram makes the program with ID programID the program of the
+// student with ID personID, replacing any program they declared before. An
+// unknown student gives ErrNotFound and an unknown program
+// ErrInvalidReference; declaring a program for someone who is not a student
+// is a constraint violation.
+func (s ProgramService) DeclareProgram(ctx context.Context, personID, programID int) (models.ProgramDeclaration, error) {
+	var personType sql.NullString
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT (SELECT type FROM person WHERE id = $1)
+	`, personID).Scan(&personType)
+	if err != nil {
+		return models.ProgramDeclaration{}, fmt.Errorf("[in services.DeclareProgram] failed to get person: %w", classify(err))
+	}
+	switch {
+	case !personType.Valid:
+		return models.ProgramDeclaration{}, fmt.Errorf("[in services.DeclareProgram] person with ID %d does not exist: %w", personID, ErrNotFound)
+	case personType.String != "student":
+		return models.ProgramDeclaration{}, fmt.Errorf("[in services.DeclareProgram] person %d is not a student: %w", personID, ErrConstraintViolation)
+	}
+
+	declaration := models.ProgramDeclaration{PersonID: personID, ProgramID: programID}
+	err = s.Database.QueryRowContext(ctx, `
+	INSERT INTO student_program (student_id, program_id)
+	VALUES ($1, $2)
+	ON CONFLICT (student_id) DO UPDATE
+	SET program_id = EXCLUDED.program_id, declared_at = now()
+	RETURNING declared_at
+	`, personID, programID).Scan(&declaration.DeclaredAt)
+	if err != nil {
+		return models.ProgramDeclaration{}, fmt.Errorf("[in services.DeclareProgram] failed to declare program: %w", classify(err))
+	}
+	return declaration, nil
+}
+
+// RemoveDeclaration withdraws the program declaration of the student with
+// the given ID. It fails with ErrNotFound if they have not declared one.
+func (s ProgramService) RemoveDeclaration(ctx context.Context, personID int) error {
+	result, err := s.Database.ExecContext(ctx, `
+	DELETE FROM student_program
+	WHERE student_id = $1
+	`, personID)
+	if err != nil {
+		return fmt.Errorf("[in services.RemoveDeclaration] failed to remove declaration: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.RemoveDeclaration] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.RemoveDeclaration] person %d has not declared a program: %w", personID, ErrNotFound)
+	}
+	return nil
+}
+
+// GetDegreeAudit audits the student with the given ID against the program
+// they declared. Students who have not declared a program give ErrNotFound.
+// A course is completed as defined by completedCourse, and in progress
+// while the student takes it without a grade in a term that has not ended
+// yet.
+func (s ProgramService) GetDegreeAudit(ctx context.Context, personID int) (models.DegreeAudit, error) {
+	var program models.Program
+	err := s.Database.QueryRowContext(ctx, `
+	SELECT p.id, p.name
+		FROM student_program sp
+		JOIN program p ON p.id = sp.program_id
+		WHERE sp.student_id = $1
+	`, personID).Scan(&program.ID, &program.Name)
+	if err != nil {
+		return models.DegreeAudit{}, fmt.Errorf("[in services.GetDegreeAudit] person %d has not declared a program: %w", personID, classify(err))
+	}
+
+	programs := []models.Program{program}
+	if err := getRequirements(ctx, s.Database, programs); err != nil {
+		return models.DegreeAudit{}, fmt.Errorf("[in services.GetDegreeAudit] %w", err)
+	}
+	program = programs[0]
+
+	courseIDs := append([]int{}, program.RequiredCourses...)
+	for _, pool := range program.ElectivePools {
+		courseIDs = append(courseIDs, pool.Courses...)
+	}
+
+	rows, err := s.Database.QueryContext(ctx, `
+	SELECT c.id, c.code, c.name, c.credits,
+		CASE
+			WHEN bool_or(`+completedCourse("pc")+`) THEN 'completed'
+			WHEN bool_or(pc.person_id IS NOT NULL AND pc.grade IS NULL AND (t.id IS NULL OR t.end_date >= CURRENT_DATE)) THEN 'in_progress'
+			ELSE 'outstanding'
+		END
+		FROM course c
+		LEFT JOIN person_course pc ON pc.course_id = c.id AND pc.person_id = $1 AND pc.role = 'student'
+		LEFT JOIN term t ON t.id = pc.term_id
+		WHERE c.id = ANY($2)
+		GROUP BY c.id
+		ORDER BY c.id
+	`, personID, pq.Array(distinctIDs(courseIDs)))
+	if err != nil {
+		return models.DegreeAudit{}, fmt.Errorf("[in services.GetDegreeAudit] failed to get courses: %w", classify(err))
+	}
+	defer rows.Close()
+
+	courses := make(map[int]models.AuditCourse)
+	for rows.Next() {
+		var course models.AuditCourse
+		if err := rows.Scan(&course.CourseID, &course.Code, &course.Name, &course.Credits, &course.Status); err != nil {
+			return models.DegreeAudit{}, fmt.Errorf("[in services.GetDegreeAudit] failed to scan course: %w", classify(err))
+		}
+		courses[course.CourseID] = course
+	}
+	if err := rows.Err(); err != nil {
+		return models.DegreeAudit{}, fmt.Errorf("[in services.GetDegreeAudit] failed to scan courses: %w", classify(err))
+	}
+
+	audit := auditProgram(program, courses)
+	audit.PersonID = personID
+	return audit, nil
+}
+
+// auditProgram checks the requirements of the program against the status of
+// its courses. A completed course counts towards one requirement only:
+// required courses come first, then the elective pools in order. A pool
+// stops taking courses once it is satisfied, so later pools can count the
+// courses it does not need.
+func auditProgram(program models.Program, courses map[int]models.AuditCourse) models.DegreeAudit {
+	audit := models.DegreeAudit{
+		ProgramID:       program.ID,
+		ProgramName:     program.Name,
+		Complete:        true,
+		RequiredCourses: []models.AuditCourse{},
+		ElectivePools:   []models.PoolAudit{},
+	}
+
+	counted := make(map[int]bool)
+	for _, id := range program.RequiredCourses {
+		course := courses[id]
+		if course.Status != models.AuditCompleted {
+			audit.Complete = false
+		}
+		counted[id] = true
+		audit.RequiredCourses = append(audit.RequiredCourses, course)
+	}
+
+	for _, pool := range program.ElectivePools {
+		result := models.PoolAudit{
+			PoolID:     pool.ID,
+			Name:       pool.Name,
+			MinCredits: pool.MinCredits,
+			Counted:    []models.AuditCourse{},
+			Remaining:  []models.AuditCourse{},
+		}
+		for _, id := range pool.Courses {
+			course := courses[id]
+			if counted[id] || course.Status != models.AuditCompleted || result.EarnedCredits >= pool.MinCredits {
+				continue
+			}
+			counted[id] = true
+			result.EarnedCredits += course.Credits
+			result.Counted = append(result.Counted, course)
+		}
+		result.Satisfied = result.EarnedCredits >= pool.MinCredits
+		if !result.Satisfied {
+			audit.Complete = false
+			result.RemainingCredits = pool.MinCredits - result.EarnedCredits
+			for _, id := range pool.Courses {
+				if course := courses[id]; !counted[id] && course.Status != models.AuditCompleted {
+					result.Remaining = append(result.Remaining, course)
+				}
+			}
+		}
+		audit.ElectivePools = append(audit.ElectivePools, result)
+	}
+	return audit
+}
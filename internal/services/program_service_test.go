@@ -0,0 +1,256 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var auditCourseColumns = []string{"id", "code", "name", "credits", "status"}
+
+func TestNewProgramService(t *testing.T) {
+	var mockDB *sql.DB
+
+	programService := services.NewProgramService(mockDB)
+
+	require.NotNil(t, programService)
+	require.Equal(t, mockDB, programService.Database)
+}
+
+func TestGetProgram(t *testing.T) {
+	service, mock := newMockProgramService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT "id", "name" FROM "program" WHERE "id" = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Computer Science"))
+	expectRequirements(mock, []int{1})
+
+	program, err := service.GetProgram(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, models.Program{
+		ID:              1,
+		Name:            "Computer Science",
+		RequiredCourses: []int{1, 2},
+		ElectivePools: []models.ElectivePool{
+			{ID: 1, Name: "Design electives", MinCredits: 3, Courses: []int{3, 4}},
+			{ID: 2, Name: "Seminars", MinCredits: 2, Courses: []int{}},
+		},
+	}, program)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProgram(t *testing.T) {
+	program := models.Program{
+		Name:            "Computer Science",
+		RequiredCourses: []int{1, 2},
+		ElectivePools:   []models.ElectivePool{{Name: "Design electives", MinCredits: 3, Courses: []int{3}}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockProgramService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "program" \(name\) VALUES \(\$1\) RETURNING "id"`).
+			WithArgs("Computer Science").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectExec(`DELETE FROM program_course WHERE program_id = \$1`).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM elective_pool WHERE program_id = \$1`).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO program_course \(program_id, course_id\) SELECT \$1, unnest\(\$2::int\[\]\)`).
+			WithArgs(3, pq.Array([]int{1, 2})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(`INSERT INTO elective_pool \(program_id, name, min_credits\) VALUES \(\$1, \$2, \$3\) RETURNING id`).
+			WithArgs(3, "Design electives", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectExec(`INSERT INTO elective_pool_course \(pool_id, course_id\) SELECT \$1, unnest\(\$2::int\[\]\)`).
+			WithArgs(5, pq.Array([]int{3})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		created, err := service.CreateProgram(context.Background(), program)
+		require.NoError(t, err)
+		require.Equal(t, 3, created.ID)
+		require.Equal(t, 5, created.ElectivePools[0].ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		service, mock := newMockProgramService(t)
+		defer service.Database.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "program" \(name\) VALUES \(\$1\) RETURNING "id"`).
+			WithArgs("Computer Science").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectExec(`DELETE FROM program_course WHERE program_id = \$1`).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM elective_pool WHERE program_id = \$1`).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO program_course \(program_id, course_id\) SELECT \$1, unnest\(\$2::int\[\]\)`).
+			WithArgs(3, pq.Array([]int{1, 2})).
+			WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
+
+		_, err := service.CreateProgram(context.Background(), program)
+		require.ErrorIs(t, err, services.ErrInvalidReference)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateProgramNotFound(t *testing.T) {
+	service, mock := newMockProgramService(t)
+	defer service.Database.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "program" SET "name" = \$1 WHERE "id" = \$2`).
+		WithArgs("Computer Science", 9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := service.UpdateProgram(context.Background(), 9, models.Program{Name: "Computer Science"})
+	require.ErrorIs(t, err, services.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteProgramDeclared(t *testing.T) {
+	service, mock := newMockProgramService(t)
+	defer service.Database.Close()
+
+	mock.ExpectExec(`DELETE FROM "program" WHERE "id" = \$1`).
+		WithArgs(1).
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	err := service.DeleteProgram(context.Background(), 1)
+	require.ErrorIs(t, err, services.ErrConflict)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeclareProgram(t *testing.T) {
+	declared := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		personType    any
+		expectInsert  bool
+		insertError   error
+		expectedError error
+	}{
+		{name: "Success", personType: "student", expectInsert: true},
+		{name: "Unknown Program", personType: "student", expectInsert: true, insertError: &pq.Error{Code: "23503"}, expectedError: services.ErrInvalidReference},
+		{name: "Not A Student", personType: "professor", expectedError: services.ErrConstraintViolation},
+		{name: "Person Not Found", personType: nil, expectedError: services.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockProgramService(t)
+			defer service.Database.Close()
+
+			mock.ExpectQuery(`SELECT \(SELECT type FROM person WHERE id = \$1\)`).
+				WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"type"}).AddRow(tt.personType))
+			if tt.expectInsert {
+				insert := mock.ExpectQuery(`INSERT INTO student_program \(student_id, program_id\) VALUES \(\$1, \$2\) ON CONFLICT \(student_id\) DO UPDATE`).
+					WithArgs(3, 1)
+				if tt.insertError != nil {
+					insert.WillReturnError(tt.insertError)
+				} else {
+					insert.WillReturnRows(sqlmock.NewRows([]string{"declared_at"}).AddRow(declared))
+				}
+			}
+
+			declaration, err := service.DeclareProgram(context.Background(), 3, 1)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, models.ProgramDeclaration{PersonID: 3, ProgramID: 1, DeclaredAt: declared}, declaration)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetDegreeAudit(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockProgramService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT p.id, p.name FROM student_program sp JOIN program p ON p.id = sp.program_id WHERE sp.student_id = \$1`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Computer Science"))
+		expectRequirements(mock, []int{1})
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, c.credits, CASE WHEN bool_or\(pc.role = 'student' AND pc.grade_points > 0\) THEN 'completed' .* FROM course c LEFT JOIN person_course pc .* WHERE c.id = ANY\(\$2\) GROUP BY c.id ORDER BY c.id`).
+			WithArgs(3, pq.Array([]int{1, 2, 3, 4})).
+			WillReturnRows(sqlmock.NewRows(auditCourseColumns).
+				AddRow(1, "CS-101", "Programming", 4, "completed").
+				AddRow(2, "CS-201", "Databases", 3, "in_progress").
+				AddRow(3, "DES-110", "UI Design", 3, "completed").
+				AddRow(4, "DES-210", "Typography", 3, "completed"))
+
+		audit, err := service.GetDegreeAudit(context.Background(), 3)
+		require.NoError(t, err)
+
+		programming := models.AuditCourse{CourseID: 1, Code: "CS-101", Name: "Programming", Credits: 4, Status: models.AuditCompleted}
+		databases := models.AuditCourse{CourseID: 2, Code: "CS-201", Name: "Databases", Credits: 3, Status: models.AuditInProgress}
+		design := models.AuditCourse{CourseID: 3, Code: "DES-110", Name: "UI Design", Credits: 3, Status: models.AuditCompleted}
+		require.Equal(t, models.DegreeAudit{
+			PersonID:        3,
+			ProgramID:       1,
+			ProgramName:     "Computer Science",
+			Complete:        false,
+			RequiredCourses: []models.AuditCourse{programming, databases},
+			ElectivePools: []models.PoolAudit{
+				// UI Design satisfies the design pool on its own, so
+				// Typography is not counted towards it.
+				{PoolID: 1, Name: "Design electives", MinCredits: 3, EarnedCredits: 3, Satisfied: true, Counted: []models.AuditCourse{design}, Remaining: []models.AuditCourse{}},
+				{PoolID: 2, Name: "Seminars", MinCredits: 2, RemainingCredits: 2, Counted: []models.AuditCourse{}, Remaining: []models.AuditCourse{}},
+			},
+		}, audit)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Declared Program", func(t *testing.T) {
+		service, mock := newMockProgramService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT p.id, p.name FROM student_program sp JOIN program p ON p.id = sp.program_id WHERE sp.student_id = \$1`).
+			WithArgs(3).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := service.GetDegreeAudit(context.Background(), 3)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// expectRequirements expects the requirement queries of program 1: courses
+// 1 and 2 are required, a design pool offers courses 3 and 4 and a seminar
+// pool has no courses yet.
+func expectRequirements(mock sqlmock.Sqlmock, programIDs []int) {
+	mock.ExpectQuery(`SELECT program_id, course_id FROM program_course WHERE program_id = ANY\(\$1\)`).
+		WithArgs(pq.Array(programIDs)).
+		WillReturnRows(sqlmock.NewRows([]string{"program_id", "course_id"}).AddRow(1, 1).AddRow(1, 2))
+	mock.ExpectQuery(`SELECT p.program_id, p.id, p.name, p.min_credits, pc.course_id FROM elective_pool p LEFT JOIN elective_pool_course pc`).
+		WithArgs(pq.Array(programIDs)).
+		WillReturnRows(sqlmock.NewRows([]string{"program_id", "id", "name", "min_credits", "course_id"}).
+			AddRow(1, 1, "Design electives", 3, 3).
+			AddRow(1, 1, "Design electives", 3, 4).
+			AddRow(1, 2, "Seminars", 2, nil))
+}
+
+func newMockProgramService(t *testing.T) (services.ProgramService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.ProgramService{Database: db}
+
+	return service, mock
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+type RoomService struct {
+	Database *sql.DB
+}
+
+func NewRoomService(db *sql.DB) *RoomService {
+	return &RoomService{
+		Database: db,
+	}
+}
+
+// queryer is the part of *sql.DB and *sql.Tx used by reads that run both
+// inside and outside transactions.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// meetingEnrollment counts the students taking the course of meeting m in
+// the current term, counting enrollments not tied to a term.
+var meetingEnrollment = `(
+	SELECT COUNT(DISTINCT e.person_id)
+		FROM person_course e
+		WHERE e.course_id = m.course_id AND e.role = 'student'
+		AND (e.term_id IS NULL OR ` + currentTermCondition("e.term_id") + `)
+	)`
+
+func scanRoom(scan func(dest ...any) error) (models.Room, error) {
+	var room models.Room
+	err := scan(&room.ID, &room.Building, &room.Number, &room.Seats, pq.Array(&room.Features))
+	if room.Features == nil {
+		room.Features = []string{}
+	}
+	return room, err
+}
+
+// GetRooms returns every room ordered by building and number.
+func (s RoomService) GetRooms(ctx context.Context) ([]models.Room, error) {
+	rows, err := s.Database.QueryContext(ctx, `
+	SELECT "id", "building", "number", "seats", "features"
+		FROM "room"
+		ORDER BY "building", "number"
+	`)
+	if err != nil {
+		return []models.Room{}, fmt.Errorf("[in services.GetRooms] failed to get rooms: %w", classify(err))
+	}
+	defer rows.Close()
+
+	rooms := []models.Room{}
+	for rows.Next() {
+		room, err := scanRoom(rows.Scan)
+		if err != nil {
+			return []models.Room{}, fmt.Errorf("[in services.GetRooms] failed to scan room: %w", classify(err))
+		}
+		rooms = append(rooms, room)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Room{}, fmt.Errorf("[in services.GetRooms] failed to scan rooms: %w", classify(err))
+	}
+	return rooms, nil
+}
+
+func (s RoomService) GetRoom(ctx context.Context, id int) (models.Room, error) {
+	room, err := scanRoom(s.Database.QueryRowContext(ctx, `
+	SELECT "id", "building", "number", "seats", "features"
+		FROM "room"
+		WHERE "id" = $1
+	`, id).Scan)
+	if err != nil {
+		return models.Room{}, fmt.Errorf("[in services.GetRoom] failed to get room: %w", classify(err))
+	}
+	return room, nil
+}
+
+// CreateRoom creates a room. Reusing the number of another room in the same
+// building is a conflict.
+func (s RoomService) CreateRoom(ctx context.Context, room models.Room) (models.Room, error) {
+	if room.Features == nil {
+		room.Features = []string{}
+	}
+	err := s.Database.QueryRowContext(ctx, `
+	INSERT INTO "room"
+	(building, number, seats, features)
+	VALUES ($1, $2, $3, $4)
+	RETURNING "id"
+	`, room.Building, room.Number, room.Seats, pq.Array(room.Features)).Scan(&room.ID)
+	if err != nil {
+		return models.Room{}, fmt.Errorf("[in services.CreateRoom] failed to create room: %w", classify(err))
+	}
+	return room, nil
+}
+
+// UpdateRoom updates the room with the given ID. Shrinking a room does not
+// cancel its bookings; courses that no longer fit report room warnings.
+func (s RoomService) UpdateRoom(ctx context.Context, id int, room models.Room) (models.Room, error) {
+	if room.Features == nil {
+		room.Features = []string{}
+	}
+	result, err := s.Database.ExecContext(ctx, `
+	UPDATE "room"
+	SET "building" = $1, "number" = $2, "seats" = $3, "features" = $4
+	WHERE "id" = $5
+	`, room.Building, room.Number, room.Seats, pq.Array(room.Features), id)
+	if err != nil {
+		return models.Room{}, fmt.Errorf("[in services.UpdateRoom] failed to update room: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.Room{}, fmt.Errorf("[in services.UpdateRoom] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return models.Room{}, fmt.Errorf("[in services.UpdateRoom] room with ID %d does not exist: %w", id, ErrNotFound)
+	}
+
+	room.ID = id
+	return room, nil
+}
+
+// DeleteRoom deletes the room with the given ID. Rooms that are still booked
+// for course meetings cannot be deleted.
+func (s RoomService) DeleteRoom(ctx context.Context, id int) error {
+	result, err := s.Database.ExecContext(ctx, `
+	DELETE FROM "room"
+	WHERE "id" = $1
+	`, id)
+	if err != nil {
+		err = classify(err)
+		if errors.Is(err, ErrInvalidReference) {
+			err = withKind(ErrConflict, err)
+		}
+		return fmt.Errorf("[in services.DeleteRoom] failed to delete room: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteRoom] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.DeleteRoom] room with ID %d does not exist: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// GetRoomBookings returns the course meetings booked into the room with the
+// given ID ordered by start time. Bookings of courses with more students in
+// the current term than the room seats are flagged as over capacity.
+func (s RoomService) GetRoomBookings(ctx context.Context, roomID int) ([]models.RoomBooking, error) {
+	var seats int
+	err := s.Database.QueryRowContext(ctx, `SELECT seats FROM room WHERE id = $1`, roomID).Scan(&seats)
+	if err != nil {
+		return []models.RoomBooking{}, fmt.Errorf("[in services.GetRoomBookings] failed to get room: %w", classify(err))
+	}
+
+	rows, err := s.Database.QueryContext(ctx, `
+	SELECT c.id, c.code, c.name, `+meetingEnrollment+`, `+meetingColumns+`
+		FROM course_meeting m
+		JOIN course c ON c.id = m.course_id
+		WHERE m.room_id = $1
+		ORDER BY m.start_time, c.id, m.id
+	`, roomID)
+	if err != nil {
+		return []models.RoomBooking{}, fmt.Errorf("[in services.GetRoomBookings] failed to get bookings: %w", classify(err))
+	}
+	defer rows.Close()
+
+	bookings := []models.RoomBooking{}
+	for rows.Next() {
+		var booking models.RoomBooking
+		booking.Meeting, err = scanMeeting(rows.Scan, &booking.CourseID, &booking.CourseCode, &booking.CourseName, &booking.Enrolled)
+		if err != nil {
+			return []models.RoomBooking{}, fmt.Errorf("[in services.GetRoomBookings] failed to scan booking: %w", classify(err))
+		}
+		booking.OverCapacity = booking.Enrolled > seats
+		bookings = append(bookings, booking)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.RoomBooking{}, fmt.Errorf("[in services.GetRoomBookings] failed to scan bookings: %w", classify(err))
+	}
+	return bookings, nil
+}
+
+// checkRoomConflicts fails with a RoomConflictError if a meeting of the
+// course is booked into one of rooms while another meeting, of another
+// course or of the same one, takes place there. Meetings overlap when they
+// share a day and their times intersect. The rooms are locked first so
+// concurrent bookings of the same room are checked one after the other;
+// FOR NO KEY UPDATE does not wait for the key share locks taken by the
+// meeting inserts.
+func checkRoomConflicts(ctx context.Context, tx *sql.Tx, courseID int, rooms []int) error {
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM room WHERE id = ANY($1) ORDER BY id FOR NO KEY UPDATE`, pq.Array(rooms)); err != nil {
+		return fmt.Errorf("failed to lock rooms: %w", classify(err))
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+	SELECT c.id, c.name, `+meetingColumns+`
+		FROM course_meeting m
+		JOIN course c ON c.id = m.course_id
+		WHERE m.room_id = ANY($2)
+		AND EXISTS (
+			SELECT 1
+			FROM course_meeting n
+			WHERE n.course_id = $1
+			AND n.id <> m.id
+			AND n.room_id = m.room_id
+			AND `+meetingsOverlap("n", "m")+`
+		)
+		ORDER BY c.id, m.id
+	`, courseID, pq.Array(rooms))
+	if err != nil {
+		return fmt.Errorf("failed to check room bookings of course %d: %w", courseID, classify(err))
+	}
+	defer rows.Close()
+
+	var conflicts []models.ScheduleConflict
+	for rows.Next() {
+		var conflict models.ScheduleConflict
+		conflict.Meeting, err = scanMeeting(rows.Scan, &conflict.CourseID, &conflict.CourseName)
+		if err != nil {
+			return fmt.Errorf("failed to scan room conflict: %w", classify(err))
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to scan room conflicts: %w", classify(err))
+	}
+	if len(conflicts) > 0 {
+		return &RoomConflictError{CourseID: courseID, Conflicts: conflicts}
+	}
+	return nil
+}
+
+// getRoomWarnings returns the rooms each of the given courses is booked
+// into that seat fewer people than take the course in the current term,
+// keyed by course ID and using a single query. Courses that fit their rooms
+// are missing from the map.
+func getRoomWarnings(ctx context.Context, q queryer, courseIDs []int) (map[int][]models.RoomWarning, error) {
+	rows, err := q.QueryContext(ctx, `
+	SELECT course_id, room_id, seats, enrolled FROM (
+		SELECT DISTINCT m.course_id, r.id AS room_id, r.seats, `+meetingEnrollment+` AS enrolled
+			FROM course_meeting m
+			JOIN room r ON r.id = m.room_id
+			WHERE m.course_id = ANY($1)
+		) w
+		WHERE enrolled > seats
+		ORDER BY course_id, room_id
+	`, pq.Array(courseIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room warnings: %w", classify(err))
+	}
+	defer rows.Close()
+
+	warnings := make(map[int][]models.RoomWarning)
+	for rows.Next() {
+		var courseID int
+		var warning models.RoomWarning
+		if err := rows.Scan(&courseID, &warning.RoomID, &warning.Seats, &warning.Enrolled); err != nil {
+			return nil, fmt.Errorf("failed to scan room warning: %w", classify(err))
+		}
+		warnings[courseID] = append(warnings[courseID], warning)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan room warnings: %w", classify(err))
+	}
+	return warnings, nil
+}
+
+// bookedMeetings reports whether any of the meetings is booked into a room.
+func bookedMeetings(meetings []models.Meeting) bool {
+	for _, meeting := range meetings {
+		if meeting.RoomID != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var lectureHall = models.Room{Building: "Main", Number: "Hall A", Seats: 120, Features: []string{"projector"}}
+
+func TestNewRoomService(t *testing.T) {
+	var mockDB *sql.DB
+
+	roomService := services.NewRoomService(mockDB)
+
+	require.NotNil(t, roomService)
+	require.Equal(t, mockDB, roomService.Database)
+}
+
+func TestGetRooms(t *testing.T) {
+	service, mock := newMockRoomService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT "id", "building", "number", "seats", "features" FROM "room" ORDER BY "building", "number"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "building", "number", "seats", "features"}).
+			AddRow(1, "Main", "Hall A", 120, pq.Array([]string{"projector"})).
+			AddRow(2, "Science", "Lab 1", 24, pq.Array([]string{})))
+
+	rooms, err := service.GetRooms(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []models.Room{
+		{ID: 1, Building: "Main", Number: "Hall A", Seats: 120, Features: []string{"projector"}},
+		{ID: 2, Building: "Science", Number: "Lab 1", Seats: 24, Features: []string{}},
+	}, rooms)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRoom(t *testing.T) {
+	service, mock := newMockRoomService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`FROM "room" WHERE "id" = \$1`).
+		WithArgs(9).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := service.GetRoom(context.Background(), 9)
+	require.ErrorIs(t, err, services.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateRoom(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`INSERT INTO "room" \(building, number, seats, features\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING "id"`).
+			WithArgs("Main", "Hall A", 120, pq.Array([]string{"projector"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		room, err := service.CreateRoom(context.Background(), lectureHall)
+		require.NoError(t, err)
+		require.Equal(t, 1, room.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate Number", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`INSERT INTO "room"`).
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		_, err := service.CreateRoom(context.Background(), lectureHall)
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateRoom(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`UPDATE "room" SET "building" = \$1, "number" = \$2, "seats" = \$3, "features" = \$4 WHERE "id" = \$5`).
+			WithArgs("Main", "Hall A", 120, pq.Array([]string{"projector"}), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		room, err := service.UpdateRoom(context.Background(), 1, lectureHall)
+		require.NoError(t, err)
+		require.Equal(t, 1, room.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`UPDATE "room"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := service.UpdateRoom(context.Background(), 9, lectureHall)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteRoom(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`DELETE FROM "room" WHERE "id" = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, service.DeleteRoom(context.Background(), 1))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Still Booked", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectExec(`DELETE FROM "room" WHERE "id" = \$1`).
+			WithArgs(1).
+			WillReturnError(&pq.Error{Code: "23503"})
+
+		err := service.DeleteRoom(context.Background(), 1)
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetRoomBookings(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT seats FROM room WHERE id = \$1`).
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"seats"}).AddRow(2))
+		mock.ExpectQuery(`SELECT c.id, c.code, c.name, \( SELECT COUNT\(DISTINCT e.person_id\) .* FROM course_meeting m JOIN course c ON c.id = m.course_id WHERE m.room_id = \$1 ORDER BY m.start_time, c.id, m.id`).
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "enrolled", "days", "start_time", "end_time", "location", "room_id"}).
+				AddRow(3, "DES-110", "UI Design", 3, pq.Array([]string{"mon", "wed"}), "11:00", "12:30", "Studio 3", 4).
+				AddRow(1, "CS-101", "Programming", 1, pq.Array([]string{"fri"}), "14:00", "15:00", "", 4))
+
+		bookings, err := service.GetRoomBookings(context.Background(), 4)
+		require.NoError(t, err)
+		room := 4
+		require.Equal(t, []models.RoomBooking{
+			{
+				CourseID:     3,
+				CourseCode:   "DES-110",
+				CourseName:   "UI Design",
+				Meeting:      models.Meeting{Days: []string{"mon", "wed"}, StartTime: "11:00", EndTime: "12:30", Location: "Studio 3", RoomID: &room},
+				Enrolled:     3,
+				OverCapacity: true,
+			},
+			{
+				CourseID:   1,
+				CourseCode: "CS-101",
+				CourseName: "Programming",
+				Meeting:    models.Meeting{Days: []string{"fri"}, StartTime: "14:00", EndTime: "15:00", RoomID: &room},
+				Enrolled:   1,
+			},
+		}, bookings)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Room Not Found", func(t *testing.T) {
+		service, mock := newMockRoomService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`SELECT seats FROM room WHERE id = \$1`).
+			WithArgs(9).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := service.GetRoomBookings(context.Background(), 9)
+		require.ErrorIs(t, err, services.ErrNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateCourseRoomBooking(t *testing.T) {
+	room := 4
+	meeting := models.Meeting{Days: []string{"mon"}, StartTime: "11:30", EndTime: "12:00", RoomID: &room}
+	course := models.Course{Code: "CS-101", Name: "Programming", Credits: 4, Meetings: []models.Meeting{meeting}}
+
+	expectBooking := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "course"`).
+			WithArgs("CS-101", "Programming", nil, nil, 4, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM course_meeting WHERE course_id = \$1`).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO course_meeting \(course_id, days, start_time, end_time, location, room_id\)`).
+			WithArgs(1, pq.Array(meeting.Days), "11:30", "12:00", "", &room).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`SELECT id FROM room WHERE id = ANY\(\$1\) ORDER BY id FOR NO KEY UPDATE`).
+			WithArgs(pq.Array([]int{4})).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	t.Run("Double Booked", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		expectBooking(mock)
+		mock.ExpectQuery(`FROM course_meeting m JOIN course c ON c.id = m.course_id WHERE m.room_id = ANY\(\$2\) AND EXISTS \( SELECT 1 FROM course_meeting n WHERE n.course_id = \$1 AND n.id <> m.id AND n.room_id = m.room_id`).
+			WithArgs(1, pq.Array([]int{4})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "days", "start_time", "end_time", "location", "room_id"}).
+				AddRow(3, "UI Design", pq.Array([]string{"mon", "wed"}), "11:00", "12:30", "Studio 3", 4))
+		mock.ExpectRollback()
+
+		_, err := service.UpdateCourse(context.Background(), 1, course)
+		require.ErrorIs(t, err, services.ErrConflict)
+		var booked *services.RoomConflictError
+		require.True(t, errors.As(err, &booked))
+		require.Equal(t, []models.ScheduleConflict{{
+			CourseID:   3,
+			CourseName: "UI Design",
+			Meeting:    models.Meeting{Days: []string{"mon", "wed"}, StartTime: "11:00", EndTime: "12:30", Location: "Studio 3", RoomID: &room},
+		}}, booked.Conflicts)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Own Meetings Overlap", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		expectBooking(mock)
+		mock.ExpectQuery(`WHERE m.room_id = ANY\(\$2\) AND EXISTS \( SELECT 1 FROM course_meeting n WHERE n.course_id = \$1 AND n.id <> m.id`).
+			WithArgs(1, pq.Array([]int{4})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "days", "start_time", "end_time", "location", "room_id"}).
+				AddRow(1, "Programming", pq.Array([]string{"mon"}), "11:00", "12:00", "", 4))
+		mock.ExpectRollback()
+
+		_, err := service.UpdateCourse(context.Background(), 1, course)
+		var booked *services.RoomConflictError
+		require.True(t, errors.As(err, &booked))
+		require.Equal(t, 1, booked.Conflicts[0].CourseID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Room Too Small", func(t *testing.T) {
+		service, mock := newMockCourseService(t)
+		defer service.Database.Close()
+
+		expectBooking(mock)
+		mock.ExpectQuery(`FROM course_meeting m JOIN course c ON c.id = m.course_id WHERE m.room_id = ANY\(\$2\)`).
+			WithArgs(1, pq.Array([]int{4})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "days", "start_time", "end_time", "location", "room_id"}))
+		expectPromotion(mock, 1, nil)
+		mock.ExpectQuery(`SELECT course_id, room_id, seats, enrolled FROM \(.*\) w WHERE enrolled > seats ORDER BY course_id, room_id`).
+			WithArgs(pq.Array([]int{1})).
+			WillReturnRows(sqlmock.NewRows([]string{"course_id", "room_id", "seats", "enrolled"}).AddRow(1, 4, 2, 3))
+		mock.ExpectCommit()
+
+		updated, err := service.UpdateCourse(context.Background(), 1, course)
+		require.NoError(t, err)
+		require.Equal(t, []models.RoomWarning{{RoomID: 4, Seats: 2, Enrolled: 3}}, updated.RoomWarnings)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func newMockRoomService(t *testing.T) (services.RoomService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.RoomService{Database: db}
+
+	return service, mock
+}
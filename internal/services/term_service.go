@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+)
+
+type TermService struct {
+	Database *sql.DB
+}
+
+func NewTermService(db *sql.DB) *TermService {
+	return &TermService{
+		Database: db,
+	}
+}
+
+// GetTerms returns every term in chronological order. There are only ever a
+// handful of terms, so the list is not paginated.
+func (t TermService) GetTerms(ctx context.Context) ([]models.Term, error) {
+	rows, err := t.Database.QueryContext(ctx, `
+	SELECT "id", "name", "start_date", "end_date"
+		FROM "term"
+		ORDER BY "start_date", "id"
+	`)
+	if err != nil {
+		return []models.Term{}, fmt.Errorf("[in services.GetTerms] failed to get terms: %w", classify(err))
+	}
+	defer rows.Close()
+
+	terms := []models.Term{}
+	for rows.Next() {
+		var term models.Term
+		if err := rows.Scan(&term.ID, &term.Name, &term.StartDate, &term.EndDate); err != nil {
+			return []models.Term{}, fmt.Errorf("[in services.GetTerms] failed to scan term: %w", classify(err))
+		}
+		terms = append(terms, term)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Term{}, fmt.Errorf("[in services.GetTerms] failed to scan terms: %w", classify(err))
+	}
+	return terms, nil
+}
+
+func (t TermService) GetTerm(ctx context.Context, id int) (models.Term, error) {
+	var term models.Term
+	err := t.Database.QueryRowContext(ctx, `
+	SELECT "id", "name", "start_date", "end_date"
+		FROM "term"
+		WHERE "id" = $1
+	`, id).Scan(&term.ID, &term.Name, &term.StartDate, &term.EndDate)
+	if err != nil {
+		return models.Term{}, fmt.Errorf("[in services.GetTerm] failed to get term: %w", classify(err))
+	}
+	return term, nil
+}
+
+func (t TermService) CreateTerm(ctx context.Context, term models.Term) (models.Term, error) {
+	err := t.Database.QueryRowContext(ctx, `
+	INSERT INTO "term"
+	(name, start_date, end_date)
+	VALUES ($1, $2, $3)
+	RETURNING "id"
+	`, term.Name, term.StartDate, term.EndDate).Scan(&term.ID)
+	if err != nil {
+		return models.Term{}, fmt.Errorf("[in services.CreateTerm] failed to create term: %w", classify(err))
+	}
+	return term, nil
+}
+
+func (t TermService) UpdateTerm(ctx context.Context, id int, term models.Term) (models.Term, error) {
+	result, err := t.Database.ExecContext(ctx, `
+	UPDATE "term"
+	SET "name" = $1, "start_date" = $2, "end_date" = $3
+	WHERE "id" = $4
+	`, term.Name, term.StartDate, term.EndDate, id)
+	if err != nil {
+		return models.Term{}, fmt.Errorf("[in services.UpdateTerm] failed to update term: %w", classify(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.Term{}, fmt.Errorf("[in services.UpdateTerm] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return models.Term{}, fmt.Errorf("[in services.UpdateTerm] term with ID %d does not exist: %w", id, ErrNotFound)
+	}
+
+	term.ID = id
+	return term, nil
+}
+
+// DeleteTerm deletes the term with the given ID. Terms that still have
+// enrollments cannot be deleted.
+func (t TermService) DeleteTerm(ctx context.Context, id int) error {
+	result, err := t.Database.ExecContext(ctx, `
+	DELETE FROM "term"
+	WHERE "id" = $1
+	`, id)
+	if err != nil {
+		err = classify(err)
+		if errors.Is(err, ErrInvalidReference) {
+			err = withKind(ErrConflict, err)
+		}
+		return fmt.Errorf("[in services.DeleteTerm] failed to delete term: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("[in services.DeleteTerm] failed to get affected rows: %w", classify(err))
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("[in services.DeleteTerm] term with ID %d does not exist: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// Relative terms accepted by ParseTermScope, judged against the database's
+// current date.
+const (
+	TermCurrent = "current"
+	TermPast    = "past"
+	TermFuture  = "future"
+)
+
+// TermScope restricts enrollment listings to a single term, by ID, or to
+// every current, past or future term. The zero value does not restrict
+// anything.
+type TermScope struct {
+	ID   int
+	When string
+}
+
+// ParseTermScope parses the term query parameter, which is either a term ID
+// or one of current, past and future. An empty string is the zero scope.
+func ParseTermScope(s string) (TermScope, error) {
+	switch s {
+	case "":
+		return TermScope{}, nil
+	case TermCurrent, TermPast, TermFuture:
+		return TermScope{When: s}, nil
+	}
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		return TermScope{}, fmt.Errorf("invalid term %q: %w", s, ErrConstraintViolation)
+	}
+	return TermScope{ID: id}, nil
+}
+
+// condition returns a SQL condition restricting column, a term ID column,
+// to the scope. Arguments are appended to args, and the condition is empty
+// for the zero scope.
+func (s TermScope) condition(column string, args []interface{}) (string, []interface{}) {
+	var dates string
+	switch s.When {
+	case TermCurrent:
+		dates = "start_date <= CURRENT_DATE AND end_date >= CURRENT_DATE"
+	case TermPast:
+		dates = "end_date < CURRENT_DATE"
+	case TermFuture:
+		dates = "start_date > CURRENT_DATE"
+	default:
+		if s.ID == 0 {
+			return "", args
+		}
+		args = append(args, s.ID)
+		return fmt.Sprintf("%s = $%d", column, len(args)), args
+	}
+	return fmt.Sprintf("%s IN (SELECT id FROM term WHERE %s)", column, dates), args
+}
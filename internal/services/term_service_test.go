@@ -0,0 +1,176 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/models"
+	"github.com/dchoi22/Go-API-Tech-Challenge/internal/services"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	termStart = time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	termEnd   = time.Date(2026, 12, 18, 0, 0, 0, 0, time.UTC)
+	fallTerm  = models.Term{Name: "Fall 2026", StartDate: models.Date{Time: termStart}, EndDate: models.Date{Time: termEnd}}
+)
+
+func TestNewTermService(t *testing.T) {
+	var mockDB *sql.DB
+
+	termService := services.NewTermService(mockDB)
+
+	require.NotNil(t, termService)
+	require.Equal(t, mockDB, termService.Database)
+}
+
+func TestGetTerms(t *testing.T) {
+	service, mock := newMockTermService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`SELECT "id", "name", "start_date", "end_date" FROM "term" ORDER BY "start_date", "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "start_date", "end_date"}).
+			AddRow(1, "Fall 2026", termStart, termEnd))
+
+	terms, err := service.GetTerms(context.Background())
+	require.NoError(t, err)
+	expected := fallTerm
+	expected.ID = 1
+	require.Equal(t, []models.Term{expected}, terms)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTerm(t *testing.T) {
+	service, mock := newMockTermService(t)
+	defer service.Database.Close()
+
+	mock.ExpectQuery(`FROM "term" WHERE "id" = \$1`).
+		WithArgs(9).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := service.GetTerm(context.Background(), 9)
+	require.ErrorIs(t, err, services.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTerm(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, mock := newMockTermService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`INSERT INTO "term" \(name, start_date, end_date\) VALUES \(\$1, \$2, \$3\) RETURNING "id"`).
+			WithArgs("Fall 2026", "2026-09-01", "2026-12-18").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		term, err := service.CreateTerm(context.Background(), fallTerm)
+		require.NoError(t, err)
+		require.Equal(t, 1, term.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate Name", func(t *testing.T) {
+		service, mock := newMockTermService(t)
+		defer service.Database.Close()
+
+		mock.ExpectQuery(`INSERT INTO "term"`).
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		_, err := service.CreateTerm(context.Background(), fallTerm)
+		require.ErrorIs(t, err, services.ErrConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateTerm(t *testing.T) {
+	service, mock := newMockTermService(t)
+	defer service.Database.Close()
+
+	mock.ExpectExec(`UPDATE "term" SET "name" = \$1, "start_date" = \$2, "end_date" = \$3 WHERE "id" = \$4`).
+		WithArgs("Fall 2026", "2026-09-01", "2026-12-18", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	term, err := service.UpdateTerm(context.Background(), 1, fallTerm)
+	require.NoError(t, err)
+	require.Equal(t, 1, term.ID)
+
+	mock.ExpectExec(`UPDATE "term"`).
+		WithArgs("Fall 2026", "2026-09-01", "2026-12-18", 9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = service.UpdateTerm(context.Background(), 9, fallTerm)
+	require.ErrorIs(t, err, services.ErrNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTerm(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      sql.Result
+		dbError     error
+		expectedErr error
+	}{
+		{name: "Success", result: sqlmock.NewResult(0, 1)},
+		{name: "Not Found", result: sqlmock.NewResult(0, 0), expectedErr: services.ErrNotFound},
+		{name: "Has Enrollments", dbError: &pq.Error{Code: "23503"}, expectedErr: services.ErrConflict},
+		{name: "Database Error", dbError: errors.New("database error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockTermService(t)
+			defer service.Database.Close()
+
+			exec := mock.ExpectExec(`DELETE FROM "term" WHERE "id" = \$1`).WithArgs(1)
+			if tt.dbError != nil {
+				exec.WillReturnError(tt.dbError)
+			} else {
+				exec.WillReturnResult(tt.result)
+			}
+
+			err := service.DeleteTerm(context.Background(), 1)
+			switch {
+			case tt.expectedErr != nil:
+				require.ErrorIs(t, err, tt.expectedErr)
+			case tt.dbError != nil:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestParseTermScope(t *testing.T) {
+	scope, err := services.ParseTermScope("")
+	require.NoError(t, err)
+	require.Equal(t, services.TermScope{}, scope)
+
+	scope, err = services.ParseTermScope("past")
+	require.NoError(t, err)
+	require.Equal(t, services.TermScope{When: services.TermPast}, scope)
+
+	scope, err = services.ParseTermScope("4")
+	require.NoError(t, err)
+	require.Equal(t, services.TermScope{ID: 4}, scope)
+
+	for _, s := range []string{"0", "-1", "soon"} {
+		_, err = services.ParseTermScope(s)
+		require.ErrorIs(t, err, services.ErrConstraintViolation, s)
+	}
+}
+
+func newMockTermService(t *testing.T) (services.TermService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	service := services.TermService{Database: db}
+
+	return service, mock
+}